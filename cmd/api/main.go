@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/auth"
 	"github.com/victorgiudicissi/your-diet/internal/constants"
 	"github.com/victorgiudicissi/your-diet/internal/handler"
+	"github.com/victorgiudicissi/your-diet/internal/mailer"
 	"github.com/victorgiudicissi/your-diet/internal/middleware"
 	"github.com/victorgiudicissi/your-diet/internal/repository"
 	"github.com/victorgiudicissi/your-diet/internal/usecase"
@@ -15,6 +19,11 @@ import (
 func main() {
 	cfg := utils.LoadEnvConfig()
 
+	keySet, err := auth.LoadKeySet(cfg)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+
 	dietRepo, err := repository.NewDietRepository(cfg)
 	if err != nil {
 		log.Fatalf("Failed to connect to MongoDB for diets: %v", err)
@@ -25,20 +34,210 @@ func main() {
 		log.Fatalf("Failed to connect to MongoDB for users: %v", err)
 	}
 
-	createDietUseCase := usecase.NewCreateDiet(dietRepo)
-	updateDietUseCase := usecase.NewUpdateDiet(dietRepo)
-	createUserUseCase := usecase.NewCreateUser(userRepo)
-	loginUseCase := usecase.NewLogin(userRepo)
-	listDietsUseCase := usecase.NewListDiets(dietRepo, userRepo)
+	sessionRepo, err := repository.NewSessionRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for sessions: %v", err)
+	}
+
+	userTokenRepo, err := repository.NewUserTokenRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for user tokens: %v", err)
+	}
+
+	roleRepo, err := repository.NewRoleRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for roles: %v", err)
+	}
+
+	linkRepo, err := repository.NewPatientLinkRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for patient links: %v", err)
+	}
+
+	revisionRepo, err := repository.NewDietRevisionRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for diet revisions: %v", err)
+	}
+
+	foodRepo, err := repository.NewFoodRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for foods: %v", err)
+	}
+
+	measurementRepo, err := repository.NewMeasurementRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for measurements: %v", err)
+	}
+
+	mealLogRepo, err := repository.NewMealLogRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for meal logs: %v", err)
+	}
+
+	dietTemplateRepo, err := repository.NewDietTemplateRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for diet templates: %v", err)
+	}
+
+	exportTemplateRepo, err := repository.NewExportTemplateRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for export templates: %v", err)
+	}
+
+	calendarFeedRepo, err := repository.NewCalendarFeedRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for calendar feeds: %v", err)
+	}
+
+	seedCtx, cancelSeed := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := usecase.NewSyncDefaultRoles(roleRepo).Execute(seedCtx); err != nil {
+		log.Fatalf("Failed to seed default roles: %v", err)
+	}
+	cancelSeed()
+
+	go runDietLifecycle(usecase.NewAdvanceDietLifecycle(dietRepo, revisionRepo), time.Duration(cfg.DietLifecycleIntervalMinutes)*time.Minute)
+
+	var mailSender usecase.Mailer = mailer.NewLogMailer()
+	if cfg.SMTPHost != "" {
+		mailSender = mailer.NewSMTPMailer(cfg)
+	}
+
+	createDietUseCase := usecase.NewCreateDiet(dietRepo, linkRepo, revisionRepo, foodRepo)
+	updateDietUseCase := usecase.NewUpdateDiet(dietRepo, linkRepo, revisionRepo, foodRepo, userRepo)
+	createUserUseCase := usecase.NewCreateUser(userRepo, userTokenRepo, mailSender, cfg.AppBaseURL)
+	loginUseCase := usecase.NewLogin(userRepo, sessionRepo, roleRepo, keySet, cfg.RequireEmailVerification)
+	refreshTokenUseCase := usecase.NewRefreshToken(userRepo, sessionRepo, roleRepo, keySet)
+	logoutUseCase := usecase.NewLogout(sessionRepo)
+	validateSessionUseCase := usecase.NewValidateSession(sessionRepo)
+	requestPasswordResetUseCase := usecase.NewRequestPasswordReset(userRepo, userTokenRepo, mailSender, cfg.AppBaseURL)
+	resetPasswordUseCase := usecase.NewResetPassword(userRepo, userTokenRepo, sessionRepo)
+	requestEmailVerificationUseCase := usecase.NewRequestEmailVerification(userRepo, userTokenRepo, mailSender, cfg.AppBaseURL)
+	verifyEmailUseCase := usecase.NewVerifyEmail(userRepo, userTokenRepo)
+	unlockUserUseCase := usecase.NewUnlockUser(userRepo)
+	listRolesUseCase := usecase.NewListRoles(roleRepo)
+	updateRoleUseCase := usecase.NewUpdateRole(roleRepo)
+	assignUserRoleUseCase := usecase.NewAssignUserRole(userRepo, roleRepo)
+	listNutritionistRequestsUseCase := usecase.NewListNutritionistRequests(userRepo)
+	reviewNutritionistUseCase := usecase.NewReviewNutritionist(userRepo)
+	invitePatientUseCase := usecase.NewInvitePatient(linkRepo, userRepo, mailSender, cfg.AppBaseURL)
+	listPatientsUseCase := usecase.NewListPatients(linkRepo)
+	listNutritionistsUseCase := usecase.NewListNutritionists(linkRepo, userRepo)
+	respondInvitationUseCase := usecase.NewRespondInvitation(linkRepo, userRepo)
+	endPatientLinkUseCase := usecase.NewEndPatientLink(linkRepo, userRepo)
+	listDietsUseCase := usecase.NewListDiets(dietRepo, userRepo, linkRepo, foodRepo)
+	getDietUseCase := usecase.NewGetDiet(dietRepo, userRepo, foodRepo)
+	deleteDietUseCase := usecase.NewDeleteDiet(dietRepo)
+	restoreDietUseCase := usecase.NewRestoreDiet(dietRepo, foodRepo, userRepo)
+	listDietRevisionsUseCase := usecase.NewListDietRevisions(dietRepo, userRepo, revisionRepo)
+	getDietRevisionUseCase := usecase.NewGetDietRevision(dietRepo, userRepo, revisionRepo)
+	diffDietRevisionsUseCase := usecase.NewDiffDietRevisions(dietRepo, userRepo, revisionRepo)
+	rollbackDietUseCase := usecase.NewRollbackDiet(dietRepo, linkRepo, revisionRepo, foodRepo, userRepo)
+	getDietNutritionUseCase := usecase.NewGetDietNutrition(dietRepo, userRepo, foodRepo)
+	searchFoodsUseCase := usecase.NewSearchFoods(foodRepo)
+	getFoodUseCase := usecase.NewGetFood(foodRepo)
+	updateFoodMeasuresUseCase := usecase.NewUpdateFoodMeasures(foodRepo)
+	updateAnthropometricsUseCase := usecase.NewUpdateAnthropometrics(linkRepo, userRepo)
+	getEnergyAssessmentUseCase := usecase.NewGetEnergyAssessment(linkRepo, userRepo)
+	createMeasurementUseCase := usecase.NewCreateMeasurement(measurementRepo, linkRepo, userRepo)
+	listMeasurementsUseCase := usecase.NewListMeasurements(measurementRepo, linkRepo, userRepo)
+	updateMeasurementUseCase := usecase.NewUpdateMeasurement(measurementRepo, linkRepo, userRepo)
+	deleteMeasurementUseCase := usecase.NewDeleteMeasurement(measurementRepo, linkRepo, userRepo)
+	logMealUseCase := usecase.NewLogMeal(dietRepo, userRepo, mealLogRepo)
+	listMealLogsUseCase := usecase.NewListMealLogs(dietRepo, userRepo, mealLogRepo)
+	reviewMealLogUseCase := usecase.NewReviewMealLog(dietRepo, mealLogRepo)
+	deleteMealLogUseCase := usecase.NewDeleteMealLog(dietRepo, userRepo, mealLogRepo)
+	getDietAdherenceUseCase := usecase.NewGetDietAdherence(dietRepo, userRepo, mealLogRepo)
+	getDietScheduleUseCase := usecase.NewGetDietSchedule(dietRepo, userRepo)
+	changeDietStatusUseCase := usecase.NewChangeDietStatus(dietRepo, linkRepo, revisionRepo, foodRepo, userRepo)
+	cloneDietUseCase := usecase.NewCloneDiet(dietRepo, linkRepo, revisionRepo, foodRepo, userRepo)
+	createDietTemplateUseCase := usecase.NewCreateDietTemplate(dietTemplateRepo, foodRepo)
+	listDietTemplatesUseCase := usecase.NewListDietTemplates(dietTemplateRepo, foodRepo)
+	getDietTemplateUseCase := usecase.NewGetDietTemplate(dietTemplateRepo, foodRepo)
+	updateDietTemplateUseCase := usecase.NewUpdateDietTemplate(dietTemplateRepo, foodRepo)
+	deleteDietTemplateUseCase := usecase.NewDeleteDietTemplate(dietTemplateRepo)
+	instantiateDietTemplateUseCase := usecase.NewInstantiateDietTemplate(dietTemplateRepo, dietRepo, linkRepo, revisionRepo, foodRepo, userRepo)
+	getShoppingListUseCase := usecase.NewGetShoppingList(dietRepo, userRepo, foodRepo)
+	getExportTemplateUseCase := usecase.NewGetExportTemplate(exportTemplateRepo)
+	updateExportTemplateUseCase := usecase.NewUpdateExportTemplate(exportTemplateRepo)
+	exportDietPdfUseCase := usecase.NewExportDietPdf(dietRepo, userRepo, exportTemplateRepo, foodRepo)
+	enableCalendarFeedUseCase := usecase.NewEnableCalendarFeed(calendarFeedRepo, userRepo, cfg.APIBaseURL)
+	disableCalendarFeedUseCase := usecase.NewDisableCalendarFeed(calendarFeedRepo)
+	getCalendarFeedUseCase := usecase.NewGetCalendarFeed(calendarFeedRepo, userRepo, dietRepo)
+	exportDietFhirUseCase := usecase.NewExportDietFhir(dietRepo, userRepo, foodRepo)
 
 	dietHandler := handler.NewCreateDietHandler(createDietUseCase)
 	updateDietHandler := handler.NewUpdateDietHandler(updateDietUseCase)
 	registerUserHandler := handler.NewRegisterUserHandler(createUserUseCase)
 	userLoginHandler := handler.NewLoginHandler(loginUseCase)
+	refreshTokenHandler := handler.NewRefreshTokenHandler(refreshTokenUseCase)
+	logoutHandler := handler.NewLogoutHandler(logoutUseCase)
+	jwksHandler := handler.NewJWKSHandler(keySet)
+	forgotPasswordHandler := handler.NewForgotPasswordHandler(requestPasswordResetUseCase)
+	resetPasswordHandler := handler.NewResetPasswordHandler(resetPasswordUseCase)
+	requestEmailVerificationHandler := handler.NewRequestEmailVerificationHandler(requestEmailVerificationUseCase)
+	verifyEmailHandler := handler.NewVerifyEmailHandler(verifyEmailUseCase)
+	unlockUserHandler := handler.NewUnlockUserHandler(unlockUserUseCase)
+	listRolesHandler := handler.NewListRolesHandler(listRolesUseCase)
+	updateRoleHandler := handler.NewUpdateRoleHandler(updateRoleUseCase)
+	assignUserRoleHandler := handler.NewAssignUserRoleHandler(assignUserRoleUseCase)
+	listNutritionistRequestsHandler := handler.NewListNutritionistRequestsHandler(listNutritionistRequestsUseCase)
+	reviewNutritionistHandler := handler.NewReviewNutritionistHandler(reviewNutritionistUseCase)
+	invitePatientHandler := handler.NewInvitePatientHandler(invitePatientUseCase)
+	listPatientsHandler := handler.NewListPatientsHandler(listPatientsUseCase)
+	listNutritionistsHandler := handler.NewListNutritionistsHandler(listNutritionistsUseCase)
+	respondInvitationHandler := handler.NewRespondInvitationHandler(respondInvitationUseCase)
+	endPatientLinkHandler := handler.NewEndPatientLinkHandler(endPatientLinkUseCase)
 	listDietsHandler := handler.NewListDietsHandler(listDietsUseCase)
+	getDietHandler := handler.NewGetDietHandler(getDietUseCase)
+	deleteDietHandler := handler.NewDeleteDietHandler(deleteDietUseCase)
+	restoreDietHandler := handler.NewRestoreDietHandler(restoreDietUseCase)
+	listDietRevisionsHandler := handler.NewListDietRevisionsHandler(listDietRevisionsUseCase)
+	getDietRevisionHandler := handler.NewGetDietRevisionHandler(getDietRevisionUseCase)
+	diffDietRevisionsHandler := handler.NewDiffDietRevisionsHandler(diffDietRevisionsUseCase)
+	rollbackDietHandler := handler.NewRollbackDietHandler(rollbackDietUseCase)
+	getDietNutritionHandler := handler.NewGetDietNutritionHandler(getDietNutritionUseCase)
+	searchFoodsHandler := handler.NewSearchFoodsHandler(searchFoodsUseCase)
+	getFoodHandler := handler.NewGetFoodHandler(getFoodUseCase)
+	updateFoodMeasuresHandler := handler.NewUpdateFoodMeasuresHandler(updateFoodMeasuresUseCase)
+	listUnitsHandler := handler.NewListUnitsHandler()
+	updateAnthropometricsHandler := handler.NewUpdateAnthropometricsHandler(updateAnthropometricsUseCase)
+	getEnergyAssessmentHandler := handler.NewGetEnergyAssessmentHandler(getEnergyAssessmentUseCase)
+	createMeasurementHandler := handler.NewCreateMeasurementHandler(createMeasurementUseCase)
+	listMeasurementsHandler := handler.NewListMeasurementsHandler(listMeasurementsUseCase)
+	updateMeasurementHandler := handler.NewUpdateMeasurementHandler(updateMeasurementUseCase)
+	deleteMeasurementHandler := handler.NewDeleteMeasurementHandler(deleteMeasurementUseCase)
+	logMealHandler := handler.NewLogMealHandler(logMealUseCase)
+	listMealLogsHandler := handler.NewListMealLogsHandler(listMealLogsUseCase)
+	reviewMealLogHandler := handler.NewReviewMealLogHandler(reviewMealLogUseCase)
+	deleteMealLogHandler := handler.NewDeleteMealLogHandler(deleteMealLogUseCase)
+	getDietAdherenceHandler := handler.NewGetDietAdherenceHandler(getDietAdherenceUseCase)
+	getDietScheduleHandler := handler.NewGetDietScheduleHandler(getDietScheduleUseCase)
+	changeDietStatusHandler := handler.NewChangeDietStatusHandler(changeDietStatusUseCase)
+	cloneDietHandler := handler.NewCloneDietHandler(cloneDietUseCase)
+	createDietTemplateHandler := handler.NewCreateDietTemplateHandler(createDietTemplateUseCase)
+	listDietTemplatesHandler := handler.NewListDietTemplatesHandler(listDietTemplatesUseCase)
+	getDietTemplateHandler := handler.NewGetDietTemplateHandler(getDietTemplateUseCase)
+	updateDietTemplateHandler := handler.NewUpdateDietTemplateHandler(updateDietTemplateUseCase)
+	deleteDietTemplateHandler := handler.NewDeleteDietTemplateHandler(deleteDietTemplateUseCase)
+	instantiateDietTemplateHandler := handler.NewInstantiateDietTemplateHandler(instantiateDietTemplateUseCase)
+	getShoppingListHandler := handler.NewGetShoppingListHandler(getShoppingListUseCase)
+	getExportTemplateHandler := handler.NewGetExportTemplateHandler(getExportTemplateUseCase)
+	updateExportTemplateHandler := handler.NewUpdateExportTemplateHandler(updateExportTemplateUseCase)
+	exportDietPdfHandler := handler.NewExportDietPdfHandler(exportDietPdfUseCase)
+	importDietHandler := handler.NewImportDietHandler(createDietUseCase)
+	exportDietTableHandler := handler.NewExportDietTableHandler(getDietUseCase)
+	exportDietFhirHandler := handler.NewExportDietFhirHandler(exportDietFhirUseCase)
+	importDietFhirHandler := handler.NewImportDietFhirHandler(createDietUseCase)
+	calendarFeedHandler := handler.NewCalendarFeedHandler(enableCalendarFeedUseCase, disableCalendarFeedUseCase)
+	getCalendarFeedHandler := handler.NewGetCalendarFeedHandler(getCalendarFeedUseCase)
 
 	r := gin.New()
-	r.Use(gin.Logger())
+	r.Use(gin.LoggerWithConfig(gin.LoggerConfig{
+		// A URL do calendário contém o token secreto e não vai para o log
+		Skip: func(c *gin.Context) bool {
+			return c.FullPath() == "/v1/calendar/:token"
+		},
+	}))
 	r.Use(gin.Recovery())
 
 	r.Use(func(c *gin.Context) {
@@ -52,7 +251,8 @@ func main() {
 		if allowedOrigins[origin] {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
 			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, If-Match, If-None-Match")
+			c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
 			c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 			if c.Request.Method == "OPTIONS" {
@@ -64,28 +264,140 @@ func main() {
 		c.Next()
 	})
 
-	if err := r.SetTrustedProxies([]string{"127.0.0.1"}); err != nil {
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
 		log.Fatalf("Failed to set trusted proxies: %v", err)
 	}
 
 	r.RemoveExtraSlash = true
 
 	r.GET("/ping", handler.Ping)
+	r.GET("/.well-known/jwks.json", jwksHandler.Handle)
+
+	authMiddleware := middleware.AuthMiddleware(keySet, validateSessionUseCase)
+	authRateLimit := middleware.RateLimitByIP(middleware.NewRateLimiter(cfg.AuthRateLimitPerMinute, cfg.AuthRateLimitBurst))
 
 	apiGroup := r.Group("/v1")
 
 	userGroup := apiGroup.Group("/users")
 	{
 		userGroup.POST("", registerUserHandler.Handle)
-		userGroup.POST("/login", userLoginHandler.HandleLogin)
+		userGroup.POST("/login", authRateLimit, userLoginHandler.HandleLogin)
+		userGroup.POST("/token/refresh", authRateLimit, refreshTokenHandler.Handle)
+		userGroup.POST("/logout", authMiddleware, logoutHandler.Handle)
+		userGroup.POST("/password/forgot", authRateLimit, forgotPasswordHandler.Handle)
+		userGroup.POST("/password/reset", authRateLimit, resetPasswordHandler.Handle)
+		userGroup.POST("/email/verification", authRateLimit, requestEmailVerificationHandler.Handle)
+		userGroup.POST("/email/verify", authRateLimit, verifyEmailHandler.Handle)
+	}
+
+	meGroup := userGroup.Group("/me")
+	meGroup.Use(authMiddleware, middleware.HasPermission(constants.PermissionListDiet))
+	{
+		meGroup.GET("/energy", getEnergyAssessmentHandler.HandleMe)
+		meGroup.POST("/measurements", createMeasurementHandler.HandleMe)
+		meGroup.GET("/measurements", listMeasurementsHandler.HandleMe)
+		meGroup.PUT("/measurements/:measurementId", updateMeasurementHandler.HandleMe)
+		meGroup.DELETE("/measurements/:measurementId", deleteMeasurementHandler.HandleMe)
+		meGroup.GET("/export-template", middleware.HasPermission(constants.PermissionCreateDiet), getExportTemplateHandler.Handle)
+		meGroup.PUT("/export-template", middleware.HasPermission(constants.PermissionCreateDiet), updateExportTemplateHandler.Handle)
+		meGroup.POST("/calendar-feed", calendarFeedHandler.HandleEnable)
+		meGroup.DELETE("/calendar-feed", calendarFeedHandler.HandleDisable)
+	}
+
+	// O token do link é a credencial: aplicativos de calendário não enviam
+	// o cabeçalho Authorization
+	apiGroup.GET("/calendar/:token", getCalendarFeedHandler.Handle)
+
+	adminGroup := apiGroup.Group("/admin")
+	adminGroup.Use(authMiddleware)
+	{
+		adminGroup.POST("/users/:id/unlock", middleware.HasPermission(constants.PermissionUnlockUser), unlockUserHandler.Handle)
+		adminGroup.PUT("/users/:id/role", middleware.HasPermission(constants.PermissionManageUsers), assignUserRoleHandler.Handle)
+		adminGroup.GET("/roles", middleware.HasAnyPermission(constants.PermissionManageRoles, constants.PermissionManageUsers), listRolesHandler.Handle)
+		adminGroup.PUT("/roles/:name", middleware.HasPermission(constants.PermissionManageRoles), updateRoleHandler.Handle)
+		adminGroup.GET("/nutritionists", middleware.HasPermission(constants.PermissionApproveNutritionist), listNutritionistRequestsHandler.Handle)
+		adminGroup.POST("/nutritionists/:id/approve", middleware.HasPermission(constants.PermissionApproveNutritionist), reviewNutritionistHandler.HandleApprove)
+		adminGroup.POST("/nutritionists/:id/reject", middleware.HasPermission(constants.PermissionApproveNutritionist), reviewNutritionistHandler.HandleReject)
+	}
+
+	patientGroup := apiGroup.Group("/patients")
+	patientGroup.Use(authMiddleware, middleware.HasPermission(constants.PermissionManagePatients))
+	{
+		patientGroup.POST("/invitations", invitePatientHandler.Handle)
+		patientGroup.GET("", listPatientsHandler.Handle)
+		patientGroup.DELETE("/:id", endPatientLinkHandler.Handle)
+		patientGroup.PUT("/:id/anthropometrics", updateAnthropometricsHandler.Handle)
+		patientGroup.GET("/:id/energy", getEnergyAssessmentHandler.Handle)
+		patientGroup.POST("/:id/measurements", createMeasurementHandler.Handle)
+		patientGroup.GET("/:id/measurements", listMeasurementsHandler.Handle)
+		patientGroup.PUT("/:id/measurements/:measurementId", updateMeasurementHandler.Handle)
+		patientGroup.DELETE("/:id/measurements/:measurementId", deleteMeasurementHandler.Handle)
+	}
+
+	nutritionistGroup := apiGroup.Group("/nutritionists")
+	nutritionistGroup.Use(authMiddleware, middleware.HasPermission(constants.PermissionManageNutritionists))
+	{
+		nutritionistGroup.GET("", listNutritionistsHandler.Handle)
+		nutritionistGroup.GET("/invitations", listNutritionistsHandler.HandleInvitations)
+		nutritionistGroup.POST("/invitations/:id/accept", respondInvitationHandler.HandleAccept)
+		nutritionistGroup.POST("/invitations/:id/decline", respondInvitationHandler.HandleDecline)
+		nutritionistGroup.DELETE("/:id", endPatientLinkHandler.Handle)
 	}
 
 	dietGroup := apiGroup.Group("/diets")
-	dietGroup.Use(middleware.AuthMiddleware([]byte(usecase.JWTSecretKey)))
+	dietGroup.Use(authMiddleware)
 	{
 		dietGroup.POST("", middleware.HasPermission(constants.PermissionCreateDiet), dietHandler.Handle)
+		dietGroup.POST("/import", middleware.HasPermission(constants.PermissionCreateDiet), importDietHandler.Handle)
+		dietGroup.POST("/import/fhir", middleware.HasPermission(constants.PermissionCreateDiet), importDietFhirHandler.Handle)
 		dietGroup.PUT("/:id", middleware.HasPermission(constants.PermissionUpdateDiet), updateDietHandler.Handle)
 		dietGroup.GET("", middleware.HasPermission(constants.PermissionListDiet), listDietsHandler.Handle)
+		dietGroup.GET("/:id", middleware.HasPermission(constants.PermissionListDiet), getDietHandler.Handle)
+		dietGroup.DELETE("/:id", middleware.HasPermission(constants.PermissionDeleteDiet), deleteDietHandler.Handle)
+		dietGroup.POST("/:id/restore", middleware.HasPermission(constants.PermissionDeleteDiet), restoreDietHandler.Handle)
+		dietGroup.GET("/:id/nutrition", middleware.HasPermission(constants.PermissionListDiet), getDietNutritionHandler.Handle)
+		dietGroup.GET("/:id/revisions", middleware.HasPermission(constants.PermissionListDiet), listDietRevisionsHandler.Handle)
+		dietGroup.GET("/:id/revisions/diff", middleware.HasPermission(constants.PermissionListDiet), diffDietRevisionsHandler.Handle)
+		dietGroup.GET("/:id/revisions/:number", middleware.HasPermission(constants.PermissionListDiet), getDietRevisionHandler.Handle)
+		dietGroup.POST("/:id/revisions/:number/rollback", middleware.HasPermission(constants.PermissionUpdateDiet), rollbackDietHandler.Handle)
+		dietGroup.POST("/:id/meal-logs", middleware.HasPermission(constants.PermissionLogMeals), logMealHandler.Handle)
+		dietGroup.GET("/:id/meal-logs", middleware.HasPermission(constants.PermissionListDiet), listMealLogsHandler.Handle)
+		dietGroup.DELETE("/:id/meal-logs/:logId", middleware.HasPermission(constants.PermissionLogMeals), deleteMealLogHandler.Handle)
+		dietGroup.POST("/:id/meal-logs/:logId/review", middleware.HasPermission(constants.PermissionUpdateDiet), reviewMealLogHandler.Handle)
+		dietGroup.GET("/:id/adherence", middleware.HasPermission(constants.PermissionListDiet), getDietAdherenceHandler.Handle)
+		dietGroup.GET("/:id/schedule", middleware.HasPermission(constants.PermissionListDiet), getDietScheduleHandler.Handle)
+		dietGroup.POST("/:id/status", middleware.HasPermission(constants.PermissionUpdateDiet), changeDietStatusHandler.Handle)
+		dietGroup.GET("/:id/shopping-list", middleware.HasPermission(constants.PermissionListDiet), getShoppingListHandler.Handle)
+		dietGroup.GET("/:id/export.pdf", middleware.HasPermission(constants.PermissionListDiet), exportDietPdfHandler.Handle)
+		dietGroup.GET("/:id/export.csv", middleware.HasPermission(constants.PermissionListDiet), exportDietTableHandler.HandleCSV)
+		dietGroup.GET("/:id/export.xlsx", middleware.HasPermission(constants.PermissionListDiet), exportDietTableHandler.HandleXLSX)
+		dietGroup.GET("/:id/fhir", middleware.HasPermission(constants.PermissionListDiet), exportDietFhirHandler.Handle)
+		dietGroup.POST("/:id/clone", middleware.HasPermission(constants.PermissionCreateDiet), cloneDietHandler.Handle)
+	}
+
+	dietTemplateGroup := apiGroup.Group("/diet-templates")
+	dietTemplateGroup.Use(authMiddleware, middleware.HasPermission(constants.PermissionManageDietTemplates))
+	{
+		dietTemplateGroup.POST("", createDietTemplateHandler.Handle)
+		dietTemplateGroup.GET("", listDietTemplatesHandler.Handle)
+		dietTemplateGroup.GET("/:id", getDietTemplateHandler.Handle)
+		dietTemplateGroup.PUT("/:id", updateDietTemplateHandler.Handle)
+		dietTemplateGroup.DELETE("/:id", deleteDietTemplateHandler.Handle)
+		dietTemplateGroup.POST("/:id/instantiate", middleware.HasPermission(constants.PermissionCreateDiet), instantiateDietTemplateHandler.Handle)
+	}
+
+	foodGroup := apiGroup.Group("/foods")
+	foodGroup.Use(authMiddleware, middleware.HasPermission(constants.PermissionListDiet))
+	{
+		foodGroup.GET("", searchFoodsHandler.Handle)
+		foodGroup.GET("/:id", getFoodHandler.Handle)
+		foodGroup.PUT("/:id/measures", middleware.HasPermission(constants.PermissionManageFoods), updateFoodMeasuresHandler.Handle)
+	}
+
+	unitGroup := apiGroup.Group("/units")
+	unitGroup.Use(authMiddleware)
+	{
+		unitGroup.GET("", listUnitsHandler.Handle)
 	}
 
 	log.Printf("Server starting on :%s", cfg.Port)
@@ -93,3 +405,22 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// runDietLifecycle advances the status of the diets on startup and then at
+// every interval
+func runDietLifecycle(advance usecase.AdvanceDietLifecycleUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		if changed, err := advance.Execute(ctx, time.Now()); err != nil {
+			log.Printf("Failed to advance diet lifecycle: %v", err)
+		} else if changed > 0 {
+			log.Printf("Diet lifecycle advanced %d diets", changed)
+		}
+		cancel()
+
+		<-ticker.C
+	}
+}
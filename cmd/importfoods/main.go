@@ -0,0 +1,108 @@
+// Command importfoods loads food composition tables into the foods collection.
+//
+//	go run ./cmd/importfoods -source taco -file ./data/taco.csv
+//	go run ./cmd/importfoods -source usda -dir ./data/FoodData_Central_csv
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/foodimport"
+	"github.com/victorgiudicissi/your-diet/internal/repository"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+func main() {
+	source := flag.String("source", "", "table to import: taco or usda")
+	file := flag.String("file", "", "TACO table exported as CSV")
+	dir := flag.String("dir", "", "folder of a FoodData Central CSV download (food.csv, food_nutrient.csv, food_category.csv)")
+	types := flag.String("types", "foundation_food,sr_legacy_food", "FoodData Central data types to import, comma separated (empty imports all)")
+	flag.Parse()
+
+	var (
+		foods []*entity.Food
+		err   error
+	)
+
+	switch strings.ToLower(*source) {
+	case "taco":
+		foods, err = parseTACO(*file)
+	case "usda":
+		foods, err = parseUSDA(*dir, *types)
+	default:
+		log.Fatalf("Unknown source %q, expected taco or usda", *source)
+	}
+	if err != nil {
+		log.Fatalf("Failed to parse %s: %v", *source, err)
+	}
+
+	cfg := utils.LoadEnvConfig()
+
+	foodRepo, err := repository.NewFoodRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB for foods: %v", err)
+	}
+
+	count, err := usecase.NewImportFoods(foodRepo).Execute(context.Background(), foods)
+	if err != nil {
+		log.Fatalf("Import failed after %d foods: %v", count, err)
+	}
+
+	log.Printf("Imported %s: %d foods read, %d inserted or updated", *source, len(foods), count)
+}
+
+func parseTACO(path string) ([]*entity.Food, error) {
+	if path == "" {
+		log.Fatal("-file is required for the taco source")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return foodimport.ParseTACO(f)
+}
+
+func parseUSDA(dir, types string) ([]*entity.Food, error) {
+	if dir == "" {
+		log.Fatal("-dir is required for the usda source")
+	}
+
+	foods, err := os.Open(filepath.Join(dir, "food.csv"))
+	if err != nil {
+		return nil, err
+	}
+	defer foods.Close()
+
+	nutrients, err := os.Open(filepath.Join(dir, "food_nutrient.csv"))
+	if err != nil {
+		return nil, err
+	}
+	defer nutrients.Close()
+
+	files := foodimport.USDAFiles{Foods: foods, FoodNutrients: nutrients}
+
+	// food_category.csv é opcional
+	if categories, err := os.Open(filepath.Join(dir, "food_category.csv")); err == nil {
+		defer categories.Close()
+		files.Categories = categories
+	}
+
+	var dataTypes []string
+	for _, dataType := range strings.Split(types, ",") {
+		if dataType = strings.TrimSpace(dataType); dataType != "" {
+			dataTypes = append(dataTypes, dataType)
+		}
+	}
+
+	return foodimport.ParseUSDA(files, dataTypes)
+}
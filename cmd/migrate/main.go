@@ -0,0 +1,104 @@
+// Command migrate runs one-off data migrations against the configured database.
+//
+//	go run ./cmd/migrate -step patient-links
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/victorgiudicissi/your-diet/internal/repository"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+type migration func(ctx context.Context, cfg *utils.EnvConfig) (int, error)
+
+var migrations = map[string]migration{
+	"patient-links":  backfillPatientLinks,
+	"meal-times":     migrateMealTimes,
+	"diet-lifecycle": migrateDietLifecycle,
+	"user-emails":    migrateUserEmails,
+}
+
+func main() {
+	step := flag.String("step", "", "migration to run: "+strings.Join(migrationNames(), ", "))
+	flag.Parse()
+
+	run, ok := migrations[*step]
+	if !ok {
+		log.Fatalf("Unknown migration %q, expected one of: %s", *step, strings.Join(migrationNames(), ", "))
+	}
+
+	cfg := utils.LoadEnvConfig()
+
+	count, err := run(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("Migration %s failed after %d changes: %v", *step, count, err)
+	}
+
+	log.Printf("Migration %s finished: %d changes", *step, count)
+}
+
+func backfillPatientLinks(ctx context.Context, cfg *utils.EnvConfig) (int, error) {
+	dietRepo, err := repository.NewDietRepository(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	userRepo, err := repository.NewMongoUserRepository(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	linkRepo, err := repository.NewPatientLinkRepository(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	return usecase.NewBackfillPatientLinks(dietRepo, userRepo, linkRepo).Execute(ctx)
+}
+
+func migrateMealTimes(ctx context.Context, cfg *utils.EnvConfig) (int, error) {
+	dietRepo, err := repository.NewDietRepository(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	revisionRepo, err := repository.NewDietRevisionRepository(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	return usecase.NewMigrateMealTimes(dietRepo, revisionRepo).Execute(ctx)
+}
+
+func migrateDietLifecycle(ctx context.Context, cfg *utils.EnvConfig) (int, error) {
+	dietRepo, err := repository.NewDietRepository(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	return usecase.NewMigrateDietLifecycle(dietRepo).Execute(ctx)
+}
+
+func migrateUserEmails(ctx context.Context, cfg *utils.EnvConfig) (int, error) {
+	userRepo, err := repository.NewMongoUserRepository(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	return usecase.NewMigrateUserEmails(userRepo).Execute(ctx)
+}
+
+func migrationNames() []string {
+	names := make([]string, 0, len(migrations))
+	for name := range migrations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
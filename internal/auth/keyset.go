@@ -0,0 +1,241 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+var (
+	ErrUnknownKeyID         = errors.New("token signed with an unknown key id")
+	ErrUnexpectedAlgorithm  = errors.New("token algorithm does not match the signing key")
+	ErrUnsupportedAlgorithm = errors.New("unsupported JWT signing algorithm")
+)
+
+// Key is a single signing or verification key identified by its kid.
+// Verification-only keys (e.g. a public key of a retired key pair) have no
+// signing material.
+type Key struct {
+	ID        string
+	Method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// KeySet holds the active signing key plus every key still accepted for
+// verification, which allows rotating keys without invalidating live tokens.
+type KeySet struct {
+	active *Key
+	keys   map[string]*Key
+	order  []string
+}
+
+// NewKeySet builds a key set that signs with active and verifies with active
+// and any additional keys.
+func NewKeySet(active *Key, additional ...*Key) (*KeySet, error) {
+	if active == nil || active.signKey == nil {
+		return nil, errors.New("active JWT key must be able to sign tokens")
+	}
+
+	ks := &KeySet{
+		active: active,
+		keys:   map[string]*Key{},
+	}
+
+	for _, key := range append([]*Key{active}, additional...) {
+		if _, exists := ks.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicated JWT key id %q", key.ID)
+		}
+		ks.keys[key.ID] = key
+		ks.order = append(ks.order, key.ID)
+	}
+
+	return ks, nil
+}
+
+// LoadKeySet reads the JWT keys configured in the environment.
+func LoadKeySet(cfg *utils.EnvConfig) (*KeySet, error) {
+	active, err := loadActiveKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var additional []*Key
+	for _, entry := range splitList(cfg.JWTVerificationKeys) {
+		key, err := parseVerificationKey(entry)
+		if err != nil {
+			return nil, err
+		}
+		additional = append(additional, key)
+	}
+
+	return NewKeySet(active, additional...)
+}
+
+// NewHMACKey creates a symmetric key for one of the HS* algorithms.
+func NewHMACKey(id, algorithm string, secret []byte) (*Key, error) {
+	method := jwt.GetSigningMethod(algorithm)
+	if _, ok := method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("%w: %s is not an HMAC algorithm", ErrUnsupportedAlgorithm, algorithm)
+	}
+
+	if len(secret) == 0 {
+		return nil, errors.New("HMAC secret must not be empty")
+	}
+
+	return &Key{ID: id, Method: method, signKey: secret, verifyKey: secret}, nil
+}
+
+// NewKeyFromPEM creates an asymmetric key for RS* or EdDSA. A PEM encoded
+// private key can sign and verify; a public key can only verify.
+func NewKeyFromPEM(id, algorithm string, data []byte) (*Key, error) {
+	method := jwt.GetSigningMethod(algorithm)
+
+	switch method.(type) {
+	case *jwt.SigningMethodRSA:
+		if private, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+			return &Key{ID: id, Method: method, signKey: private, verifyKey: &private.PublicKey}, nil
+		}
+		public, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA key %q: %w", id, err)
+		}
+		return &Key{ID: id, Method: method, verifyKey: public}, nil
+	case *jwt.SigningMethodEd25519:
+		if private, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+			edPrivate := private.(ed25519.PrivateKey)
+			return &Key{ID: id, Method: method, signKey: edPrivate, verifyKey: edPrivate.Public()}, nil
+		}
+		public, err := jwt.ParseEdPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Ed25519 key %q: %w", id, err)
+		}
+		return &Key{ID: id, Method: method, verifyKey: public}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
+	}
+}
+
+// Sign signs the claims with the active key and stamps its kid in the header.
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.active.Method, claims)
+	token.Header["kid"] = ks.active.ID
+	return token.SignedString(ks.active.signKey)
+}
+
+// Keyfunc resolves the verification key for a parsed token. Tokens without a
+// kid were issued before key rotation existed and are checked against the
+// active key.
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	key := ks.active
+	if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+		key, ok = ks.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKeyID
+		}
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, ErrUnexpectedAlgorithm
+	}
+
+	return key.verifyKey, nil
+}
+
+// Algorithms lists the algorithms accepted when verifying tokens.
+func (ks *KeySet) Algorithms() []string {
+	seen := map[string]bool{}
+	var algorithms []string
+	for _, id := range ks.order {
+		alg := ks.keys[id].Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			algorithms = append(algorithms, alg)
+		}
+	}
+	return algorithms
+}
+
+func loadActiveKey(cfg *utils.EnvConfig) (*Key, error) {
+	method := jwt.GetSigningMethod(cfg.JWTAlgorithm)
+	if method == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, cfg.JWTAlgorithm)
+	}
+
+	if _, ok := method.(*jwt.SigningMethodHMAC); ok {
+		secret := []byte(cfg.JWTSecret)
+		if cfg.JWTSecretFile != "" {
+			data, err := os.ReadFile(cfg.JWTSecretFile)
+			if err != nil {
+				return nil, err
+			}
+			secret = []byte(strings.TrimSpace(string(data)))
+		}
+		return NewHMACKey(cfg.JWTKeyID, cfg.JWTAlgorithm, secret)
+	}
+
+	data, err := os.ReadFile(cfg.JWTPrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := NewKeyFromPEM(cfg.JWTKeyID, cfg.JWTAlgorithm, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.signKey == nil {
+		return nil, errors.New("JWT_PRIVATE_KEY_FILE must contain a private key")
+	}
+
+	return key, nil
+}
+
+// parseVerificationKey parses a "kid:ALG:path" entry. For HMAC algorithms the
+// file holds the shared secret, otherwise a PEM encoded key.
+func parseVerificationKey(entry string) (*Key, error) {
+	parts := strings.SplitN(entry, ":", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid JWT verification key %q, expected kid:ALG:path", entry)
+	}
+
+	id, algorithm, path := parts[0], parts[1], parts[2]
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := jwt.GetSigningMethod(algorithm).(*jwt.SigningMethodHMAC); ok {
+		return NewHMACKey(id, algorithm, []byte(strings.TrimSpace(string(data))))
+	}
+
+	return NewKeyFromPEM(id, algorithm, data)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// publicKey returns the asymmetric public key of k, or nil for HMAC keys.
+func (k *Key) publicKey() crypto.PublicKey {
+	switch key := k.verifyKey.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+		return key
+	default:
+		return nil
+	}
+}
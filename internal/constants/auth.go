@@ -4,27 +4,106 @@ const (
 	// Token types
 	TokenTypeDefault      = "DEFAULT"
 	TokenTypeNutritionist = "NUTRITIONIST"
+	TokenTypeAdmin        = "ADMIN"
 
 	// Permissions
-	PermissionListDiet   = "list_diet"
-	PermissionCreateDiet = "create_diet"
-	PermissionUpdateDiet = "update_diet"
-	PermissionUploadFile = "upload_file"
+	PermissionListDiet            = "list_diet"
+	PermissionCreateDiet          = "create_diet"
+	PermissionUpdateDiet          = "update_diet"
+	PermissionDeleteDiet          = "delete_diet"
+	PermissionUploadFile          = "upload_file"
+	PermissionUnlockUser          = "unlock_user"
+	PermissionManageRoles         = "manage_roles"
+	PermissionManageUsers         = "manage_users"
+	PermissionApproveNutritionist = "approve_nutritionist"
+	PermissionManagePatients      = "manage_patients"
+	PermissionManageNutritionists = "manage_nutritionists"
+	PermissionManageFoods         = "manage_foods"
+	PermissionLogMeals            = "log_meals"
+	PermissionManageDietTemplates = "manage_diet_templates"
 )
 
-// GetPermissionsByUserType returns the permissions for a given user type
+// UserTypes lists the built-in user types, which are seeded as roles
+var UserTypes = []string{
+	TokenTypeDefault,
+	TokenTypeNutritionist,
+	TokenTypeAdmin,
+}
+
+// AllPermissions lists every permission checked by the API
+var AllPermissions = []string{
+	PermissionListDiet,
+	PermissionCreateDiet,
+	PermissionUpdateDiet,
+	PermissionDeleteDiet,
+	PermissionUploadFile,
+	PermissionUnlockUser,
+	PermissionManageRoles,
+	PermissionManageUsers,
+	PermissionApproveNutritionist,
+	PermissionManagePatients,
+	PermissionManageNutritionists,
+	PermissionManageFoods,
+	PermissionLogMeals,
+	PermissionManageDietTemplates,
+}
+
+// GetPermissionsByUserType returns the default permissions for a given user type.
+// Roles stored in the database take precedence; these defaults seed them.
 func GetPermissionsByUserType(userType string) []string {
 	switch userType {
 	case TokenTypeDefault:
-		return []string{PermissionListDiet}
+		return []string{
+			PermissionListDiet,
+			PermissionManageNutritionists,
+			PermissionLogMeals,
+		}
 	case TokenTypeNutritionist:
 		return []string{
 			PermissionListDiet,
 			PermissionCreateDiet,
 			PermissionUpdateDiet,
+			PermissionDeleteDiet,
 			PermissionUploadFile,
+			PermissionManagePatients,
+			PermissionManageNutritionists,
+			PermissionManageFoods,
+			PermissionManageDietTemplates,
+		}
+	case TokenTypeAdmin:
+		return []string{
+			PermissionListDiet,
+			PermissionUnlockUser,
+			PermissionManageRoles,
+			PermissionManageUsers,
+			PermissionApproveNutritionist,
+			PermissionManageFoods,
 		}
 	default:
 		return []string{}
 	}
 }
+
+// GetRoleDescription returns the description of a built-in user type
+func GetRoleDescription(userType string) string {
+	switch userType {
+	case TokenTypeDefault:
+		return "Paciente"
+	case TokenTypeNutritionist:
+		return "Nutricionista aprovado"
+	case TokenTypeAdmin:
+		return "Administrador"
+	default:
+		return ""
+	}
+}
+
+// IsKnownPermission reports whether the permission is checked by the API
+func IsKnownPermission(permission string) bool {
+	for _, p := range AllPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+// Package dayplan resolves which meals a diet prescribes on each date when
+// the diet alternates day templates.
+package dayplan
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// Weekdays lists the days of the week starting on Monday
+var Weekdays = []entity.Weekday{
+	entity.Monday,
+	entity.Tuesday,
+	entity.Wednesday,
+	entity.Thursday,
+	entity.Friday,
+	entity.Saturday,
+	entity.Sunday,
+}
+
+// IsWeekday reports whether the value is a canonical day of the week
+func IsWeekday(value string) bool {
+	for _, weekday := range Weekdays {
+		if string(weekday) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// WeekdayOf returns the day of the week of a date
+func WeekdayOf(date time.Time) entity.Weekday {
+	return Weekdays[(int(date.Weekday())+6)%7]
+}
+
+// DaysBetween counts the calendar days from start to date, ignoring the time
+// of the day and daylight saving changes
+func DaysBetween(start, date time.Time) int {
+	from := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+// Validate checks the day templates and the rule that assigns them.
+// hasBaseMeals tells whether the diet has meals of its own, used by the days
+// without a template.
+func Validate(hasBaseMeals bool, days []entity.DayTemplate, rule *entity.DayRule) error {
+	if len(days) == 0 {
+		if rule != nil {
+			return errors.New("day_rule requires days")
+		}
+		if !hasBaseMeals {
+			return errors.New("the diet must have meals or days")
+		}
+		return nil
+	}
+	if rule == nil {
+		return errors.New("days require a day_rule")
+	}
+
+	names := make(map[string]bool, len(days))
+	for _, day := range days {
+		key := strings.ToLower(strings.TrimSpace(day.Name))
+		if names[key] {
+			return fmt.Errorf("day %q is repeated", day.Name)
+		}
+		names[key] = true
+	}
+
+	switch rule.Type {
+	case entity.DayRuleWeekday:
+		if len(rule.Rotation) > 0 {
+			return errors.New("rotation is only used by the ROTATION rule")
+		}
+
+		assigned := map[entity.Weekday]string{}
+		for _, day := range days {
+			if len(day.Weekdays) == 0 {
+				return fmt.Errorf("day %q has no weekdays", day.Name)
+			}
+			for _, weekday := range day.Weekdays {
+				if other, ok := assigned[weekday]; ok {
+					return fmt.Errorf("%s is assigned to both %q and %q", weekday, other, day.Name)
+				}
+				assigned[weekday] = day.Name
+			}
+		}
+
+		if !hasBaseMeals && len(assigned) < len(Weekdays) {
+			return errors.New("every weekday needs a day when the diet has no meals of its own")
+		}
+	case entity.DayRuleRotation:
+		if len(rule.Rotation) == 0 {
+			return errors.New("rotation must list at least one day")
+		}
+		if hasBaseMeals {
+			return errors.New("the ROTATION rule only uses days, move the meals into a day")
+		}
+		for _, day := range days {
+			if len(day.Weekdays) > 0 {
+				return fmt.Errorf("day %q: weekdays are only used by the WEEKDAY rule", day.Name)
+			}
+		}
+		for _, name := range rule.Rotation {
+			if !names[strings.ToLower(strings.TrimSpace(name))] {
+				return fmt.Errorf("rotation references the unknown day %q", name)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown day_rule type %q", rule.Type)
+	}
+
+	return nil
+}
+
+// TemplateFor returns the template followed by the diet on the date, or nil
+// when the date follows the meals of the diet. start is the first day of the
+// diet, from which rotations are counted.
+func TemplateFor(diet *entity.Diet, start, date time.Time) *entity.DayTemplate {
+	if len(diet.Days) == 0 || diet.DayRule == nil {
+		return nil
+	}
+
+	switch diet.DayRule.Type {
+	case entity.DayRuleWeekday:
+		weekday := WeekdayOf(date)
+		for i := range diet.Days {
+			for _, assigned := range diet.Days[i].Weekdays {
+				if assigned == weekday {
+					return &diet.Days[i]
+				}
+			}
+		}
+	case entity.DayRuleRotation:
+		if len(diet.DayRule.Rotation) == 0 {
+			return nil
+		}
+		size := len(diet.DayRule.Rotation)
+		position := ((DaysBetween(start, date) % size) + size) % size
+		return findTemplate(diet, diet.DayRule.Rotation[position])
+	}
+	return nil
+}
+
+// MealsOn returns the meals the diet prescribes on the date
+func MealsOn(diet *entity.Diet, start, date time.Time) []entity.Meal {
+	if template := TemplateFor(diet, start, date); template != nil {
+		return template.Meals
+	}
+	return diet.Meals
+}
+
+// AllMeals returns the meals of the diet followed by the meals of every template
+func AllMeals(diet *entity.Diet) []entity.Meal {
+	meals := append([]entity.Meal{}, diet.Meals...)
+	for _, day := range diet.Days {
+		meals = append(meals, day.Meals...)
+	}
+	return meals
+}
+
+func findTemplate(diet *entity.Diet, name string) *entity.DayTemplate {
+	key := strings.ToLower(strings.TrimSpace(name))
+	for i := range diet.Days {
+		if strings.ToLower(strings.TrimSpace(diet.Days[i].Name)) == key {
+			return &diet.Days[i]
+		}
+	}
+	return nil
+}
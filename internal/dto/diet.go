@@ -2,62 +2,222 @@ package dto
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
 	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/mealtime"
+	"github.com/victorgiudicissi/your-diet/internal/units"
 )
 
 // IngredientRequest representa um ingrediente na requisição
 type IngredientRequest struct {
+	FoodID      string              `json:"food_id"`
 	Description string              `json:"description" validate:"required,min=1"`
 	Quantity    float64             `json:"quantity" validate:"required,min=0"`
-	Unit        string              `json:"unit" validate:"required,oneof=ml g l kg mg un fatia(s)"`
+	Unit        string              `json:"unit" validate:"required,unit"`
 	Substitutes []IngredientRequest `json:"substitutes"`
+	// Opcionais, usados no cálculo nutricional
+	NutrientsPer100g *NutrientsRequest `json:"nutrients_per_100g" validate:"omitempty"`
+	UnitWeightG      float64           `json:"unit_weight_g" validate:"min=0"`
 }
 
-// MealRequest representa uma refeição na requisição
+// NutrientsRequest representa a composição por 100 g informada no ingrediente
+type NutrientsRequest struct {
+	EnergyKcal    float64 `json:"energy_kcal" validate:"min=0"`
+	ProteinG      float64 `json:"protein_g" validate:"min=0"`
+	CarbohydrateG float64 `json:"carbohydrate_g" validate:"min=0"`
+	FatG          float64 `json:"fat_g" validate:"min=0"`
+	FiberG        float64 `json:"fiber_g" validate:"min=0"`
+	CholesterolMg float64 `json:"cholesterol_mg" validate:"min=0"`
+	SodiumMg      float64 `json:"sodium_mg" validate:"min=0"`
+	CalciumMg     float64 `json:"calcium_mg" validate:"min=0"`
+	IronMg        float64 `json:"iron_mg" validate:"min=0"`
+	PotassiumMg   float64 `json:"potassium_mg" validate:"min=0"`
+	MagnesiumMg   float64 `json:"magnesium_mg" validate:"min=0"`
+	VitaminCMg    float64 `json:"vitamin_c_mg" validate:"min=0"`
+}
+
+// MealRequest representa uma refeição na requisição. O slot é opcional e,
+// quando ausente, é deduzido do horário
 type MealRequest struct {
 	Name        string              `json:"name" validate:"required,min=3,max=100"`
 	Description string              `json:"description"`
-	TimeOfDay   string              `json:"time_of_day" validate:"required"`
+	TimeOfDay   string              `json:"time_of_day" validate:"required,clock"`
+	WindowStart string              `json:"window_start" validate:"omitempty,clock"`
+	WindowEnd   string              `json:"window_end" validate:"omitempty,clock"`
+	Slot        string              `json:"slot" validate:"omitempty,slot"`
 	Ingredients []IngredientRequest `json:"ingredients" validate:"required,min=1,dive"`
 }
 
-// DietRequest represents the request body for creating a new diet
+// DayTemplateRequest representa um modelo de dia da dieta
+type DayTemplateRequest struct {
+	Name     string        `json:"name" validate:"required,max=50"`
+	Weekdays []string      `json:"weekdays" validate:"omitempty,dive,weekday"`
+	Meals    []MealRequest `json:"meals" validate:"required,min=1,dive"`
+}
+
+// DayRuleRequest representa a regra que distribui os modelos de dia
+type DayRuleRequest struct {
+	Type     string   `json:"type" validate:"required,oneof=WEEKDAY ROTATION"`
+	Rotation []string `json:"rotation" validate:"omitempty,dive,required"`
+}
+
+// DietRequest represents the request body for creating a new diet. Meals may
+// be left empty when every day of the diet follows one of the Days. StartsAt
+// (AAAA-MM-DD) defaults to today and Status only accepts DRAFT; the other
+// statuses follow the dates of the diet.
 type DietRequest struct {
-	UserEmail      string        `json:"user_email" validate:"required,email"`
-	DietName       string        `json:"name" validate:"required,min=3,max=100"`
-	DurationInDays uint32        `json:"duration_in_days" validate:"required,min=1"`
-	Meals          []MealRequest `json:"meals" validate:"required,min=1,dive"`
-	Observations   string        `json:"observations"`
+	UserEmail      string               `json:"user_email" validate:"required,email"`
+	DietName       string               `json:"name" validate:"required,min=3,max=100"`
+	DurationInDays uint32               `json:"duration_in_days" validate:"required,min=1"`
+	StartsAt       string               `json:"starts_at" validate:"omitempty,datetime=2006-01-02"`
+	Status         string               `json:"status" validate:"omitempty,oneof=DRAFT"`
+	Meals          []MealRequest        `json:"meals" validate:"required_without=Days,dive"`
+	Days           []DayTemplateRequest `json:"days" validate:"omitempty,dive"`
+	DayRule        *DayRuleRequest      `json:"day_rule"`
+	Observations   string               `json:"observations"`
+	Texture        string               `json:"texture" validate:"omitempty,oneof=REGULAR EASY_TO_CHEW SOFT_AND_BITE_SIZED MINCED_AND_MOIST PUREED LIQUIDISED"`
 }
 
 func ConvertToDiet(createdBy string, req *DietRequest) (*entity.Diet, error) {
 	now := time.Now()
 
-	meals := make([]entity.Meal, 0, len(req.Meals))
-	for _, mealReq := range req.Meals {
-		meal, err := ConvertToMeal(&mealReq)
-		if err != nil {
-			return nil, err
-		}
-		meals = append(meals, *meal)
+	meals, days, dayRule, err := convertMealPlan(req.Meals, req.Days, req.DayRule)
+	if err != nil {
+		return nil, err
+	}
+
+	startsAt, err := parseStartsAt(req.StartsAt)
+	if err != nil {
+		return nil, err
 	}
 
 	return &entity.Diet{
 		UserEmail:      req.UserEmail,
 		DietName:       req.DietName,
 		DurationInDays: req.DurationInDays,
-		Status:         string(entity.Enabled),
+		Status:         req.Status,
+		StartsAt:       startsAt,
 		Meals:          meals,
+		Days:           days,
+		DayRule:        dayRule,
 		Observations:   req.Observations,
+		Texture:        entity.DietTexture(req.Texture),
 		CreatedBy:      createdBy,
 		CreatedAt:      now,
 		UpdatedAt:      now,
+		Version:        1,
 	}, nil
 }
 
+// UpdateDietRequest represents the request body of PUT /v1/diets/:id, which
+// replaces the whole content of the diet: fields left out are cleared. The
+// patient and the status do not change through it, and the start date is kept
+// when StartsAt is empty.
+type UpdateDietRequest struct {
+	DietName       string               `json:"name" validate:"required,min=3,max=100"`
+	DurationInDays uint32               `json:"duration_in_days" validate:"required,min=1"`
+	StartsAt       string               `json:"starts_at" validate:"omitempty,datetime=2006-01-02"`
+	Meals          []MealRequest        `json:"meals" validate:"required_without=Days,dive"`
+	Days           []DayTemplateRequest `json:"days" validate:"omitempty,dive"`
+	DayRule        *DayRuleRequest      `json:"day_rule"`
+	Observations   string               `json:"observations"`
+	Texture        string               `json:"texture" validate:"omitempty,oneof=REGULAR EASY_TO_CHEW SOFT_AND_BITE_SIZED MINCED_AND_MOIST PUREED LIQUIDISED"`
+}
+
+// ConvertUpdateToDiet converts the new content of the diet; only the fields
+// the update replaces are filled
+func ConvertUpdateToDiet(updatedBy string, req *UpdateDietRequest) (*entity.Diet, error) {
+	meals, days, dayRule, err := convertMealPlan(req.Meals, req.Days, req.DayRule)
+	if err != nil {
+		return nil, err
+	}
+
+	startsAt, err := parseStartsAt(req.StartsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Diet{
+		DietName:       req.DietName,
+		DurationInDays: req.DurationInDays,
+		StartsAt:       startsAt,
+		Meals:          meals,
+		Days:           days,
+		DayRule:        dayRule,
+		Observations:   req.Observations,
+		Texture:        entity.DietTexture(req.Texture),
+		CreatedBy:      updatedBy,
+	}, nil
+}
+
+// parseStartsAt converte o primeiro dia da dieta (AAAA-MM-DD), opcional
+func parseStartsAt(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("starts_at inválido: %q", value)
+	}
+	return &parsed, nil
+}
+
+// convertMealPlan converte as refeições, os modelos de dia e a regra que os distribui
+func convertMealPlan(mealReqs []MealRequest, dayReqs []DayTemplateRequest, ruleReq *DayRuleRequest) ([]entity.Meal, []entity.DayTemplate, *entity.DayRule, error) {
+	meals, err := convertToMeals(mealReqs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var days []entity.DayTemplate
+	for _, dayReq := range dayReqs {
+		dayMeals, err := convertToMeals(dayReq.Meals)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		weekdays := make([]entity.Weekday, 0, len(dayReq.Weekdays))
+		for _, weekday := range dayReq.Weekdays {
+			weekdays = append(weekdays, entity.Weekday(weekday))
+		}
+
+		days = append(days, entity.DayTemplate{
+			Name:     strings.TrimSpace(dayReq.Name),
+			Weekdays: weekdays,
+			Meals:    dayMeals,
+		})
+	}
+
+	var dayRule *entity.DayRule
+	if ruleReq != nil {
+		dayRule = &entity.DayRule{
+			Type:     entity.DayRuleType(ruleReq.Type),
+			Rotation: ruleReq.Rotation,
+		}
+	}
+
+	return meals, days, dayRule, nil
+}
+
+// convertToMeals converte as refeições e as ordena cronologicamente
+func convertToMeals(reqs []MealRequest) ([]entity.Meal, error) {
+	meals := make([]entity.Meal, 0, len(reqs))
+	for _, mealReq := range reqs {
+		meal, err := ConvertToMeal(&mealReq)
+		if err != nil {
+			return nil, err
+		}
+		meals = append(meals, *meal)
+	}
+	mealtime.Normalize(meals)
+	return meals, nil
+}
+
 func ConvertToMeal(req *MealRequest) (*entity.Meal, error) {
 	ingredients := make([]entity.Ingredient, 0, len(req.Ingredients))
 	for _, ingReq := range req.Ingredients {
@@ -72,11 +232,19 @@ func ConvertToMeal(req *MealRequest) (*entity.Meal, error) {
 		Name:        req.Name,
 		Description: req.Description,
 		TimeOfDay:   req.TimeOfDay,
+		WindowStart: req.WindowStart,
+		WindowEnd:   req.WindowEnd,
+		Slot:        entity.MealSlot(req.Slot),
 		Ingredients: ingredients,
 	}, nil
 }
 
 func ConvertToIngredient(req *IngredientRequest) (*entity.Ingredient, error) {
+	unit, err := units.Parse(req.Unit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unidade de medida desconhecida: %q", req.Description, req.Unit)
+	}
+
 	substitutes := make([]entity.Ingredient, 0, len(req.Substitutes))
 	for _, subReq := range req.Substitutes {
 		subIngredient, err := ConvertToIngredient(&subReq)
@@ -86,14 +254,28 @@ func ConvertToIngredient(req *IngredientRequest) (*entity.Ingredient, error) {
 		substitutes = append(substitutes, *subIngredient)
 	}
 
+	var nutrients *entity.Nutrients
+	if req.NutrientsPer100g != nil {
+		converted := entity.Nutrients(*req.NutrientsPer100g)
+		nutrients = &converted
+	}
+
 	return &entity.Ingredient{
-		Description: req.Description,
-		Quantity:    req.Quantity,
-		Unit:        req.Unit,
-		Substitutes: substitutes,
+		FoodID:           strings.TrimSpace(req.FoodID),
+		Description:      req.Description,
+		Quantity:         req.Quantity,
+		Unit:             unit.Code,
+		NutrientsPer100g: nutrients,
+		UnitWeightG:      req.UnitWeightG,
+		Substitutes:      substitutes,
 	}, nil
 }
 
+// DietStatusRequest representa a mudança de status de uma dieta
+type DietStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=DRAFT SCHEDULED ACTIVE EXPIRED ARCHIVED"`
+}
+
 // ValidationError represents a custom validation error
 type ValidationError struct {
 	Field   string `json:"field"`
@@ -115,10 +297,24 @@ func fieldNameToHumanReadable(field string) string {
 		return "duração em dias"
 	case "Meals":
 		return "refeições"
+	case "Days":
+		return "dias"
+	case "Weekdays":
+		return "dias da semana"
+	case "Type":
+		return "tipo da regra"
+	case "Rotation":
+		return "rotação"
 	case "Name":
 		return "nome"
 	case "TimeOfDay":
-		return "período do dia"
+		return "horário"
+	case "WindowStart":
+		return "início da janela"
+	case "WindowEnd":
+		return "fim da janela"
+	case "Slot":
+		return "tipo de refeição"
 	case "Ingredients":
 		return "ingredientes"
 	case "Description":
@@ -129,28 +325,89 @@ func fieldNameToHumanReadable(field string) string {
 		return "unidade de medida"
 	case "Observations":
 		return "observações"
+	case "StartsAt":
+		return "data de início"
+	case "Texture":
+		return "textura"
 	default:
 		return field
 	}
 }
 
 func (d *DietRequest) Validate() error {
-	validate := validator.New()
-	err := validate.Struct(d)
+	if err := newDietValidator().Struct(d); err != nil {
+		return translateValidationError(err)
+	}
+	return validateMealPlan(d.Meals, d.Days, d.DayRule)
+}
 
-	if err == nil {
-		return nil
+func (d *UpdateDietRequest) Validate() error {
+	if err := newDietValidator().Struct(d); err != nil {
+		return translateValidationError(err)
 	}
+	return validateMealPlan(d.Meals, d.Days, d.DayRule)
+}
 
+// newDietValidator registers the custom tags used by the diet requests
+func newDietValidator() *validator.Validate {
+	validate := validator.New()
+	_ = validate.RegisterValidation("unit", func(fl validator.FieldLevel) bool {
+		return units.IsKnown(fl.Field().String())
+	})
+	_ = validate.RegisterValidation("clock", func(fl validator.FieldLevel) bool {
+		_, err := mealtime.ParseClock(fl.Field().String())
+		return err == nil
+	})
+	_ = validate.RegisterValidation("slot", func(fl validator.FieldLevel) bool {
+		return mealtime.IsSlot(fl.Field().String())
+	})
+	_ = validate.RegisterValidation("weekday", func(fl validator.FieldLevel) bool {
+		return dayplan.IsWeekday(fl.Field().String())
+	})
+	return validate
+}
+
+// translateValidationError turns the first validator error into a readable message
+func translateValidationError(err error) error {
 	if validationErrors, ok := err.(validator.ValidationErrors); ok {
 		for _, fieldError := range validationErrors {
 			fieldName := fieldNameToHumanReadable(fieldError.Field())
 			switch fieldError.Tag() {
-			case "required":
+			case "required", "required_without":
 				return &ValidationError{
 					Field:   fieldError.Field(),
 					Message: fmt.Sprintf("The %s field is required", fieldName),
 				}
+			case "unit":
+				return &ValidationError{
+					Field:   fieldError.Field(),
+					Message: fmt.Sprintf("Unknown unit %q, see GET /v1/units", fieldError.Value()),
+				}
+			case "clock":
+				return &ValidationError{
+					Field:   fieldError.Field(),
+					Message: fmt.Sprintf("The %s must be in the HH:MM format, got %q", fieldName, fieldError.Value()),
+				}
+			case "slot":
+				return &ValidationError{
+					Field:   fieldError.Field(),
+					Message: fmt.Sprintf("Unknown meal slot %q", fieldError.Value()),
+				}
+			case "weekday":
+				return &ValidationError{
+					Field:   fieldError.Field(),
+					Message: fmt.Sprintf("Unknown weekday %q, use MONDAY to SUNDAY", fieldError.Value()),
+				}
+			case "datetime":
+				return &ValidationError{
+					Field:   fieldError.Field(),
+					Message: fmt.Sprintf("The %s must be in the AAAA-MM-DD format, got %q", fieldName, fieldError.Value()),
+				}
+			case "oneof":
+				return &ValidationError{
+					Field:   fieldError.Field(),
+					Message: fmt.Sprintf("The %s must be one of: %s", fieldName, fieldError.Param()),
+				}
 			case "email":
 				return &ValidationError{
 					Field:   fieldError.Field(),
@@ -182,31 +439,102 @@ func (d *DietRequest) Validate() error {
 	}
 }
 
+// validateMealPlan checks the meal windows and how the day templates are assigned
+func validateMealPlan(baseMeals []MealRequest, dayReqs []DayTemplateRequest, dayRule *DayRuleRequest) error {
+	meals := append([]MealRequest{}, baseMeals...)
+	days := make([]entity.DayTemplate, 0, len(dayReqs))
+	for _, day := range dayReqs {
+		meals = append(meals, day.Meals...)
+
+		weekdays := make([]entity.Weekday, 0, len(day.Weekdays))
+		for _, weekday := range day.Weekdays {
+			weekdays = append(weekdays, entity.Weekday(weekday))
+		}
+		days = append(days, entity.DayTemplate{Name: day.Name, Weekdays: weekdays})
+	}
+
+	for _, meal := range meals {
+		if err := mealtime.ValidateWindow(meal.TimeOfDay, meal.WindowStart, meal.WindowEnd); err != nil {
+			return &ValidationError{
+				Field:   "WindowStart",
+				Message: fmt.Sprintf("Meal %q: %s", meal.Name, err.Error()),
+			}
+		}
+	}
+
+	var rule *entity.DayRule
+	if dayRule != nil {
+		rule = &entity.DayRule{Type: entity.DayRuleType(dayRule.Type), Rotation: dayRule.Rotation}
+	}
+	if err := dayplan.Validate(len(baseMeals) > 0, days, rule); err != nil {
+		return &ValidationError{
+			Field:   "Days",
+			Message: err.Error(),
+		}
+	}
+
+	return nil
+}
+
 type DietResponse struct {
-	ID             string         `json:"id"`
-	UserEmail      string         `json:"user_email"`
-	DietName       string         `json:"name"`
-	DurationInDays uint32         `json:"duration_in_days"`
-	Status         string         `json:"status"`
-	Meals          []MealResponse `json:"meals"`
-	Observations   string         `json:"observations"`
-	CreatedBy      string         `json:"created_by"`
-	CreatedAt      time.Time      `json:"created_at"`
-	UpdatedAt      time.Time      `json:"updated_at"`
+	ID             string                `json:"id"`
+	UserEmail      string                `json:"user_email"`
+	DietName       string                `json:"name"`
+	DurationInDays uint32                `json:"duration_in_days"`
+	Status         string                `json:"status"`
+	StartsAt       *time.Time            `json:"starts_at,omitempty"`
+	EndsAt         *time.Time            `json:"ends_at,omitempty"`
+	Meals          []MealResponse        `json:"meals"`
+	Days           []DayTemplateResponse `json:"days,omitempty"`
+	DayRule        *entity.DayRule       `json:"day_rule,omitempty"`
+	Observations   string                `json:"observations"`
+	Texture        entity.DietTexture    `json:"texture,omitempty"`
+	CreatedBy      string                `json:"created_by"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	Version        int64                 `json:"version"`
+	DeletedAt      *time.Time            `json:"deleted_at,omitempty"`
+	Nutrition      *DietNutritionSummary `json:"nutrition,omitempty"`
+}
+
+// DietNutritionSummary resume os totais nutricionais da dieta
+type DietNutritionSummary struct {
+	PerDay     entity.Nutrients  `json:"per_day"`
+	Total      entity.Nutrients  `json:"total"`
+	MacroSplit entity.MacroSplit `json:"macro_split"`
+	Unresolved []string          `json:"unresolved"`
+	// Target indica o quanto a dieta se afasta da meta do paciente
+	Target *entity.TargetDeviation `json:"target,omitempty"`
+}
+
+type DayTemplateResponse struct {
+	Name      string            `json:"name"`
+	Weekdays  []entity.Weekday  `json:"weekdays,omitempty"`
+	Meals     []MealResponse    `json:"meals"`
+	Nutrition *entity.Nutrients `json:"nutrition,omitempty"`
 }
 
 type MealResponse struct {
 	Name        string               `json:"name"`
 	Description string               `json:"description"`
 	TimeOfDay   string               `json:"time_of_day"`
+	WindowStart string               `json:"window_start,omitempty"`
+	WindowEnd   string               `json:"window_end,omitempty"`
+	Slot        entity.MealSlot      `json:"slot,omitempty"`
 	Ingredients []IngredientResponse `json:"ingredients"`
+	Nutrition   *entity.Nutrients    `json:"nutrition,omitempty"`
 }
 
 type IngredientResponse struct {
-	Description string               `json:"description"`
-	Quantity    float64              `json:"quantity"`
-	Unit        string               `json:"unit"`
-	Substitutes []IngredientResponse `json:"substitutes"`
+	FoodID           string               `json:"food_id,omitempty"`
+	Description      string               `json:"description"`
+	Quantity         float64              `json:"quantity"`
+	Unit             string               `json:"unit"`
+	Substitutes      []IngredientResponse `json:"substitutes"`
+	NutrientsPer100g *entity.Nutrients    `json:"nutrients_per_100g,omitempty"`
+	UnitWeightG      float64              `json:"unit_weight_g,omitempty"`
+	Grams            *float64             `json:"grams,omitempty"`
+	Nutrition        *entity.Nutrients    `json:"nutrition,omitempty"`
 }
 
 // ListDietsInput represents the input parameters for listing diets
@@ -214,32 +542,156 @@ type ListDietsInput struct {
 	UserEmail       string `form:"userEmail" binding:"required,email"`
 	CreatedBySearch bool   `form:"createdBySearch"`
 	UserID          string `form:"userId" binding:"required"`
+	IncludeDeleted  bool   `form:"includeDeleted"`
+
+	// Filtros
+	Status      string     `form:"status"`
+	Name        string     `form:"name"`
+	CreatedFrom *time.Time `form:"createdFrom"`
+	CreatedTo   *time.Time `form:"createdTo"`
+	UpdatedFrom *time.Time `form:"updatedFrom"`
+	UpdatedTo   *time.Time `form:"updatedTo"`
+
+	// Ordenação e paginação
+	SortBy string `form:"sortBy"`
+	Order  string `form:"order"`
+	Limit  int    `form:"limit"`
+	Cursor string `form:"cursor"`
 }
 
 type ListDietsUseCaseOutput struct {
-	Diets []*DietResponse `json:"diets"`
+	Diets      []*DietResponse `json:"diets"`
+	Limit      int             `json:"limit"`
+	NextCursor string          `json:"next_cursor"`
+}
+
+// ListDietsResponse is the paginated envelope returned by GET /v1/diets
+type ListDietsResponse struct {
+	Data []*DietResponse `json:"data"`
+	Page PageResponse    `json:"page"`
+}
+
+// PageResponse carries the pagination metadata of a list response
+type PageResponse struct {
+	Limit   int    `json:"limit"`
+	Count   int    `json:"count"`
+	HasMore bool   `json:"has_more"`
+	Next    string `json:"next,omitempty"`
+}
+
+// NewListDietsResponse wraps the use case output in the response envelope
+func NewListDietsResponse(output *ListDietsUseCaseOutput) *ListDietsResponse {
+	data := output.Diets
+	if data == nil {
+		data = []*DietResponse{}
+	}
+
+	return &ListDietsResponse{
+		Data: data,
+		Page: PageResponse{
+			Limit:   output.Limit,
+			Count:   len(data),
+			HasMore: output.NextCursor != "",
+			Next:    output.NextCursor,
+		},
+	}
 }
 
-func NewListDietsUseCaseOutput(diets []*entity.Diet) *ListDietsUseCaseOutput {
+func NewListDietsUseCaseOutput(diets []*entity.Diet, limit int, nextCursor string) *ListDietsUseCaseOutput {
 	var dietsResponse []*DietResponse
 	for _, diet := range diets {
-		dietsResponse = append(dietsResponse, &DietResponse{
-			ID:             diet.ID,
-			UserEmail:      diet.UserEmail,
-			DietName:       diet.DietName,
-			DurationInDays: diet.DurationInDays,
-			Status:         diet.Status,
-			Meals:          convertMealsToMealResponse(diet.Meals),
-			Observations:   diet.Observations,
-			CreatedBy:      diet.CreatedBy,
-			CreatedAt:      diet.CreatedAt,
-			UpdatedAt:      diet.UpdatedAt,
-		})
+		dietsResponse = append(dietsResponse, NewDietResponse(diet))
 	}
 
 	return &ListDietsUseCaseOutput{
-		Diets: dietsResponse,
+		Diets:      dietsResponse,
+		Limit:      limit,
+		NextCursor: nextCursor,
+	}
+}
+
+// NewDietResponse converts a diet entity into its API representation
+func NewDietResponse(diet *entity.Diet) *DietResponse {
+	response := &DietResponse{
+		ID:             diet.ID,
+		UserEmail:      diet.UserEmail,
+		DietName:       diet.DietName,
+		DurationInDays: diet.DurationInDays,
+		Status:         diet.Status,
+		StartsAt:       diet.StartsAt,
+		EndsAt:         diet.EndsAt,
+		Meals:          convertMealsToMealResponse(diet.Meals),
+		Days:           convertDaysToDayTemplateResponse(diet.Days),
+		DayRule:        diet.DayRule,
+		Observations:   diet.Observations,
+		Texture:        diet.Texture,
+		CreatedBy:      diet.CreatedBy,
+		CreatedAt:      diet.CreatedAt,
+		UpdatedAt:      diet.UpdatedAt,
+		Version:        diet.Version,
+		DeletedAt:      diet.DeletedAt,
+	}
+
+	response.Nutrition = summarizeNutrition(diet.Nutrition, response.Meals, response.Days)
+	return response
+}
+
+// summarizeNutrition copia os valores calculados para as refeições e os
+// modelos de dia da resposta e retorna o resumo da dieta
+func summarizeNutrition(nutrition *entity.DietNutrition, meals []MealResponse, days []DayTemplateResponse) *DietNutritionSummary {
+	if nutrition == nil {
+		return nil
+	}
+
+	attachNutrition(meals, nutrition.Meals)
+	for i := range days {
+		if i >= len(nutrition.Days) {
+			break
+		}
+		days[i].Nutrition = &nutrition.Days[i].Nutrients
+		attachNutrition(days[i].Meals, nutrition.Days[i].Meals)
+	}
+
+	return &DietNutritionSummary{
+		PerDay:     nutrition.PerDay,
+		Total:      nutrition.Total,
+		MacroSplit: nutrition.MacroSplit,
+		Unresolved: nutrition.Unresolved,
+		Target:     nutrition.Target,
+	}
+}
+
+// attachNutrition copia os valores calculados para as refeições e ingredientes
+// da resposta, que seguem a mesma ordem da dieta
+func attachNutrition(meals []MealResponse, nutrition []entity.MealNutrition) {
+	for i := range meals {
+		if i >= len(nutrition) {
+			return
+		}
+
+		mealNutrition := nutrition[i]
+		meals[i].Nutrition = &mealNutrition.Nutrients
+
+		for j := range meals[i].Ingredients {
+			if j >= len(mealNutrition.Ingredients) {
+				break
+			}
+			meals[i].Ingredients[j].Grams = mealNutrition.Ingredients[j].Grams
+			meals[i].Ingredients[j].Nutrition = mealNutrition.Ingredients[j].Nutrients
+		}
+	}
+}
+
+func convertDaysToDayTemplateResponse(days []entity.DayTemplate) []DayTemplateResponse {
+	var dayResponses []DayTemplateResponse
+	for _, day := range days {
+		dayResponses = append(dayResponses, DayTemplateResponse{
+			Name:     day.Name,
+			Weekdays: day.Weekdays,
+			Meals:    convertMealsToMealResponse(day.Meals),
+		})
 	}
+	return dayResponses
 }
 
 func convertMealsToMealResponse(meals []entity.Meal) []MealResponse {
@@ -249,6 +701,9 @@ func convertMealsToMealResponse(meals []entity.Meal) []MealResponse {
 			Name:        meal.Name,
 			Description: meal.Description,
 			TimeOfDay:   meal.TimeOfDay,
+			WindowStart: meal.WindowStart,
+			WindowEnd:   meal.WindowEnd,
+			Slot:        meal.Slot,
 			Ingredients: convertIngredientsToIngredientResponse(meal.Ingredients),
 		})
 	}
@@ -259,10 +714,13 @@ func convertIngredientsToIngredientResponse(ingredients []entity.Ingredient) []I
 	var ingredientResponses []IngredientResponse
 	for _, ingredient := range ingredients {
 		ingredientResponses = append(ingredientResponses, IngredientResponse{
-			Description: ingredient.Description,
-			Quantity:    ingredient.Quantity,
-			Unit:        ingredient.Unit,
-			Substitutes: convertIngredientsToIngredientResponse(ingredient.Substitutes),
+			FoodID:           ingredient.FoodID,
+			Description:      ingredient.Description,
+			Quantity:         ingredient.Quantity,
+			Unit:             ingredient.Unit,
+			Substitutes:      convertIngredientsToIngredientResponse(ingredient.Substitutes),
+			NutrientsPer100g: ingredient.NutrientsPer100g,
+			UnitWeightG:      ingredient.UnitWeightG,
 		})
 	}
 	return ingredientResponses
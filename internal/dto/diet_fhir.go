@@ -0,0 +1,382 @@
+package dto
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/fhir"
+	"github.com/victorgiudicissi/your-diet/internal/mealtime"
+	"github.com/victorgiudicissi/your-diet/internal/units"
+)
+
+// fhirOrderPath is the FHIRPath of the imported resource
+const fhirOrderPath = "NutritionOrder"
+
+// nutritionOrderParser accumulates the schedules of the order into the diet request
+type nutritionOrderParser struct {
+	issues   []fhir.Issue
+	validate *validator.Validate
+}
+
+// fhirMeal keeps where each meal came from, to report its errors
+type fhirMeal struct {
+	path        string
+	request     MealRequest
+	ingredients []string
+}
+
+// ParseNutritionOrder reads a FHIR NutritionOrder into a diet request,
+// validating it with the rules of DietRequest.Validate. Every problem found
+// is returned as an issue pointing to its element. Meals are read from the
+// meal extension of each oral diet schedule, as written by the export.
+func ParseNutritionOrder(order *fhir.NutritionOrder, userEmail string) (*DietRequest, []fhir.Issue) {
+	p := &nutritionOrderParser{validate: newDietValidator()}
+
+	req := &DietRequest{UserEmail: strings.TrimSpace(userEmail)}
+	if req.UserEmail == "" {
+		p.fail(fhir.IssueRequired, "the patient must be identified by email (identifier mailto: or the telecom of the Patient)", dietPathsByField["UserEmail"])
+	}
+
+	switch order.Status {
+	case "draft":
+		req.Status = string(entity.DietDraft)
+	case "active", "on-hold", "unknown", "":
+	default:
+		p.fail(fhir.IssueValue, fmt.Sprintf("orders with status %q cannot be imported, use draft or active", order.Status), dietPathsByField["Status"])
+	}
+
+	var notes []string
+	for _, note := range order.Note {
+		if strings.TrimSpace(note.Text) != "" {
+			notes = append(notes, note.Text)
+		}
+	}
+	req.Observations = strings.Join(notes, "\n")
+
+	oralDiet := order.OralDiet
+	if oralDiet == nil {
+		p.fail(fhir.IssueRequired, "the order has no oralDiet", fhirOrderPath+".oralDiet")
+		return nil, p.issues
+	}
+
+	for _, dietType := range oralDiet.Type {
+		req.DietName = dietType.Text
+		if req.DietName == "" && len(dietType.Coding) > 0 {
+			req.DietName = dietType.Coding[0].Display
+		}
+		if req.DietName != "" {
+			break
+		}
+	}
+	req.DietName = strings.TrimSpace(req.DietName)
+
+	p.readTexture(req, oralDiet)
+	p.readPeriod(req, order)
+
+	meals := p.readSchedules(req, oralDiet.Schedule)
+	if len(meals) == 0 && len(p.issues) == 0 {
+		p.fail(fhir.IssueRequired, "the oral diet has no meals", fhirOrderPath+".oralDiet.schedule")
+	}
+
+	p.validateDiet(req, meals)
+	if len(p.issues) > 0 {
+		return nil, p.issues
+	}
+
+	// Regras que envolvem a dieta inteira, como a distribuição dos modelos de dia
+	if err := req.Validate(); err != nil {
+		return nil, []fhir.Issue{fhir.ErrorIssue(fhir.IssueInvalid, err.Error(), fhirOrderPath)}
+	}
+	return req, nil
+}
+
+func (p *nutritionOrderParser) fail(code, message, path string) {
+	p.issues = append(p.issues, fhir.ErrorIssue(code, message, path))
+}
+
+// failValidation reports a validator error on the element of the failing
+// field, or on path when the field has no element of its own
+func (p *nutritionOrderParser) failValidation(err error, path string, pathsByField map[string]string) {
+	code := fhir.IssueValue
+	if validationErrors, ok := err.(validator.ValidationErrors); ok && len(validationErrors) > 0 {
+		switch validationErrors[0].Tag() {
+		case "required", "required_without":
+			code = fhir.IssueRequired
+		}
+		if fieldPath, ok := pathsByField[validationErrors[0].Field()]; ok {
+			path = fieldPath
+		}
+	}
+	// O elemento que não pôde ser lido já foi apontado
+	for _, existing := range p.issues {
+		if len(existing.Expression) > 0 && existing.Expression[0] == path && path != fhirOrderPath {
+			return
+		}
+	}
+	p.fail(code, translateValidationError(err).Error(), path)
+}
+
+var dietPathsByField = map[string]string{
+	"UserEmail":      fhirOrderPath + ".patient",
+	"DietName":       fhirOrderPath + ".oralDiet.type",
+	"DurationInDays": fhirOrderPath + ".extension('" + fhir.ExtensionDiet + "').extension('durationInDays')",
+	"StartsAt":       fhirOrderPath + ".oralDiet.schedule.repeat.boundsPeriod",
+	"Status":         fhirOrderPath + ".status",
+	"Texture":        fhirOrderPath + ".oralDiet.texture",
+	"Type":           fhirOrderPath + ".extension('" + fhir.ExtensionDiet + "').extension('dayRule')",
+	"Rotation":       fhirOrderPath + ".extension('" + fhir.ExtensionDiet + "').extension('rotation')",
+}
+
+func (p *nutritionOrderParser) readTexture(req *DietRequest, oralDiet *fhir.OralDiet) {
+	for _, texture := range oralDiet.Texture {
+		if texture.Modifier == nil {
+			continue
+		}
+		for _, coding := range texture.Modifier.Coding {
+			if coding.System == fhir.SystemTexture {
+				req.Texture = coding.Code
+				return
+			}
+		}
+		// Uma textura que não entendemos não pode virar uma dieta sem restrição
+		p.fail(fhir.IssueValue, "unknown texture, use a coding of "+fhir.SystemTexture, dietPathsByField["Texture"])
+		return
+	}
+}
+
+// readPeriod reads the duration and the rule of the day templates from the
+// diet extension, and the first day from the bounds of the schedules
+func (p *nutritionOrderParser) readPeriod(req *DietRequest, order *fhir.NutritionOrder) {
+	var bounds *fhir.Period
+	for _, schedule := range order.OralDiet.Schedule {
+		if schedule.Repeat != nil && schedule.Repeat.BoundsPeriod != nil {
+			bounds = schedule.Repeat.BoundsPeriod
+			break
+		}
+	}
+
+	var start, end time.Time
+	if bounds != nil {
+		start, _ = parseFhirDate(bounds.Start)
+		end, _ = parseFhirDate(bounds.End)
+		if !start.IsZero() {
+			req.StartsAt = start.Format(fhir.DateLayout)
+		}
+	}
+
+	extension := fhir.FindExtension(order.Extension, fhir.ExtensionDiet)
+	if extension != nil {
+		if duration := fhir.FindExtension(extension.Extension, "durationInDays"); duration != nil && duration.ValueInteger != nil {
+			if *duration.ValueInteger < 0 || *duration.ValueInteger > math.MaxUint32 {
+				p.fail(fhir.IssueValue, "invalid durationInDays", dietPathsByField["DurationInDays"])
+			} else {
+				req.DurationInDays = uint32(*duration.ValueInteger)
+			}
+		}
+
+		if rule := fhir.FindExtension(extension.Extension, "dayRule"); rule != nil {
+			req.DayRule = &DayRuleRequest{Type: rule.Text()}
+			for _, item := range extension.Extension {
+				if item.URL == "rotation" {
+					req.DayRule.Rotation = append(req.DayRule.Rotation, strings.TrimSpace(item.Text()))
+				}
+			}
+		}
+	}
+
+	// Sem a extensão, a duração vem do período das refeições
+	if req.DurationInDays == 0 && !start.IsZero() && !end.IsZero() && !end.Before(start) {
+		req.DurationInDays = uint32(dayplan.DaysBetween(start, end)) + 1
+	}
+}
+
+// readSchedules turns each schedule into a meal of the diet or of its day template
+func (p *nutritionOrderParser) readSchedules(req *DietRequest, schedules []fhir.Timing) []*fhirMeal {
+	var meals []*fhirMeal
+	days := map[string]int{}
+
+	for i, schedule := range schedules {
+		path := fmt.Sprintf("%s.oralDiet.schedule[%d]", fhirOrderPath, i)
+		extension := fhir.FindExtension(schedule.Extension, fhir.ExtensionMeal)
+		if extension == nil {
+			p.fail(fhir.IssueRequired, "the schedule has no meal extension ("+fhir.ExtensionMeal+") with the foods of the meal", path)
+			continue
+		}
+
+		meal := p.readMeal(extension, schedule.Repeat, fmt.Sprintf("%s.extension('%s')", path, fhir.ExtensionMeal))
+		meal.path = path
+		meals = append(meals, meal)
+
+		dayName := ""
+		if day := fhir.FindExtension(extension.Extension, "dayTemplate"); day != nil {
+			dayName = strings.TrimSpace(day.Text())
+		}
+		if dayName == "" {
+			req.Meals = append(req.Meals, meal.request)
+			continue
+		}
+
+		position, ok := days[dayName]
+		if !ok {
+			position = len(req.Days)
+			days[dayName] = position
+			req.Days = append(req.Days, DayTemplateRequest{Name: dayName})
+		}
+		day := &req.Days[position]
+		day.Meals = append(day.Meals, meal.request)
+
+		if schedule.Repeat == nil {
+			continue
+		}
+		for _, code := range schedule.Repeat.DayOfWeek {
+			weekday, ok := fhir.Weekday(code)
+			if !ok {
+				p.fail(fhir.IssueValue, fmt.Sprintf("unknown dayOfWeek %q", code), path+".repeat.dayOfWeek")
+				continue
+			}
+			if !containsString(day.Weekdays, string(weekday)) {
+				day.Weekdays = append(day.Weekdays, string(weekday))
+			}
+		}
+	}
+	return meals
+}
+
+func (p *nutritionOrderParser) readMeal(extension *fhir.Extension, repeat *fhir.TimingRepeat, path string) *fhirMeal {
+	meal := &fhirMeal{}
+	text := func(url string) string {
+		item := fhir.FindExtension(extension.Extension, url)
+		return strings.TrimSpace(item.Text())
+	}
+
+	meal.request = MealRequest{
+		Name:        text("name"),
+		Description: text("description"),
+		TimeOfDay:   fhirClock(text("timeOfDay")),
+		WindowStart: fhirClock(text("windowStart")),
+		WindowEnd:   fhirClock(text("windowEnd")),
+		Slot:        text("slot"),
+	}
+	if meal.request.TimeOfDay == "" && repeat != nil && len(repeat.TimeOfDay) > 0 {
+		meal.request.TimeOfDay = fhirClock(repeat.TimeOfDay[0])
+	}
+
+	count := 0
+	for _, item := range extension.Extension {
+		if item.URL != "ingredient" {
+			continue
+		}
+		ingredientPath := fmt.Sprintf("%s.extension('ingredient')[%d]", path, count)
+		count++
+		meal.request.Ingredients = append(meal.request.Ingredients, p.readIngredient(item, ingredientPath))
+		meal.ingredients = append(meal.ingredients, ingredientPath)
+	}
+	return meal
+}
+
+func (p *nutritionOrderParser) readIngredient(extension fhir.Extension, path string) IngredientRequest {
+	var req IngredientRequest
+	count := 0
+	for _, item := range extension.Extension {
+		switch item.URL {
+		case "description":
+			req.Description = strings.TrimSpace(item.Text())
+		case "foodId":
+			req.FoodID = strings.TrimSpace(item.Text())
+		case "unitWeightG":
+			if item.ValueDecimal != nil {
+				req.UnitWeightG = *item.ValueDecimal
+			}
+		case "quantity":
+			if item.ValueQuantity != nil {
+				if item.ValueQuantity.Value != nil {
+					req.Quantity = *item.ValueQuantity.Value
+				}
+				req.Unit = quantityUnit(item.ValueQuantity)
+			}
+		case "substitute":
+			substitutePath := fmt.Sprintf("%s.extension('substitute')[%d]", path, count)
+			count++
+			substitute := p.readIngredient(item, substitutePath)
+			if err := p.validate.StructExcept(substitute, "Substitutes"); err != nil {
+				p.failValidation(err, substitutePath, nil)
+			}
+			req.Substitutes = append(req.Substitutes, substitute)
+		}
+	}
+	return req
+}
+
+// quantityUnit finds the unit by the code or, failing that, by its name
+func quantityUnit(quantity *fhir.Quantity) string {
+	for _, name := range []string{quantity.Code, quantity.Unit} {
+		if unit, err := units.Parse(name); err == nil {
+			return unit.Code
+		}
+	}
+	if quantity.Code != "" {
+		return quantity.Code
+	}
+	return quantity.Unit
+}
+
+// validateDiet checks the diet, the day templates, the meals and their
+// ingredients, reporting each error on its element
+func (p *nutritionOrderParser) validateDiet(req *DietRequest, meals []*fhirMeal) {
+	if err := p.validate.StructExcept(req, "Meals", "Days"); err != nil {
+		p.failValidation(err, fhirOrderPath, dietPathsByField)
+	}
+
+	for _, day := range req.Days {
+		if err := p.validate.StructExcept(day, "Meals"); err != nil {
+			p.failValidation(err, fhirOrderPath+".oralDiet.schedule", nil)
+		}
+	}
+
+	for _, meal := range meals {
+		if err := p.validate.StructExcept(meal.request, "Ingredients"); err != nil {
+			p.failValidation(err, meal.path, nil)
+		} else if err := mealtime.ValidateWindow(meal.request.TimeOfDay, meal.request.WindowStart, meal.request.WindowEnd); err != nil {
+			p.fail(fhir.IssueValue, fmt.Sprintf("Meal %q: %s", meal.request.Name, err.Error()), meal.path)
+		}
+
+		if len(meal.request.Ingredients) == 0 {
+			p.fail(fhir.IssueRequired, fmt.Sprintf("Meal %q has no ingredient", meal.request.Name), meal.path)
+		}
+		for i, ingredient := range meal.request.Ingredients {
+			if err := p.validate.StructExcept(ingredient, "Substitutes"); err != nil {
+				p.failValidation(err, meal.ingredients[i], nil)
+			}
+		}
+	}
+}
+
+// fhirClock converts a FHIR time ("07:30:00") to HH:MM, keeping other text
+func fhirClock(value string) string {
+	if parsed, err := time.Parse(fhir.TimeLayout, value); err == nil {
+		return parsed.Format("15:04")
+	}
+	return value
+}
+
+// parseFhirDate reads the date of a FHIR date or dateTime
+func parseFhirDate(value string) (time.Time, error) {
+	if len(value) > len(fhir.DateLayout) {
+		value = value[:len(fhir.DateLayout)]
+	}
+	return time.ParseInLocation(fhir.DateLayout, value, time.Local)
+}
+
+func containsString(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,646 @@
+package dto
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/mealtime"
+)
+
+// Columns of the tabular diet format. Each row is one ingredient; the diet,
+// day and meal columns repeat the values of the row they belong to.
+const (
+	ColumnDietName        = "diet_name"
+	ColumnUserEmail       = "user_email"
+	ColumnDurationInDays  = "duration_in_days"
+	ColumnStartsAt        = "starts_at"
+	ColumnStatus          = "status"
+	ColumnObservations    = "observations"
+	ColumnTexture         = "texture"
+	ColumnDayRule         = "day_rule"
+	ColumnRotation        = "rotation"
+	ColumnDay             = "day"
+	ColumnWeekdays        = "weekdays"
+	ColumnMeal            = "meal"
+	ColumnTimeOfDay       = "time_of_day"
+	ColumnWindowStart     = "window_start"
+	ColumnWindowEnd       = "window_end"
+	ColumnSlot            = "slot"
+	ColumnMealDescription = "meal_description"
+	ColumnIngredient      = "ingredient"
+	ColumnSubstituteOf    = "substitute_of"
+	ColumnFoodID          = "food_id"
+	ColumnQuantity        = "quantity"
+	ColumnUnit            = "unit"
+	ColumnUnitWeightG     = "unit_weight_g"
+)
+
+// nutrientColumns are the optional composition per 100 g of the ingredient
+var nutrientColumns = []struct {
+	name  string
+	field string
+	get   func(n *NutrientsRequest) *float64
+}{
+	{"energy_kcal", "EnergyKcal", func(n *NutrientsRequest) *float64 { return &n.EnergyKcal }},
+	{"protein_g", "ProteinG", func(n *NutrientsRequest) *float64 { return &n.ProteinG }},
+	{"carbohydrate_g", "CarbohydrateG", func(n *NutrientsRequest) *float64 { return &n.CarbohydrateG }},
+	{"fat_g", "FatG", func(n *NutrientsRequest) *float64 { return &n.FatG }},
+	{"fiber_g", "FiberG", func(n *NutrientsRequest) *float64 { return &n.FiberG }},
+	{"cholesterol_mg", "CholesterolMg", func(n *NutrientsRequest) *float64 { return &n.CholesterolMg }},
+	{"sodium_mg", "SodiumMg", func(n *NutrientsRequest) *float64 { return &n.SodiumMg }},
+	{"calcium_mg", "CalciumMg", func(n *NutrientsRequest) *float64 { return &n.CalciumMg }},
+	{"iron_mg", "IronMg", func(n *NutrientsRequest) *float64 { return &n.IronMg }},
+	{"potassium_mg", "PotassiumMg", func(n *NutrientsRequest) *float64 { return &n.PotassiumMg }},
+	{"magnesium_mg", "MagnesiumMg", func(n *NutrientsRequest) *float64 { return &n.MagnesiumMg }},
+	{"vitamin_c_mg", "VitaminCMg", func(n *NutrientsRequest) *float64 { return &n.VitaminCMg }},
+}
+
+// DietTableColumns lists the columns in the order they are exported
+var DietTableColumns = func() []string {
+	columns := []string{
+		ColumnDietName, ColumnUserEmail, ColumnDurationInDays, ColumnStartsAt, ColumnStatus, ColumnObservations,
+		ColumnTexture, ColumnDayRule, ColumnRotation, ColumnDay, ColumnWeekdays,
+		ColumnMeal, ColumnTimeOfDay, ColumnWindowStart, ColumnWindowEnd, ColumnSlot, ColumnMealDescription,
+		ColumnIngredient, ColumnSubstituteOf, ColumnFoodID, ColumnQuantity, ColumnUnit, ColumnUnitWeightG,
+	}
+	for _, column := range nutrientColumns {
+		columns = append(columns, column.name)
+	}
+	return columns
+}()
+
+// dietColumns hold one value for the whole file, dayColumns one per day and
+// mealColumns one per meal
+var (
+	dietColumns = []string{ColumnDietName, ColumnUserEmail, ColumnDurationInDays, ColumnStartsAt, ColumnStatus, ColumnObservations, ColumnTexture, ColumnDayRule, ColumnRotation}
+	dayColumns  = []string{ColumnWeekdays}
+	mealColumns = []string{ColumnWindowStart, ColumnWindowEnd, ColumnSlot, ColumnMealDescription}
+)
+
+// requiredColumns must be in the header of every file
+var requiredColumns = []string{ColumnDietName, ColumnMeal, ColumnTimeOfDay, ColumnIngredient, ColumnQuantity, ColumnUnit}
+
+// RowError is a problem found in a row of an imported spreadsheet. Row is the
+// line in the spreadsheet, counting the header as 1; errors of the diet as a
+// whole have no row.
+type RowError struct {
+	Row     int    `json:"row,omitempty"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// DietImportErrorResponse lists every problem found in the imported file
+type DietImportErrorResponse struct {
+	Field   string     `json:"field"`
+	Message string     `json:"message"`
+	Errors  []RowError `json:"errors"`
+}
+
+// tableIngredient keeps the substitutes of an ingredient while the rows are read
+type tableIngredient struct {
+	request     IngredientRequest
+	substitutes []*tableIngredient
+}
+
+type tableMeal struct {
+	row         int
+	request     MealRequest
+	ingredients []*tableIngredient
+	// all holds the ingredients and substitutes in row order, to resolve substitute_of
+	all []*tableIngredient
+}
+
+type tableDay struct {
+	row     int
+	request DayTemplateRequest
+	meals   []*tableMeal
+}
+
+// dietTableParser accumulates the rows into the diet request
+type dietTableParser struct {
+	columns  map[string]int
+	errors   []RowError
+	values   map[string]string
+	valueRow map[string]int
+	dietRow  int
+	meals    []*tableMeal
+	days     []*tableDay
+	mealKeys map[string]*tableMeal
+	dayKeys  map[string]*tableDay
+	validate *validator.Validate
+}
+
+// ParseDietTable reads the rows of a spreadsheet (header first) into a diet
+// request, validating each row with the rules of DietRequest.Validate. Every
+// problem found is returned, with the row it came from. userEmail is the
+// patient when the file has no user_email.
+func ParseDietTable(rows [][]string, userEmail string) (*DietRequest, []RowError) {
+	p := &dietTableParser{
+		columns:  map[string]int{},
+		values:   map[string]string{},
+		valueRow: map[string]int{},
+		mealKeys: map[string]*tableMeal{},
+		dayKeys:  map[string]*tableDay{},
+		validate: newDietValidator(),
+	}
+
+	if len(rows) == 0 {
+		return nil, []RowError{{Message: "the file is empty"}}
+	}
+
+	if !p.readHeader(rows[0]) {
+		return nil, p.errors
+	}
+
+	for i, row := range rows[1:] {
+		if isBlankRow(row) {
+			continue
+		}
+		p.readRow(i+2, row)
+	}
+
+	if p.value("", ColumnUserEmail) == "" {
+		p.values["\x00"+ColumnUserEmail] = userEmail
+	}
+
+	if len(p.meals) == 0 && len(p.days) == 0 && len(p.errors) == 0 {
+		return nil, []RowError{{Message: "the file has no ingredient rows"}}
+	}
+
+	req := p.request()
+	p.validateDiet(req)
+	if len(p.errors) > 0 {
+		sort.SliceStable(p.errors, func(i, j int) bool { return p.errors[i].Row < p.errors[j].Row })
+		return nil, p.errors
+	}
+
+	// Regras que envolvem a dieta inteira, como a distribuição dos modelos de dia
+	if err := req.Validate(); err != nil {
+		return nil, []RowError{{Message: err.Error()}}
+	}
+	return req, nil
+}
+
+func (p *dietTableParser) readHeader(header []string) bool {
+	known := map[string]bool{}
+	for _, column := range DietTableColumns {
+		known[column] = true
+	}
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		switch {
+		case name == "":
+			continue
+		case !known[name]:
+			p.fail(1, name, fmt.Sprintf("unknown column %q", name))
+		case p.has(name):
+			p.fail(1, name, fmt.Sprintf("column %q appears more than once", name))
+		default:
+			p.columns[name] = i
+		}
+	}
+
+	for _, column := range requiredColumns {
+		if !p.has(column) {
+			p.fail(1, column, fmt.Sprintf("missing required column %q", column))
+		}
+	}
+	return len(p.errors) == 0
+}
+
+func (p *dietTableParser) readRow(number int, row []string) {
+	get := func(column string) string {
+		index, ok := p.columns[column]
+		if !ok || index >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[index])
+	}
+
+	if p.dietRow == 0 {
+		p.dietRow = number
+	}
+	for _, column := range dietColumns {
+		p.single(number, column, "", get(column))
+	}
+
+	var day *tableDay
+	if name := get(ColumnDay); name != "" {
+		key := strings.ToLower(name)
+		day = p.dayKeys[key]
+		if day == nil {
+			day = &tableDay{row: number, request: DayTemplateRequest{Name: name}}
+			p.dayKeys[key] = day
+			p.days = append(p.days, day)
+		}
+		for _, column := range dayColumns {
+			p.single(number, column, "day:"+key, get(column))
+		}
+	}
+
+	timeOfDay := parseClockCell(get(ColumnTimeOfDay))
+	mealKey := strings.ToLower(get(ColumnDay) + "\x00" + get(ColumnMeal) + "\x00" + timeOfDay)
+	meal := p.mealKeys[mealKey]
+	if meal == nil {
+		meal = &tableMeal{row: number, request: MealRequest{Name: get(ColumnMeal), TimeOfDay: timeOfDay}}
+		p.mealKeys[mealKey] = meal
+		if day != nil {
+			day.meals = append(day.meals, meal)
+		} else {
+			p.meals = append(p.meals, meal)
+		}
+	}
+	for _, column := range mealColumns {
+		value := get(column)
+		if column == ColumnWindowStart || column == ColumnWindowEnd {
+			value = parseClockCell(value)
+		}
+		p.single(number, column, "meal:"+mealKey, value)
+	}
+
+	ingredient := &tableIngredient{request: IngredientRequest{
+		Description: get(ColumnIngredient),
+		FoodID:      get(ColumnFoodID),
+		Unit:        get(ColumnUnit),
+	}}
+
+	ingredient.request.Quantity = p.number(number, ColumnQuantity, get(ColumnQuantity))
+	ingredient.request.UnitWeightG = p.number(number, ColumnUnitWeightG, get(ColumnUnitWeightG))
+
+	for _, column := range nutrientColumns {
+		value := get(column.name)
+		if value == "" {
+			continue
+		}
+		if ingredient.request.NutrientsPer100g == nil {
+			ingredient.request.NutrientsPer100g = &NutrientsRequest{}
+		}
+		*column.get(ingredient.request.NutrientsPer100g) = p.number(number, column.name, value)
+	}
+
+	if err := p.validate.Struct(ingredient.request); err != nil {
+		p.failValidation(number, err, ingredientColumnsByField)
+	}
+
+	if parent := get(ColumnSubstituteOf); parent != "" {
+		target := p.findIngredient(meal, parent)
+		if target == nil {
+			p.fail(number, ColumnSubstituteOf, fmt.Sprintf("no previous ingredient %q in meal %q", parent, meal.request.Name))
+			return
+		}
+		target.substitutes = append(target.substitutes, ingredient)
+	} else {
+		meal.ingredients = append(meal.ingredients, ingredient)
+	}
+	meal.all = append(meal.all, ingredient)
+}
+
+// findIngredient returns the closest previous row of the meal with the description
+func (p *dietTableParser) findIngredient(meal *tableMeal, description string) *tableIngredient {
+	for i := len(meal.all) - 1; i >= 0; i-- {
+		if strings.EqualFold(meal.all[i].request.Description, description) {
+			return meal.all[i]
+		}
+	}
+	return nil
+}
+
+// single keeps the first value of a column that holds one value per scope,
+// such as the diet or the meal; empty cells repeat it and other values are errors
+func (p *dietTableParser) single(row int, column, scope, value string) {
+	if value == "" {
+		return
+	}
+
+	key := scope + "\x00" + column
+	current, ok := p.values[key]
+	if !ok {
+		p.values[key] = value
+		p.valueRow[key] = row
+		return
+	}
+	if current != value {
+		p.fail(row, column, fmt.Sprintf("%q differs from %q given in row %d", value, current, p.valueRow[key]))
+	}
+}
+
+func (p *dietTableParser) value(scope, column string) string {
+	return p.values[scope+"\x00"+column]
+}
+
+// number reads a quantity, accepting the decimal comma
+func (p *dietTableParser) number(row int, column, value string) float64 {
+	if value == "" {
+		return 0
+	}
+	number, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		p.fail(row, column, fmt.Sprintf("%q is not a number", value))
+		return 0
+	}
+	return number
+}
+
+func (p *dietTableParser) has(column string) bool {
+	_, ok := p.columns[column]
+	return ok
+}
+
+func (p *dietTableParser) fail(row int, column, message string) {
+	p.errors = append(p.errors, RowError{Row: row, Column: column, Message: message})
+}
+
+// failValidation reports a validator error on the column of the failing field
+func (p *dietTableParser) failValidation(row int, err error, columnsByField map[string]string) {
+	column := ""
+	if validationErrors, ok := err.(validator.ValidationErrors); ok && len(validationErrors) > 0 {
+		field := validationErrors[0].Field()
+		column = columnsByField[field]
+		for _, nutrient := range nutrientColumns {
+			if column == "" && nutrient.field == field {
+				column = nutrient.name
+			}
+		}
+	}
+	// O valor que não pôde ser lido já foi apontado nesta coluna
+	for _, existing := range p.errors {
+		if existing.Row == row && existing.Column == column && column != "" {
+			return
+		}
+	}
+	p.fail(row, column, translateValidationError(err).Error())
+}
+
+var (
+	ingredientColumnsByField = map[string]string{
+		"Description": ColumnIngredient,
+		"Quantity":    ColumnQuantity,
+		"Unit":        ColumnUnit,
+		"UnitWeightG": ColumnUnitWeightG,
+	}
+	mealColumnsByField = map[string]string{
+		"Name":        ColumnMeal,
+		"TimeOfDay":   ColumnTimeOfDay,
+		"WindowStart": ColumnWindowStart,
+		"WindowEnd":   ColumnWindowEnd,
+		"Slot":        ColumnSlot,
+	}
+	dayColumnsByField = map[string]string{
+		"Name":     ColumnDay,
+		"Weekdays": ColumnWeekdays,
+	}
+	dietColumnsByField = map[string]string{
+		"UserEmail":      ColumnUserEmail,
+		"DietName":       ColumnDietName,
+		"DurationInDays": ColumnDurationInDays,
+		"StartsAt":       ColumnStartsAt,
+		"Status":         ColumnStatus,
+		"Texture":        ColumnTexture,
+		"Type":           ColumnDayRule,
+		"Rotation":       ColumnRotation,
+	}
+)
+
+// request assembles the diet request from the rows read
+func (p *dietTableParser) request() *DietRequest {
+	req := &DietRequest{
+		UserEmail:    p.value("", ColumnUserEmail),
+		DietName:     p.value("", ColumnDietName),
+		StartsAt:     parseDateCell(p.value("", ColumnStartsAt)),
+		Status:       strings.ToUpper(p.value("", ColumnStatus)),
+		Observations: p.value("", ColumnObservations),
+		Texture:      strings.ToUpper(p.value("", ColumnTexture)),
+	}
+
+	if duration := p.value("", ColumnDurationInDays); duration != "" {
+		days, err := strconv.ParseFloat(duration, 64)
+		if err != nil || days < 0 || days > math.MaxUint32 || days != math.Trunc(days) {
+			p.fail(p.valueRow["\x00"+ColumnDurationInDays], ColumnDurationInDays, fmt.Sprintf("%q is not a whole number of days", duration))
+		} else {
+			req.DurationInDays = uint32(days)
+		}
+	}
+
+	if ruleType := p.value("", ColumnDayRule); ruleType != "" {
+		req.DayRule = &DayRuleRequest{
+			Type:     strings.ToUpper(ruleType),
+			Rotation: splitList(p.value("", ColumnRotation), ";"),
+		}
+	}
+
+	for _, meal := range p.meals {
+		req.Meals = append(req.Meals, p.mealRequest(meal, ""))
+	}
+
+	for _, day := range p.days {
+		key := strings.ToLower(day.request.Name)
+		day.request.Weekdays = splitList(strings.ToUpper(p.value("day:"+key, ColumnWeekdays)), ";,")
+		for _, meal := range day.meals {
+			day.request.Meals = append(day.request.Meals, p.mealRequest(meal, day.request.Name))
+		}
+		req.Days = append(req.Days, day.request)
+	}
+	return req
+}
+
+func (p *dietTableParser) mealRequest(meal *tableMeal, dayName string) MealRequest {
+	scope := "meal:" + strings.ToLower(dayName+"\x00"+meal.request.Name+"\x00"+meal.request.TimeOfDay)
+	req := meal.request
+	req.WindowStart = p.value(scope, ColumnWindowStart)
+	req.WindowEnd = p.value(scope, ColumnWindowEnd)
+	req.Slot = strings.ToUpper(p.value(scope, ColumnSlot))
+	req.Description = p.value(scope, ColumnMealDescription)
+	req.Ingredients = ingredientRequests(meal.ingredients)
+	return req
+}
+
+func ingredientRequests(ingredients []*tableIngredient) []IngredientRequest {
+	requests := make([]IngredientRequest, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		req := ingredient.request
+		req.Substitutes = ingredientRequests(ingredient.substitutes)
+		requests = append(requests, req)
+	}
+	return requests
+}
+
+// validateDiet checks the diet, day and meal columns, reporting each error on
+// the first row of the diet, day or meal
+func (p *dietTableParser) validateDiet(req *DietRequest) {
+	if err := p.validate.StructExcept(req, "Meals", "Days"); err != nil {
+		p.failValidation(p.dietRow, err, dietColumnsByField)
+	}
+
+	for i, day := range p.days {
+		if err := p.validate.StructExcept(req.Days[i], "Meals"); err != nil {
+			p.failValidation(day.row, err, dayColumnsByField)
+		}
+		for j, meal := range day.meals {
+			p.validateMeal(meal, req.Days[i].Meals[j])
+		}
+	}
+
+	for i, meal := range p.meals {
+		p.validateMeal(meal, req.Meals[i])
+	}
+}
+
+func (p *dietTableParser) validateMeal(meal *tableMeal, req MealRequest) {
+	if err := p.validate.StructExcept(req, "Ingredients"); err != nil {
+		p.failValidation(meal.row, err, mealColumnsByField)
+		return
+	}
+	if err := mealtime.ValidateWindow(req.TimeOfDay, req.WindowStart, req.WindowEnd); err != nil {
+		p.fail(meal.row, ColumnWindowStart, fmt.Sprintf("Meal %q: %s", req.Name, err.Error()))
+	}
+}
+
+// parseClockCell accepts a time typed in a spreadsheet, which XLSX stores as
+// a fraction of the day (0.3125 is 07:30)
+func parseClockCell(value string) string {
+	fraction, err := strconv.ParseFloat(value, 64)
+	if err != nil || fraction < 0 || fraction >= 1 || !strings.Contains(value, ".") {
+		return value
+	}
+	return mealtime.FormatClock(int(math.Round(fraction * 24 * 60)))
+}
+
+// parseDateCell accepts a date typed in a spreadsheet, which XLSX stores as
+// the number of days since 1899-12-30, and DD/MM/AAAA
+func parseDateCell(value string) string {
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 0 {
+		return time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(serial)).Format("2006-01-02")
+	}
+	if parsed, err := time.Parse("02/01/2006", value); err == nil {
+		return parsed.Format("2006-01-02")
+	}
+	return value
+}
+
+func splitList(value, separators string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return strings.ContainsRune(separators, r) }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// NewDietTable writes the diet in the tabular format, one row per ingredient
+// and substitute, so that ParseDietTable reads back the same plan
+func NewDietTable(diet *entity.Diet) [][]string {
+	rows := [][]string{DietTableColumns}
+	index := map[string]int{}
+	for i, column := range DietTableColumns {
+		index[column] = i
+	}
+
+	first := true
+	addRow := func(values map[string]string) {
+		row := make([]string, len(DietTableColumns))
+		row[index[ColumnDietName]] = diet.DietName
+		if first {
+			row[index[ColumnUserEmail]] = diet.UserEmail
+			row[index[ColumnDurationInDays]] = strconv.FormatUint(uint64(diet.DurationInDays), 10)
+			if diet.StartsAt != nil {
+				row[index[ColumnStartsAt]] = diet.StartsAt.Format("2006-01-02")
+			}
+			if entity.DietStatus(diet.Status) == entity.DietDraft {
+				row[index[ColumnStatus]] = diet.Status
+			}
+			row[index[ColumnObservations]] = diet.Observations
+			row[index[ColumnTexture]] = string(diet.Texture)
+			if diet.DayRule != nil {
+				row[index[ColumnDayRule]] = string(diet.DayRule.Type)
+				row[index[ColumnRotation]] = strings.Join(diet.DayRule.Rotation, ";")
+			}
+			first = false
+		}
+		for column, value := range values {
+			row[index[column]] = value
+		}
+		rows = append(rows, row)
+	}
+
+	writeMeals := func(meals []entity.Meal, day *entity.DayTemplate) {
+		firstOfDay := true
+		for _, meal := range meals {
+			firstOfMeal := true
+			var writeIngredient func(ingredient entity.Ingredient, substituteOf string)
+			writeIngredient = func(ingredient entity.Ingredient, substituteOf string) {
+				values := map[string]string{
+					ColumnMeal:         meal.Name,
+					ColumnTimeOfDay:    meal.TimeOfDay,
+					ColumnIngredient:   ingredient.Description,
+					ColumnSubstituteOf: substituteOf,
+					ColumnFoodID:       ingredient.FoodID,
+					ColumnQuantity:     formatTableNumber(ingredient.Quantity),
+					ColumnUnit:         ingredient.Unit,
+				}
+				if ingredient.UnitWeightG != 0 {
+					values[ColumnUnitWeightG] = formatTableNumber(ingredient.UnitWeightG)
+				}
+				if ingredient.NutrientsPer100g != nil {
+					nutrients := NutrientsRequest(*ingredient.NutrientsPer100g)
+					for _, column := range nutrientColumns {
+						values[column.name] = formatTableNumber(*column.get(&nutrients))
+					}
+				}
+				if day != nil {
+					values[ColumnDay] = day.Name
+					if firstOfDay {
+						weekdays := make([]string, 0, len(day.Weekdays))
+						for _, weekday := range day.Weekdays {
+							weekdays = append(weekdays, string(weekday))
+						}
+						values[ColumnWeekdays] = strings.Join(weekdays, ";")
+						firstOfDay = false
+					}
+				}
+				if firstOfMeal {
+					values[ColumnWindowStart] = meal.WindowStart
+					values[ColumnWindowEnd] = meal.WindowEnd
+					values[ColumnSlot] = string(meal.Slot)
+					values[ColumnMealDescription] = meal.Description
+					firstOfMeal = false
+				}
+				addRow(values)
+
+				for _, substitute := range ingredient.Substitutes {
+					writeIngredient(substitute, ingredient.Description)
+				}
+			}
+
+			for _, ingredient := range meal.Ingredients {
+				writeIngredient(ingredient, "")
+			}
+		}
+	}
+
+	writeMeals(diet.Meals, nil)
+	for i := range diet.Days {
+		writeMeals(diet.Days[i].Meals, &diet.Days[i])
+	}
+	return rows
+}
+
+func formatTableNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// DietTemplateRequest represents the request body to create or edit a diet
+// template. It has the same plan of a diet, without patient or dates.
+type DietTemplateRequest struct {
+	Name           string               `json:"name" validate:"required,min=3,max=100"`
+	Description    string               `json:"description" validate:"max=500"`
+	DurationInDays uint32               `json:"duration_in_days" validate:"required,min=1"`
+	Meals          []MealRequest        `json:"meals" validate:"required_without=Days,dive"`
+	Days           []DayTemplateRequest `json:"days" validate:"omitempty,dive"`
+	DayRule        *DayRuleRequest      `json:"day_rule"`
+	Observations   string               `json:"observations"`
+}
+
+// Validate validates the template with the same rules of DietRequest
+func (t *DietTemplateRequest) Validate() error {
+	if err := newDietValidator().Struct(t); err != nil {
+		return translateValidationError(err)
+	}
+	return validateMealPlan(t.Meals, t.Days, t.DayRule)
+}
+
+// ConvertToDietTemplate converts the request to the entity
+func ConvertToDietTemplate(ownerID string, req *DietTemplateRequest) (*entity.DietTemplate, error) {
+	meals, days, dayRule, err := convertMealPlan(req.Meals, req.Days, req.DayRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.DietTemplate{
+		OwnerID:        ownerID,
+		Name:           req.Name,
+		Description:    req.Description,
+		DurationInDays: req.DurationInDays,
+		Meals:          meals,
+		Days:           days,
+		DayRule:        dayRule,
+		Observations:   req.Observations,
+	}, nil
+}
+
+// DietCopyRequest represents the request body to create a diet for a patient
+// from a template or from another diet. The calories are scaled to
+// target_kcal, or to the energy target of the patient when
+// scale_to_patient_target is set; otherwise the quantities are kept.
+type DietCopyRequest struct {
+	UserEmail            string   `json:"user_email" binding:"required,email"`
+	DietName             string   `json:"name" binding:"omitempty,min=3,max=100"`
+	StartsAt             string   `json:"starts_at" binding:"omitempty,datetime=2006-01-02"`
+	Status               string   `json:"status" binding:"omitempty,oneof=DRAFT"`
+	TargetKcal           *float64 `json:"target_kcal" binding:"omitempty,gt=0,lte=10000"`
+	ScaleToPatientTarget bool     `json:"scale_to_patient_target"`
+}
+
+// StartDate returns the informed first day of the diet
+func (r *DietCopyRequest) StartDate() (*time.Time, error) {
+	return parseStartsAt(r.StartsAt)
+}
+
+type DietTemplateResponse struct {
+	ID             string                `json:"id"`
+	OwnerID        string                `json:"owner_id"`
+	Name           string                `json:"name"`
+	Description    string                `json:"description,omitempty"`
+	DurationInDays uint32                `json:"duration_in_days"`
+	Meals          []MealResponse        `json:"meals"`
+	Days           []DayTemplateResponse `json:"days,omitempty"`
+	DayRule        *entity.DayRule       `json:"day_rule,omitempty"`
+	Observations   string                `json:"observations"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	Nutrition      *DietNutritionSummary `json:"nutrition,omitempty"`
+}
+
+// NewDietTemplateResponse converts a template entity into its API representation
+func NewDietTemplateResponse(template *entity.DietTemplate) *DietTemplateResponse {
+	response := &DietTemplateResponse{
+		ID:             template.ID,
+		OwnerID:        template.OwnerID,
+		Name:           template.Name,
+		Description:    template.Description,
+		DurationInDays: template.DurationInDays,
+		Meals:          convertMealsToMealResponse(template.Meals),
+		Days:           convertDaysToDayTemplateResponse(template.Days),
+		DayRule:        template.DayRule,
+		Observations:   template.Observations,
+		CreatedAt:      template.CreatedAt,
+		UpdatedAt:      template.UpdatedAt,
+	}
+
+	response.Nutrition = summarizeNutrition(template.Nutrition, response.Meals, response.Days)
+	return response
+}
+
+// NewDietTemplatesResponse converts a list of templates
+func NewDietTemplatesResponse(templates []*entity.DietTemplate) []*DietTemplateResponse {
+	responses := make([]*DietTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		responses = append(responses, NewDietTemplateResponse(template))
+	}
+	return responses
+}
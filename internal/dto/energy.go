@@ -0,0 +1,36 @@
+package dto
+
+import "github.com/victorgiudicissi/your-diet/internal/entity"
+
+// AnthropometricsRequest defines the expected request body to inform the
+// anthropometrics of a patient.
+type AnthropometricsRequest struct {
+	HeightCm              float64  `json:"height_cm" binding:"required,gt=0"`
+	WeightKg              float64  `json:"weight_kg" binding:"required,gt=0"`
+	BodyFatPct            *float64 `json:"body_fat_pct"`
+	Sex                   string   `json:"sex" binding:"omitempty,oneof=male female"`
+	ActivityLevel         string   `json:"activity_level" binding:"required,oneof=SEDENTARY LIGHT MODERATE ACTIVE VERY_ACTIVE"`
+	Equation              string   `json:"equation" binding:"omitempty,oneof=MIFFLIN_ST_JEOR HARRIS_BENEDICT KATCH_MCARDLE"`
+	Goal                  string   `json:"goal" binding:"required,oneof=LOSE_WEIGHT MAINTAIN GAIN_WEIGHT"`
+	TargetWeightKg        *float64 `json:"target_weight_kg" binding:"omitempty,gt=0"`
+	CalorieAdjustmentKcal *float64 `json:"calorie_adjustment_kcal"`
+	ProteinGPerKg         *float64 `json:"protein_g_per_kg"`
+	FatPct                *float64 `json:"fat_pct"`
+}
+
+// ConvertToAnthropometrics converts the request to the entity
+func ConvertToAnthropometrics(r *AnthropometricsRequest) *entity.Anthropometrics {
+	return &entity.Anthropometrics{
+		HeightCm:              r.HeightCm,
+		WeightKg:              r.WeightKg,
+		BodyFatPct:            r.BodyFatPct,
+		Sex:                   r.Sex,
+		ActivityLevel:         entity.ActivityLevel(r.ActivityLevel),
+		Equation:              entity.EnergyEquation(r.Equation),
+		Goal:                  entity.NutritionGoal(r.Goal),
+		TargetWeightKg:        r.TargetWeightKg,
+		CalorieAdjustmentKcal: r.CalorieAdjustmentKcal,
+		ProteinGPerKg:         r.ProteinGPerKg,
+		FatPct:                r.FatPct,
+	}
+}
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ExportTemplateRequest customizes the exported diets of the nutritionist.
+// Logo is a PNG or JPEG image encoded in base64; empty removes the logo.
+type ExportTemplateRequest struct {
+	ProfessionalName string   `json:"professional_name" binding:"max=100"`
+	ClinicName       string   `json:"clinic_name" binding:"max=100"`
+	HeaderLines      []string `json:"header_lines" binding:"max=5,dive,max=120"`
+	Logo             string   `json:"logo"`
+	AccentColor      string   `json:"accent_color" binding:"omitempty,hexcolor,len=7"`
+	Footer           string   `json:"footer" binding:"max=200"`
+	ShowNutrition    bool     `json:"show_nutrition"`
+}
+
+// ExportTemplateResponse returns the logo in base64 as it was sent
+type ExportTemplateResponse struct {
+	ProfessionalName string    `json:"professional_name"`
+	ClinicName       string    `json:"clinic_name"`
+	HeaderLines      []string  `json:"header_lines"`
+	Logo             string    `json:"logo,omitempty"`
+	AccentColor      string    `json:"accent_color"`
+	Footer           string    `json:"footer"`
+	ShowNutrition    bool      `json:"show_nutrition"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func ConvertToExportTemplate(req *ExportTemplateRequest) (*entity.ExportTemplate, error) {
+	logo, err := base64.StdEncoding.DecodeString(req.Logo)
+	if err != nil {
+		return nil, fmt.Errorf("logo deve estar em base64: %v", err)
+	}
+
+	return &entity.ExportTemplate{
+		ProfessionalName: req.ProfessionalName,
+		ClinicName:       req.ClinicName,
+		HeaderLines:      req.HeaderLines,
+		Logo:             logo,
+		AccentColor:      req.AccentColor,
+		Footer:           req.Footer,
+		ShowNutrition:    req.ShowNutrition,
+	}, nil
+}
+
+func NewExportTemplateResponse(template *entity.ExportTemplate) *ExportTemplateResponse {
+	response := &ExportTemplateResponse{
+		ProfessionalName: template.ProfessionalName,
+		ClinicName:       template.ClinicName,
+		HeaderLines:      template.HeaderLines,
+		AccentColor:      template.AccentColor,
+		Footer:           template.Footer,
+		ShowNutrition:    template.ShowNutrition,
+		UpdatedAt:        template.UpdatedAt,
+	}
+	if len(template.Logo) > 0 {
+		response.Logo = base64.StdEncoding.EncodeToString(template.Logo)
+	}
+	return response
+}
@@ -0,0 +1,8 @@
+package dto
+
+// FoodMeasuresRequest defines the food-specific measures used to convert
+// household measures and units to grams.
+type FoodMeasuresRequest struct {
+	DensityGPerMl float64            `json:"density_g_per_ml" binding:"min=0"`
+	GramWeights   map[string]float64 `json:"gram_weights"`
+}
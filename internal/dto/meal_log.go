@@ -0,0 +1,43 @@
+package dto
+
+import "github.com/victorgiudicissi/your-diet/internal/entity"
+
+// MealLogRequest defines the expected request body to log a meal of a diet.
+type MealLogRequest struct {
+	MealName   string                   `json:"meal_name" binding:"required"`
+	Date       string                   `json:"date"`
+	Status     string                   `json:"status" binding:"required,oneof=FOLLOWED PARTIAL SKIPPED SUBSTITUTED"`
+	Substitute *ChosenSubstituteRequest `json:"substitute"`
+	Notes      string                   `json:"notes" binding:"max=1000"`
+}
+
+// ChosenSubstituteRequest identifies the prescribed substitute eaten instead
+// of an ingredient of the meal.
+type ChosenSubstituteRequest struct {
+	Ingredient string `json:"ingredient" binding:"required"`
+	Substitute string `json:"substitute" binding:"required"`
+}
+
+// ReviewMealLogRequest defines the expected request body to review a meal log.
+type ReviewMealLogRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+// ConvertToMealLog converts the request to the entity
+func ConvertToMealLog(req *MealLogRequest) *entity.MealLog {
+	log := &entity.MealLog{
+		MealName: req.MealName,
+		Date:     req.Date,
+		Status:   entity.MealLogStatus(req.Status),
+		Notes:    req.Notes,
+	}
+
+	if req.Substitute != nil {
+		log.Substitute = &entity.ChosenSubstitute{
+			Ingredient: req.Substitute.Ingredient,
+			Substitute: req.Substitute.Substitute,
+		}
+	}
+
+	return log
+}
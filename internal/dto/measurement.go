@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// MeasurementRequest defines the expected request body to record a body
+// measurement. Patients only send weight_kg, measured_at and notes.
+type MeasurementRequest struct {
+	MeasuredAt *time.Time        `json:"measured_at"`
+	WeightKg   *float64          `json:"weight_kg" binding:"omitempty,gt=0"`
+	HeightCm   *float64          `json:"height_cm" binding:"omitempty,gt=0"`
+	WaistCm    *float64          `json:"waist_cm" binding:"omitempty,gt=0"`
+	HipCm      *float64          `json:"hip_cm" binding:"omitempty,gt=0"`
+	BodyFatPct *float64          `json:"body_fat_pct" binding:"omitempty,gt=0"`
+	Skinfolds  *SkinfoldsRequest `json:"skinfolds"`
+	Notes      string            `json:"notes" binding:"max=1000"`
+}
+
+// SkinfoldsRequest are the skinfold thicknesses in millimeters
+type SkinfoldsRequest struct {
+	TricepsMm     *float64 `json:"triceps_mm"`
+	BicepsMm      *float64 `json:"biceps_mm"`
+	SubscapularMm *float64 `json:"subscapular_mm"`
+	SuprailiacMm  *float64 `json:"suprailiac_mm"`
+	AbdominalMm   *float64 `json:"abdominal_mm"`
+	ThighMm       *float64 `json:"thigh_mm"`
+	ChestMm       *float64 `json:"chest_mm"`
+	MidaxillaryMm *float64 `json:"midaxillary_mm"`
+}
+
+// ConvertToMeasurement converts the request to the entity
+func ConvertToMeasurement(req *MeasurementRequest) *entity.Measurement {
+	measurement := &entity.Measurement{
+		WeightKg:   req.WeightKg,
+		HeightCm:   req.HeightCm,
+		WaistCm:    req.WaistCm,
+		HipCm:      req.HipCm,
+		BodyFatPct: req.BodyFatPct,
+		Notes:      req.Notes,
+	}
+
+	if req.MeasuredAt != nil {
+		measurement.MeasuredAt = *req.MeasuredAt
+	}
+
+	if req.Skinfolds != nil {
+		measurement.Skinfolds = &entity.Skinfolds{
+			TricepsMm:     req.Skinfolds.TricepsMm,
+			BicepsMm:      req.Skinfolds.BicepsMm,
+			SubscapularMm: req.Skinfolds.SubscapularMm,
+			SuprailiacMm:  req.Skinfolds.SuprailiacMm,
+			AbdominalMm:   req.Skinfolds.AbdominalMm,
+			ThighMm:       req.Skinfolds.ThighMm,
+			ChestMm:       req.Skinfolds.ChestMm,
+			MidaxillaryMm: req.Skinfolds.MidaxillaryMm,
+		}
+	}
+
+	return measurement
+}
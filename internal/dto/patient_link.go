@@ -0,0 +1,6 @@
+package dto
+
+// InvitePatientRequest defines the expected request body to invite a patient.
+type InvitePatientRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
 
 // RegisterUserRequest defines the expected request body for user registration.
 // Email and Password validation is handled by the use case.
@@ -10,6 +14,9 @@ type RegisterUserRequest struct {
 	Age            int    `json:"age" binding:"required,min=1"`
 	Gender         string `json:"gender" binding:"required,oneof=male female other"`
 	IsNutritionist bool   `json:"is_nutritionist"`
+	// ProfessionalRegistration is the CRN number checked by an admin before
+	// approving a nutritionist account. Required when IsNutritionist is set.
+	ProfessionalRegistration string `json:"professional_registration" binding:"omitempty,min=3,max=30"`
 }
 
 // RegisterUserResponse defines the response for a successful user registration.
@@ -23,8 +30,90 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
-// LoginResponse defines the response for a successful user login.
+// LoginResponse defines the response for a successful user login or token refresh.
 type LoginResponse struct {
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expires_at"`
+	Token            string    `json:"token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// RefreshTokenRequest defines the expected request body for refreshing an access token.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// MessageResponse defines a generic response carrying only a message.
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+// ForgotPasswordRequest defines the expected request body to ask for a password reset email.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest defines the expected request body to set a new password.
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// EmailVerificationRequest defines the expected request body to resend the verification email.
+type EmailVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// VerifyEmailRequest defines the expected request body to confirm an email address.
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UserResponse is the public representation of a user.
+type UserResponse struct {
+	ID                       string                    `json:"id"`
+	Email                    string                    `json:"email"`
+	Type                     string                    `json:"type"`
+	Age                      int                       `json:"age"`
+	Gender                   string                    `json:"gender"`
+	EmailVerified            bool                      `json:"email_verified"`
+	NutritionistStatus       entity.NutritionistStatus `json:"nutritionist_status,omitempty"`
+	ProfessionalRegistration string                    `json:"professional_registration,omitempty"`
+}
+
+func NewUserResponse(user *entity.User) *UserResponse {
+	return &UserResponse{
+		ID:                       user.ID.Hex(),
+		Email:                    user.Email,
+		Type:                     user.Type,
+		Age:                      user.Age,
+		Gender:                   user.Gender,
+		EmailVerified:            user.EmailVerified,
+		NutritionistStatus:       user.NutritionistStatus,
+		ProfessionalRegistration: user.ProfessionalRegistration,
+	}
+}
+
+func NewUserResponses(users []*entity.User) []*UserResponse {
+	responses := make([]*UserResponse, 0, len(users))
+	for _, user := range users {
+		responses = append(responses, NewUserResponse(user))
+	}
+	return responses
+}
+
+// RoleRequest defines the expected request body to create or edit a role.
+type RoleRequest struct {
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// AssignRoleRequest defines the expected request body to change a user's role.
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// ReviewNutritionistRequest defines the optional note attached to a nutritionist review.
+type ReviewNutritionistRequest struct {
+	Note string `json:"note" binding:"max=500"`
 }
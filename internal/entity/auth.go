@@ -3,11 +3,15 @@ package entity
 import "time"
 
 type LoginUseCaseInput struct {
-	Email    string
-	Password string
+	Email     string
+	Password  string
+	UserAgent string
+	IPAddress string
 }
 
 type LoginUseCaseOutput struct {
-	Token       string
-	ExpiresAt   time.Time
-}
\ No newline at end of file
+	Token            string
+	ExpiresAt        time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// CalendarFeed is the secret link of the iCalendar feed of a patient. There
+// is one feed per user, keyed by their id; only the hash of the token is stored.
+type CalendarFeed struct {
+	UserID    string    `bson:"_id" json:"user_id"`
+	TokenHash string    `bson:"token_hash" json:"-"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CalendarFeedOutput carries the link of the feed, which holds the token and
+// is only shown when the feed is enabled
+type CalendarFeedOutput struct {
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
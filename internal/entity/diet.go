@@ -7,6 +7,17 @@ import (
 type DietStatus string
 
 const (
+	// DietDraft is still being written and is not visible as the patient's plan
+	DietDraft DietStatus = "DRAFT"
+	// DietScheduled starts in the future
+	DietScheduled DietStatus = "SCHEDULED"
+	DietActive    DietStatus = "ACTIVE"
+	// DietExpired reached its end
+	DietExpired  DietStatus = "EXPIRED"
+	DietArchived DietStatus = "ARCHIVED"
+
+	// Enabled e Disabled são os status anteriores ao ciclo de vida, convertidos
+	// pela migração diet-lifecycle
 	Enabled  DietStatus = "ENABLED"
 	Disabled DietStatus = "DISABLED"
 )
@@ -22,18 +33,118 @@ type Diet struct {
 	CreatedBy      string    `bson:"created_by" json:"created_by"`
 	CreatedAt      time.Time `bson:"created_at" json:"created_at"`
 	UpdatedAt      time.Time `bson:"updated_at" json:"updated_at"`
+	// Version é incrementada a cada alteração; dietas antigas sem o campo ficam com 0
+	Version   int64      `bson:"version" json:"version"`
+	DeletedAt *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy string     `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+	// StartsAt é o primeiro dia da dieta e EndsAt o instante em que ela termina
+	// (StartsAt + DurationInDays). Rascunhos podem não ter datas
+	StartsAt *time.Time `bson:"starts_at,omitempty" json:"starts_at,omitempty"`
+	EndsAt   *time.Time `bson:"ends_at,omitempty" json:"ends_at,omitempty"`
+	// Days são modelos de dia alternados conforme DayRule; sem modelos todos
+	// os dias seguem Meals
+	Days    []DayTemplate `bson:"days,omitempty" json:"days,omitempty"`
+	DayRule *DayRule      `bson:"day_rule,omitempty" json:"day_rule,omitempty"`
+	// Texture é a consistência dos alimentos prescrita (níveis IDDSI); vazia
+	// é a dieta sem restrição
+	Texture DietTexture `bson:"texture,omitempty" json:"texture,omitempty"`
+	// Nutrition é calculada pelos casos de uso e nunca persistida
+	Nutrition *DietNutrition `bson:"-" json:"nutrition,omitempty"`
+}
+
+// IsDeleted reports whether the diet was soft-deleted
+func (d *Diet) IsDeleted() bool {
+	return d.DeletedAt != nil
 }
 
+// DietTexture is the food texture of the diet, following the IDDSI levels
+type DietTexture string
+
+const (
+	TextureRegular          DietTexture = "REGULAR"
+	TextureEasyToChew       DietTexture = "EASY_TO_CHEW"
+	TextureSoftAndBiteSized DietTexture = "SOFT_AND_BITE_SIZED"
+	TextureMincedAndMoist   DietTexture = "MINCED_AND_MOIST"
+	TexturePureed           DietTexture = "PUREED"
+	TextureLiquidised       DietTexture = "LIQUIDISED"
+)
+
+// MealSlot is the canonical moment of the day of a meal
+type MealSlot string
+
+const (
+	SlotBreakfast      MealSlot = "BREAKFAST"
+	SlotMorningSnack   MealSlot = "MORNING_SNACK"
+	SlotLunch          MealSlot = "LUNCH"
+	SlotAfternoonSnack MealSlot = "AFTERNOON_SNACK"
+	SlotDinner         MealSlot = "DINNER"
+	SlotSupper         MealSlot = "SUPPER"
+	SlotPreWorkout     MealSlot = "PRE_WORKOUT"
+	SlotPostWorkout    MealSlot = "POST_WORKOUT"
+	SlotOther          MealSlot = "OTHER"
+)
+
 type Meal struct {
-	Name        string       `bson:"name" json:"name"`
-	Description string       `bson:"description" json:"description"`
-	TimeOfDay   string       `bson:"time_of_day" json:"time_of_day"`
+	Name        string `bson:"name" json:"name"`
+	Description string `bson:"description" json:"description"`
+	// TimeOfDay é o horário da refeição (HH:MM). Dietas antigas podem ter
+	// texto livre até a migração meal-times
+	TimeOfDay string `bson:"time_of_day" json:"time_of_day"`
+	// WindowStart e WindowEnd delimitam opcionalmente a janela da refeição (HH:MM)
+	WindowStart string       `bson:"window_start,omitempty" json:"window_start,omitempty"`
+	WindowEnd   string       `bson:"window_end,omitempty" json:"window_end,omitempty"`
+	Slot        MealSlot     `bson:"slot,omitempty" json:"slot,omitempty"`
 	Ingredients []Ingredient `bson:"ingredients" json:"ingredients"`
 }
 
+type Weekday string
+
+const (
+	Monday    Weekday = "MONDAY"
+	Tuesday   Weekday = "TUESDAY"
+	Wednesday Weekday = "WEDNESDAY"
+	Thursday  Weekday = "THURSDAY"
+	Friday    Weekday = "FRIDAY"
+	Saturday  Weekday = "SATURDAY"
+	Sunday    Weekday = "SUNDAY"
+)
+
+type DayRuleType string
+
+const (
+	// DayRuleWeekday assigns the templates by day of the week; days without a
+	// template follow the meals of the diet
+	DayRuleWeekday DayRuleType = "WEEKDAY"
+	// DayRuleRotation repeats the templates in order from the first day of the diet
+	DayRuleRotation DayRuleType = "ROTATION"
+)
+
+// DayTemplate is a named day of the plan, such as "Dia de treino" or "Dia A"
+type DayTemplate struct {
+	Name string `bson:"name" json:"name"`
+	// Weekdays are the days of the week of the template in the WEEKDAY rule
+	Weekdays []Weekday `bson:"weekdays,omitempty" json:"weekdays,omitempty"`
+	Meals    []Meal    `bson:"meals" json:"meals"`
+}
+
+// DayRule tells which template each day of the diet follows
+type DayRule struct {
+	Type DayRuleType `bson:"type" json:"type"`
+	// Rotation is the cycle of template names of the ROTATION rule
+	Rotation []string `bson:"rotation,omitempty" json:"rotation,omitempty"`
+}
+
 type Ingredient struct {
-	Description string       `bson:"description" json:"description"`
-	Quantity    float64      `bson:"quantity" json:"quantity"`
-	Unit        string       `bson:"unit" json:"unit"`
+	// FoodID referencia opcionalmente um alimento do catálogo (coleção foods)
+	FoodID      string  `bson:"food_id,omitempty" json:"food_id,omitempty"`
+	Description string  `bson:"description" json:"description"`
+	Quantity    float64 `bson:"quantity" json:"quantity"`
+	Unit        string  `bson:"unit" json:"unit"`
+	// NutrientsPer100g informa a composição do ingrediente quando ele não vem
+	// do catálogo, ou sobrescreve os valores do catálogo
+	NutrientsPer100g *Nutrients `bson:"nutrients_per_100g,omitempty" json:"nutrients_per_100g,omitempty"`
+	// UnitWeightG é o peso em gramas de uma unidade da medida usada (ex.: uma
+	// fatia ou uma colher de sopa deste alimento)
+	UnitWeightG float64      `bson:"unit_weight_g,omitempty" json:"unit_weight_g,omitempty"`
 	Substitutes []Ingredient `bson:"substitutes" json:"substitutes"`
 }
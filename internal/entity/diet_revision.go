@@ -0,0 +1,124 @@
+package entity
+
+import "time"
+
+type DietRevisionAction string
+
+const (
+	// RevisionBaseline records the state of a diet created before revisions existed
+	RevisionBaseline DietRevisionAction = "BASELINE"
+	RevisionCreated  DietRevisionAction = "CREATED"
+	RevisionUpdated  DietRevisionAction = "UPDATED"
+	RevisionRollback DietRevisionAction = "ROLLBACK"
+)
+
+// Authors of the revisions written without a user: the background lifecycle
+// job and the data migrations
+const (
+	RevisionAuthorSystem    = "system"
+	RevisionAuthorMigration = "migration"
+)
+
+// DietRevision is an immutable snapshot of a diet taken every time it changes
+type DietRevision struct {
+	ID        string             `bson:"_id" json:"id"`
+	DietID    string             `bson:"diet_id" json:"diet_id"`
+	Number    int                `bson:"number" json:"number"`
+	Action    DietRevisionAction `bson:"action" json:"action"`
+	Snapshot  DietSnapshot       `bson:"snapshot" json:"snapshot"`
+	ChangedBy string             `bson:"changed_by" json:"changed_by"`
+	ChangedAt time.Time          `bson:"changed_at" json:"changed_at"`
+	// RolledBackFrom is the revision restored by a rollback
+	RolledBackFrom *int `bson:"rolled_back_from,omitempty" json:"rolled_back_from,omitempty"`
+}
+
+// DietSnapshot holds the editable content of a diet
+type DietSnapshot struct {
+	UserEmail      string        `bson:"user_email" json:"user_email"`
+	DietName       string        `bson:"name" json:"name"`
+	DurationInDays uint32        `bson:"duration_in_days" json:"duration_in_days"`
+	Status         string        `bson:"status" json:"status"`
+	Meals          []Meal        `bson:"meals" json:"meals"`
+	Observations   string        `bson:"observations" json:"observations"`
+	Days           []DayTemplate `bson:"days,omitempty" json:"days,omitempty"`
+	DayRule        *DayRule      `bson:"day_rule,omitempty" json:"day_rule,omitempty"`
+	StartsAt       *time.Time    `bson:"starts_at,omitempty" json:"starts_at,omitempty"`
+	Texture        DietTexture   `bson:"texture,omitempty" json:"texture,omitempty"`
+}
+
+// Snapshot copies the editable content of the diet
+func (d *Diet) Snapshot() DietSnapshot {
+	return DietSnapshot{
+		UserEmail:      d.UserEmail,
+		DietName:       d.DietName,
+		DurationInDays: d.DurationInDays,
+		Status:         d.Status,
+		Meals:          d.Meals,
+		Observations:   d.Observations,
+		Days:           d.Days,
+		DayRule:        d.DayRule,
+		StartsAt:       d.StartsAt,
+		Texture:        d.Texture,
+	}
+}
+
+// ApplySnapshot replaces the editable content of the diet, keeping its owner.
+// The status follows the lifecycle of the diet and is not restored.
+func (d *Diet) ApplySnapshot(snapshot DietSnapshot) {
+	d.DietName = snapshot.DietName
+	d.DurationInDays = snapshot.DurationInDays
+	d.StartsAt = snapshot.StartsAt
+	d.Meals = snapshot.Meals
+	d.Days = snapshot.Days
+	d.DayRule = snapshot.DayRule
+	d.Observations = snapshot.Observations
+	d.Texture = snapshot.Texture
+}
+
+type DiffChange string
+
+const (
+	DiffAdded    DiffChange = "ADDED"
+	DiffRemoved  DiffChange = "REMOVED"
+	DiffModified DiffChange = "MODIFIED"
+)
+
+// DietDiff is the structural difference between two revisions of a diet
+type DietDiff struct {
+	DietID string      `json:"diet_id"`
+	From   int         `json:"from"`
+	To     int         `json:"to"`
+	Fields []FieldDiff `json:"fields"`
+	Meals  []MealDiff  `json:"meals"`
+}
+
+// FieldDiff is a change in a top-level field of the diet
+type FieldDiff struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// MealDiff describes a meal that was added, removed or changed. Meals are
+// matched by name.
+type MealDiff struct {
+	// Day is the day template of the meal, empty for the meals of the diet
+	Day         string           `json:"day,omitempty"`
+	Name        string           `json:"name"`
+	Change      DiffChange       `json:"change"`
+	Fields      []FieldDiff      `json:"fields,omitempty"`
+	Ingredients []IngredientDiff `json:"ingredients,omitempty"`
+}
+
+// IngredientDiff describes an ingredient that was added, removed or had its
+// quantity changed. Ingredients are matched by description within a meal.
+type IngredientDiff struct {
+	Description  string     `json:"description"`
+	Change       DiffChange `json:"change"`
+	FromQuantity *float64   `json:"from_quantity,omitempty"`
+	ToQuantity   *float64   `json:"to_quantity,omitempty"`
+	FromUnit     string     `json:"from_unit,omitempty"`
+	ToUnit       string     `json:"to_unit,omitempty"`
+	// SubstitutesChanged is set when the substitutes list differs
+	SubstitutesChanged bool `json:"substitutes_changed,omitempty"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// DietSchedule is the plan of a diet expanded into dated meals
+type DietSchedule struct {
+	DietID string         `json:"diet_id"`
+	From   string         `json:"from"`
+	To     string         `json:"to"`
+	Days   []ScheduledDay `json:"days"`
+}
+
+// ScheduledDay holds the meals planned for a date
+type ScheduledDay struct {
+	Date    string  `json:"date"`
+	Weekday Weekday `json:"weekday"`
+	// DayNumber counts the days of the diet starting at 1
+	DayNumber int `json:"day_number"`
+	// Template is empty when the day follows the meals of the diet
+	Template string          `json:"template,omitempty"`
+	Meals    []ScheduledMeal `json:"meals"`
+}
+
+// ScheduledMeal is a meal of the plan on a given date
+type ScheduledMeal struct {
+	Meal
+	// At is the date and time of the meal, absent when the time of the meal
+	// is still free text
+	At *time.Time `json:"at,omitempty"`
+}
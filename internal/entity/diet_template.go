@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// DietTemplate is a reusable diet owned by a nutritionist. It has no patient;
+// diets are created from it for each patient.
+type DietTemplate struct {
+	ID             string        `bson:"_id" json:"id"`
+	OwnerID        string        `bson:"owner_id" json:"owner_id"`
+	Name           string        `bson:"name" json:"name"`
+	Description    string        `bson:"description,omitempty" json:"description,omitempty"`
+	DurationInDays uint32        `bson:"duration_in_days" json:"duration_in_days"`
+	Meals          []Meal        `bson:"meals" json:"meals"`
+	Days           []DayTemplate `bson:"days,omitempty" json:"days,omitempty"`
+	DayRule        *DayRule      `bson:"day_rule,omitempty" json:"day_rule,omitempty"`
+	Observations   string        `bson:"observations" json:"observations"`
+	CreatedAt      time.Time     `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time     `bson:"updated_at" json:"updated_at"`
+	// Nutrition é calculada pelos casos de uso e nunca persistida
+	Nutrition *DietNutrition `bson:"-" json:"nutrition,omitempty"`
+}
@@ -0,0 +1,91 @@
+package entity
+
+import "time"
+
+type ActivityLevel string
+
+const (
+	ActivitySedentary  ActivityLevel = "SEDENTARY"
+	ActivityLight      ActivityLevel = "LIGHT"
+	ActivityModerate   ActivityLevel = "MODERATE"
+	ActivityActive     ActivityLevel = "ACTIVE"
+	ActivityVeryActive ActivityLevel = "VERY_ACTIVE"
+)
+
+type EnergyEquation string
+
+const (
+	EquationMifflinStJeor  EnergyEquation = "MIFFLIN_ST_JEOR"
+	EquationHarrisBenedict EnergyEquation = "HARRIS_BENEDICT"
+	EquationKatchMcArdle   EnergyEquation = "KATCH_MCARDLE"
+)
+
+type NutritionGoal string
+
+const (
+	GoalLoseWeight NutritionGoal = "LOSE_WEIGHT"
+	GoalMaintain   NutritionGoal = "MAINTAIN"
+	GoalGainWeight NutritionGoal = "GAIN_WEIGHT"
+)
+
+// Anthropometrics are the patient data used to estimate the energy
+// expenditure. They are informed by the nutritionist.
+type Anthropometrics struct {
+	HeightCm   float64  `bson:"height_cm" json:"height_cm"`
+	WeightKg   float64  `bson:"weight_kg" json:"weight_kg"`
+	BodyFatPct *float64 `bson:"body_fat_pct,omitempty" json:"body_fat_pct,omitempty"`
+	// Sex is the biological sex used by the equations (male or female). When
+	// empty the gender of the user is used.
+	Sex           string         `bson:"sex,omitempty" json:"sex,omitempty"`
+	ActivityLevel ActivityLevel  `bson:"activity_level" json:"activity_level"`
+	Equation      EnergyEquation `bson:"equation" json:"equation"`
+	Goal          NutritionGoal  `bson:"goal" json:"goal"`
+	// TargetWeightKg é o peso alvo, usado para acompanhar o progresso
+	TargetWeightKg *float64 `bson:"target_weight_kg,omitempty" json:"target_weight_kg,omitempty"`
+	// Optional overrides of the defaults of the goal
+	CalorieAdjustmentKcal *float64 `bson:"calorie_adjustment_kcal,omitempty" json:"calorie_adjustment_kcal,omitempty"`
+	ProteinGPerKg         *float64 `bson:"protein_g_per_kg,omitempty" json:"protein_g_per_kg,omitempty"`
+	FatPct                *float64 `bson:"fat_pct,omitempty" json:"fat_pct,omitempty"`
+
+	UpdatedBy string    `bson:"updated_by" json:"updated_by"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// EnergyAssessment is the estimated energy expenditure of a patient and the
+// calorie and macronutrient targets derived from the goal
+type EnergyAssessment struct {
+	PatientEmail    string          `json:"patient_email"`
+	Anthropometrics Anthropometrics `json:"anthropometrics"`
+	Age             int             `json:"age"`
+	Sex             string          `json:"sex"`
+	Equation        EnergyEquation  `json:"equation"`
+	BMRKcal         float64         `json:"bmr_kcal"`
+	ActivityFactor  float64         `json:"activity_factor"`
+	TDEEKcal        float64         `json:"tdee_kcal"`
+	Targets         EnergyTargets   `json:"targets"`
+	// BMRByEquation compares the equations that can be applied with the
+	// available data
+	BMRByEquation map[EnergyEquation]float64 `json:"bmr_by_equation"`
+}
+
+// EnergyTargets are the daily targets of the patient
+type EnergyTargets struct {
+	EnergyKcal    float64    `json:"energy_kcal"`
+	ProteinG      float64    `json:"protein_g"`
+	CarbohydrateG float64    `json:"carbohydrate_g"`
+	FatG          float64    `json:"fat_g"`
+	MacroSplit    MacroSplit `json:"macro_split"`
+}
+
+// TargetDeviation compares the daily values prescribed by a diet with the
+// targets of the patient. Differences are prescribed minus target.
+type TargetDeviation struct {
+	Targets           EnergyTargets `json:"targets"`
+	EnergyDiffKcal    float64       `json:"energy_diff_kcal"`
+	EnergyDiffPct     float64       `json:"energy_diff_pct"`
+	ProteinDiffG      float64       `json:"protein_diff_g"`
+	CarbohydrateDiffG float64       `json:"carbohydrate_diff_g"`
+	FatDiffG          float64       `json:"fat_diff_g"`
+	// WithinTolerance is true when the energy is within the tolerance of the target
+	WithinTolerance bool `json:"within_tolerance"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// ExportTemplate is the branding a nutritionist prints on the exported diets.
+// There is one template per nutritionist, keyed by their user id.
+type ExportTemplate struct {
+	OwnerID string `bson:"_id" json:"owner_id"`
+	// ProfessionalName is the name printed as the author of the diet; without
+	// it the email of the nutritionist is used
+	ProfessionalName string   `bson:"professional_name" json:"professional_name"`
+	ClinicName       string   `bson:"clinic_name" json:"clinic_name"`
+	HeaderLines      []string `bson:"header_lines" json:"header_lines"`
+	// Logo é a imagem PNG ou JPEG impressa no cabeçalho
+	Logo []byte `bson:"logo,omitempty" json:"-"`
+	// AccentColor (#RRGGBB) colore o nome da clínica e os títulos
+	AccentColor   string    `bson:"accent_color" json:"accent_color"`
+	Footer        string    `bson:"footer" json:"footer"`
+	ShowNutrition bool      `bson:"show_nutrition" json:"show_nutrition"`
+	UpdatedAt     time.Time `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,59 @@
+package entity
+
+import "time"
+
+type FoodSource string
+
+const (
+	// FoodSourceTACO is the Brazilian Food Composition Table (TACO/UNICAMP)
+	FoodSourceTACO FoodSource = "TACO"
+	// FoodSourceUSDA is USDA FoodData Central
+	FoodSourceUSDA FoodSource = "USDA"
+)
+
+// Food is an entry of the food composition catalog. Nutrients are always per
+// 100 g of the edible portion.
+type Food struct {
+	ID             string     `bson:"_id" json:"id"`
+	Source         FoodSource `bson:"source" json:"source"`
+	SourceID       string     `bson:"source_id" json:"source_id"`
+	Name           string     `bson:"name" json:"name"`
+	NormalizedName string     `bson:"normalized_name" json:"-"`
+	Category       string     `bson:"category" json:"category"`
+	Nutrients      Nutrients  `bson:"nutrients" json:"nutrients_per_100g"`
+	// DensityGPerMl converts volumes (ml, xícara, colher...) to grams
+	DensityGPerMl float64 `bson:"density_g_per_ml,omitempty" json:"density_g_per_ml,omitempty"`
+	// GramWeights is the weight in grams of one unit of a measure for this
+	// food, keyed by unit code, e.g. {"un": 50, "fatia(s)": 25}
+	GramWeights map[string]float64 `bson:"gram_weights,omitempty" json:"gram_weights,omitempty"`
+	ImportedAt  time.Time          `bson:"imported_at" json:"imported_at"`
+}
+
+// Nutrients holds energy, macronutrients and the key micronutrients tracked
+// by the catalog. Values missing from the source, or reported as traces, are 0.
+type Nutrients struct {
+	EnergyKcal    float64 `bson:"energy_kcal" json:"energy_kcal"`
+	ProteinG      float64 `bson:"protein_g" json:"protein_g"`
+	CarbohydrateG float64 `bson:"carbohydrate_g" json:"carbohydrate_g"`
+	FatG          float64 `bson:"fat_g" json:"fat_g"`
+	FiberG        float64 `bson:"fiber_g" json:"fiber_g"`
+	CholesterolMg float64 `bson:"cholesterol_mg" json:"cholesterol_mg"`
+	SodiumMg      float64 `bson:"sodium_mg" json:"sodium_mg"`
+	CalciumMg     float64 `bson:"calcium_mg" json:"calcium_mg"`
+	IronMg        float64 `bson:"iron_mg" json:"iron_mg"`
+	PotassiumMg   float64 `bson:"potassium_mg" json:"potassium_mg"`
+	MagnesiumMg   float64 `bson:"magnesium_mg" json:"magnesium_mg"`
+	VitaminCMg    float64 `bson:"vitamin_c_mg" json:"vitamin_c_mg"`
+}
+
+// FoodID builds the catalog identifier of a food from its source
+func FoodID(source FoodSource, sourceID string) string {
+	switch source {
+	case FoodSourceTACO:
+		return "taco-" + sourceID
+	case FoodSourceUSDA:
+		return "usda-" + sourceID
+	default:
+		return sourceID
+	}
+}
@@ -0,0 +1,81 @@
+package entity
+
+import "time"
+
+type MealLogStatus string
+
+const (
+	MealFollowed    MealLogStatus = "FOLLOWED"
+	MealPartial     MealLogStatus = "PARTIAL"
+	MealSkipped     MealLogStatus = "SKIPPED"
+	MealSubstituted MealLogStatus = "SUBSTITUTED"
+)
+
+// MealLogDateLayout is the layout of MealLog.Date, the day of the patient
+// when the meal was eaten
+const MealLogDateLayout = "2006-01-02"
+
+// MealLog is what the patient reports about one meal of a diet on one day.
+// There is at most one log per diet, meal and day.
+type MealLog struct {
+	ID        string        `bson:"_id" json:"id"`
+	DietID    string        `bson:"diet_id" json:"diet_id"`
+	PatientID string        `bson:"patient_id" json:"patient_id"`
+	MealName  string        `bson:"meal_name" json:"meal_name"`
+	Date      string        `bson:"date" json:"date"`
+	Status    MealLogStatus `bson:"status" json:"status"`
+	// Substitute is the prescribed substitute chosen by the patient
+	Substitute *ChosenSubstitute `bson:"substitute,omitempty" json:"substitute,omitempty"`
+	Notes      string            `bson:"notes,omitempty" json:"notes,omitempty"`
+	LoggedAt   time.Time         `bson:"logged_at" json:"logged_at"`
+	UpdatedAt  time.Time         `bson:"updated_at" json:"updated_at"`
+
+	ReviewedBy string     `bson:"reviewed_by,omitempty" json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+	ReviewNote string     `bson:"review_note,omitempty" json:"review_note,omitempty"`
+}
+
+// ChosenSubstitute identifies a substitute of an ingredient of the meal
+type ChosenSubstitute struct {
+	Ingredient string `bson:"ingredient" json:"ingredient"`
+	Substitute string `bson:"substitute" json:"substitute"`
+}
+
+// DietAdherence summarizes how much the patient followed a diet in a period.
+// Followed and substituted meals count as adherent, partial meals count as
+// half and skipped or unlogged meals do not count.
+type DietAdherence struct {
+	DietID        string                `json:"diet_id"`
+	From          string                `json:"from"`
+	To            string                `json:"to"`
+	Days          int                   `json:"days"`
+	ExpectedMeals int                   `json:"expected_meals"`
+	LoggedMeals   int                   `json:"logged_meals"`
+	ByStatus      map[MealLogStatus]int `json:"by_status"`
+	// AdherencePct considers every expected meal; LoggedAdherencePct only the
+	// logged ones
+	AdherencePct       float64         `json:"adherence_pct"`
+	LoggedAdherencePct float64         `json:"logged_adherence_pct"`
+	Meals              []MealAdherence `json:"meals"`
+	Weeks              []WeekAdherence `json:"weeks"`
+	// Streaks count consecutive days with every meal followed or substituted
+	CurrentStreakDays int `json:"current_streak_days"`
+	LongestStreakDays int `json:"longest_streak_days"`
+}
+
+// MealAdherence is the adherence of one meal slot of the diet
+type MealAdherence struct {
+	MealName     string                `json:"meal_name"`
+	Expected     int                   `json:"expected"`
+	Logged       int                   `json:"logged"`
+	ByStatus     map[MealLogStatus]int `json:"by_status"`
+	AdherencePct float64               `json:"adherence_pct"`
+}
+
+// WeekAdherence is the adherence of a week, starting on monday
+type WeekAdherence struct {
+	WeekStart    string  `json:"week_start"`
+	Expected     int     `json:"expected"`
+	Logged       int     `json:"logged"`
+	AdherencePct float64 `json:"adherence_pct"`
+}
@@ -0,0 +1,88 @@
+package entity
+
+import "time"
+
+type MeasurementSource string
+
+const (
+	// MeasurementByPatient is a weigh-in informed by the patient
+	MeasurementByPatient MeasurementSource = "PATIENT"
+	// MeasurementByNutritionist is a full assessment informed by the nutritionist
+	MeasurementByNutritionist MeasurementSource = "NUTRITIONIST"
+)
+
+// Measurement is a body measurement of a patient at a point in time
+type Measurement struct {
+	ID         string            `bson:"_id" json:"id"`
+	PatientID  string            `bson:"patient_id" json:"patient_id"`
+	MeasuredAt time.Time         `bson:"measured_at" json:"measured_at"`
+	Source     MeasurementSource `bson:"source" json:"source"`
+	WeightKg   *float64          `bson:"weight_kg,omitempty" json:"weight_kg,omitempty"`
+	HeightCm   *float64          `bson:"height_cm,omitempty" json:"height_cm,omitempty"`
+	WaistCm    *float64          `bson:"waist_cm,omitempty" json:"waist_cm,omitempty"`
+	HipCm      *float64          `bson:"hip_cm,omitempty" json:"hip_cm,omitempty"`
+	BodyFatPct *float64          `bson:"body_fat_pct,omitempty" json:"body_fat_pct,omitempty"`
+	Skinfolds  *Skinfolds        `bson:"skinfolds,omitempty" json:"skinfolds,omitempty"`
+	Notes      string            `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedBy  string            `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time         `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time         `bson:"updated_at" json:"updated_at"`
+
+	// Campos calculados, nunca persistidos
+	BMI           *float64 `bson:"-" json:"bmi,omitempty"`
+	WaistHipRatio *float64 `bson:"-" json:"waist_hip_ratio,omitempty"`
+	SkinfoldSumMm *float64 `bson:"-" json:"skinfold_sum_mm,omitempty"`
+}
+
+// Skinfolds are the skinfold thicknesses in millimeters
+type Skinfolds struct {
+	TricepsMm     *float64 `bson:"triceps_mm,omitempty" json:"triceps_mm,omitempty"`
+	BicepsMm      *float64 `bson:"biceps_mm,omitempty" json:"biceps_mm,omitempty"`
+	SubscapularMm *float64 `bson:"subscapular_mm,omitempty" json:"subscapular_mm,omitempty"`
+	SuprailiacMm  *float64 `bson:"suprailiac_mm,omitempty" json:"suprailiac_mm,omitempty"`
+	AbdominalMm   *float64 `bson:"abdominal_mm,omitempty" json:"abdominal_mm,omitempty"`
+	ThighMm       *float64 `bson:"thigh_mm,omitempty" json:"thigh_mm,omitempty"`
+	ChestMm       *float64 `bson:"chest_mm,omitempty" json:"chest_mm,omitempty"`
+	MidaxillaryMm *float64 `bson:"midaxillary_mm,omitempty" json:"midaxillary_mm,omitempty"`
+}
+
+// Values lists the informed skinfolds
+func (s Skinfolds) Values() []float64 {
+	var values []float64
+	for _, value := range []*float64{s.TricepsMm, s.BicepsMm, s.SubscapularMm, s.SuprailiacMm, s.AbdominalMm, s.ThighMm, s.ChestMm, s.MidaxillaryMm} {
+		if value != nil {
+			values = append(values, *value)
+		}
+	}
+	return values
+}
+
+// MeasurementSeries is the list of measurements of a patient in a period with
+// the progress over it
+type MeasurementSeries struct {
+	PatientID    string              `json:"patient_id"`
+	From         *time.Time          `json:"from,omitempty"`
+	To           *time.Time          `json:"to,omitempty"`
+	Measurements []*Measurement      `json:"measurements"`
+	Progress     MeasurementProgress `json:"progress"`
+}
+
+// MeasurementProgress compares the first and the last weight of the period.
+// Fields are omitted when there is not enough data to compute them.
+type MeasurementProgress struct {
+	StartWeightKg   *float64 `json:"start_weight_kg,omitempty"`
+	CurrentWeightKg *float64 `json:"current_weight_kg,omitempty"`
+	WeightChangeKg  *float64 `json:"weight_change_kg,omitempty"`
+	// WeeklyRateKg é a variação média de peso por semana no período
+	WeeklyRateKg *float64 `json:"weekly_rate_kg,omitempty"`
+	CurrentBMI   *float64 `json:"current_bmi,omitempty"`
+	// BMIClassification follows the WHO adult classification
+	BMIClassification string   `json:"bmi_classification,omitempty"`
+	WaistChangeCm     *float64 `json:"waist_change_cm,omitempty"`
+	BodyFatChangePct  *float64 `json:"body_fat_change_pct,omitempty"`
+	TargetWeightKg    *float64 `json:"target_weight_kg,omitempty"`
+	RemainingKg       *float64 `json:"remaining_kg,omitempty"`
+	// GoalProgressPct é o quanto do caminho entre o peso inicial e o peso
+	// alvo já foi percorrido
+	GoalProgressPct *float64 `json:"goal_progress_pct,omitempty"`
+}
@@ -0,0 +1,130 @@
+package entity
+
+import "math"
+
+// Add sums the nutrients of other into n
+func (n *Nutrients) Add(other Nutrients) {
+	n.EnergyKcal += other.EnergyKcal
+	n.ProteinG += other.ProteinG
+	n.CarbohydrateG += other.CarbohydrateG
+	n.FatG += other.FatG
+	n.FiberG += other.FiberG
+	n.CholesterolMg += other.CholesterolMg
+	n.SodiumMg += other.SodiumMg
+	n.CalciumMg += other.CalciumMg
+	n.IronMg += other.IronMg
+	n.PotassiumMg += other.PotassiumMg
+	n.MagnesiumMg += other.MagnesiumMg
+	n.VitaminCMg += other.VitaminCMg
+}
+
+// Scale returns the nutrients multiplied by factor
+func (n Nutrients) Scale(factor float64) Nutrients {
+	return Nutrients{
+		EnergyKcal:    n.EnergyKcal * factor,
+		ProteinG:      n.ProteinG * factor,
+		CarbohydrateG: n.CarbohydrateG * factor,
+		FatG:          n.FatG * factor,
+		FiberG:        n.FiberG * factor,
+		CholesterolMg: n.CholesterolMg * factor,
+		SodiumMg:      n.SodiumMg * factor,
+		CalciumMg:     n.CalciumMg * factor,
+		IronMg:        n.IronMg * factor,
+		PotassiumMg:   n.PotassiumMg * factor,
+		MagnesiumMg:   n.MagnesiumMg * factor,
+		VitaminCMg:    n.VitaminCMg * factor,
+	}
+}
+
+// Rounded returns the nutrients rounded to two decimal places, for display
+func (n Nutrients) Rounded() Nutrients {
+	return Nutrients{
+		EnergyKcal:    round2(n.EnergyKcal),
+		ProteinG:      round2(n.ProteinG),
+		CarbohydrateG: round2(n.CarbohydrateG),
+		FatG:          round2(n.FatG),
+		FiberG:        round2(n.FiberG),
+		CholesterolMg: round2(n.CholesterolMg),
+		SodiumMg:      round2(n.SodiumMg),
+		CalciumMg:     round2(n.CalciumMg),
+		IronMg:        round2(n.IronMg),
+		PotassiumMg:   round2(n.PotassiumMg),
+		MagnesiumMg:   round2(n.MagnesiumMg),
+		VitaminCMg:    round2(n.VitaminCMg),
+	}
+}
+
+// MacroSplit is the share of energy coming from each macronutrient, using
+// 4 kcal/g for protein and carbohydrates and 9 kcal/g for fat.
+type MacroSplit struct {
+	ProteinPct      float64 `json:"protein_pct"`
+	CarbohydratePct float64 `json:"carbohydrate_pct"`
+	FatPct          float64 `json:"fat_pct"`
+}
+
+// MacroSplit computes the energy split of the nutrients
+func (n Nutrients) MacroSplit() MacroSplit {
+	protein := n.ProteinG * 4
+	carbohydrate := n.CarbohydrateG * 4
+	fat := n.FatG * 9
+
+	total := protein + carbohydrate + fat
+	if total == 0 {
+		return MacroSplit{}
+	}
+
+	return MacroSplit{
+		ProteinPct:      round2(protein / total * 100),
+		CarbohydratePct: round2(carbohydrate / total * 100),
+		FatPct:          round2(fat / total * 100),
+	}
+}
+
+// DietNutrition is the nutritional breakdown of a diet. Meals describe one
+// day of the plan, so PerDay is the sum of the meals and Total covers the
+// whole duration of the diet. When the diet alternates day templates, PerDay
+// is the average of the days of the diet.
+type DietNutrition struct {
+	DietID     string          `json:"diet_id"`
+	PerDay     Nutrients       `json:"per_day"`
+	Total      Nutrients       `json:"total"`
+	MacroSplit MacroSplit      `json:"macro_split"`
+	Meals      []MealNutrition `json:"meals"`
+	Days       []DayNutrition  `json:"days,omitempty"`
+	// Unresolved lists the ingredients left out of the totals because their
+	// nutrients or their weight in grams are unknown
+	Unresolved []string `json:"unresolved"`
+	// Target compares PerDay with the targets of the patient, when the
+	// patient has anthropometrics
+	Target *TargetDeviation `json:"target,omitempty"`
+}
+
+// DayNutrition is the nutritional breakdown of a day template
+type DayNutrition struct {
+	Name      string          `json:"name"`
+	Nutrients Nutrients       `json:"nutrients"`
+	Meals     []MealNutrition `json:"meals"`
+}
+
+// MealNutrition is the nutritional breakdown of one meal
+type MealNutrition struct {
+	Name        string                `json:"name"`
+	TimeOfDay   string                `json:"time_of_day"`
+	Slot        MealSlot              `json:"slot,omitempty"`
+	Nutrients   Nutrients             `json:"nutrients"`
+	Ingredients []IngredientNutrition `json:"ingredients"`
+}
+
+// IngredientNutrition is the nutritional value of an ingredient in the
+// prescribed quantity
+type IngredientNutrition struct {
+	Description string     `json:"description"`
+	FoodID      string     `json:"food_id,omitempty"`
+	Grams       *float64   `json:"grams,omitempty"`
+	Nutrients   *Nutrients `json:"nutrients,omitempty"`
+	Resolved    bool       `json:"resolved"`
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+type PatientLinkStatus string
+
+const (
+	PatientLinkPending  PatientLinkStatus = "PENDING"
+	PatientLinkActive   PatientLinkStatus = "ACTIVE"
+	PatientLinkDeclined PatientLinkStatus = "DECLINED"
+	PatientLinkEnded    PatientLinkStatus = "ENDED"
+)
+
+// PatientLink is the relationship between a nutritionist and a patient. It
+// starts as an invitation sent to the patient's email and becomes ACTIVE once
+// the patient accepts it.
+type PatientLink struct {
+	ID                string            `bson:"_id" json:"id"`
+	NutritionistID    string            `bson:"nutritionist_id" json:"nutritionist_id"`
+	NutritionistEmail string            `bson:"nutritionist_email" json:"nutritionist_email"`
+	PatientEmail      string            `bson:"patient_email" json:"patient_email"`
+	PatientID         string            `bson:"patient_id,omitempty" json:"patient_id,omitempty"`
+	Status            PatientLinkStatus `bson:"status" json:"status"`
+	InvitedAt         time.Time         `bson:"invited_at" json:"invited_at"`
+	RespondedAt       *time.Time        `bson:"responded_at,omitempty" json:"responded_at,omitempty"`
+	StartedAt         *time.Time        `bson:"started_at,omitempty" json:"started_at,omitempty"`
+	EndedAt           *time.Time        `bson:"ended_at,omitempty" json:"ended_at,omitempty"`
+	EndedBy           string            `bson:"ended_by,omitempty" json:"ended_by,omitempty"`
+}
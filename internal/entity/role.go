@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// Role maps a user type to the permissions granted to its users. Roles are
+// stored in the database so they can be edited without a redeploy.
+type Role struct {
+	Name        string   `bson:"_id" json:"name"`
+	Description string   `bson:"description" json:"description"`
+	Permissions []string `bson:"permissions" json:"permissions"`
+	// SeededPermissions records the built-in defaults already applied, so a
+	// permission removed by an admin is not added back on the next startup.
+	SeededPermissions []string  `bson:"seeded_permissions" json:"-"`
+	UpdatedBy         string    `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+	UpdatedAt         time.Time `bson:"updated_at" json:"updated_at"`
+}
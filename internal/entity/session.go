@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// Session groups every refresh token issued from a single login (the token
+// family). Access tokens carry the session ID so a revoked session invalidates
+// them as well.
+type Session struct {
+	ID               string     `bson:"_id" json:"id"`
+	UserID           string     `bson:"user_id" json:"user_id"`
+	RefreshTokenHash string     `bson:"refresh_token_hash" json:"-"`
+	UsedTokenHashes  []string   `bson:"used_token_hashes" json:"-"`
+	UserAgent        string     `bson:"user_agent" json:"user_agent"`
+	IPAddress        string     `bson:"ip_address" json:"ip_address"`
+	CreatedAt        time.Time  `bson:"created_at" json:"created_at"`
+	RefreshedAt      time.Time  `bson:"refreshed_at" json:"refreshed_at"`
+	ExpiresAt        time.Time  `bson:"expires_at" json:"expires_at"`
+	RevokedAt        *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	RevokeReason     string     `bson:"revoke_reason,omitempty" json:"revoke_reason,omitempty"`
+}
+
+// IsActive reports whether the session can still be used at the given instant.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,36 @@
+package entity
+
+// ShoppingList sums the ingredients a diet prescribes over a range of days,
+// grouped by food category
+type ShoppingList struct {
+	DietID   string `json:"diet_id"`
+	DietName string `json:"diet_name"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	// Days counts the days of the range that fall within the diet
+	Days       int                `json:"days"`
+	Categories []ShoppingCategory `json:"categories"`
+}
+
+// ShoppingCategory groups the items of a food category
+type ShoppingCategory struct {
+	Name  string         `json:"name"`
+	Items []ShoppingItem `json:"items"`
+}
+
+// ShoppingItem is an ingredient merged across meals and days
+type ShoppingItem struct {
+	FoodID      string  `json:"food_id,omitempty"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	Unit        string  `json:"unit"`
+	// Package is filled when the quantity was rounded to package sizes
+	Package *ShoppingPackage `json:"package,omitempty"`
+}
+
+// ShoppingPackage tells how many packages of a size cover the quantity
+type ShoppingPackage struct {
+	Size  float64 `json:"size"`
+	Unit  string  `json:"unit"`
+	Count int     `json:"count"`
+}
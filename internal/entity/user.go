@@ -1,13 +1,50 @@
 package entity
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NutritionistStatus string
+
+const (
+	NutritionistPending  NutritionistStatus = "PENDING"
+	NutritionistApproved NutritionistStatus = "APPROVED"
+	NutritionistRejected NutritionistStatus = "REJECTED"
+)
 
 // User represents a user in the system
 type User struct {
-	ID       primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Email    string             `bson:"email" json:"email"`
-	Password string             `bson:"password" json:"password"`
-	Type     string             `bson:"type" json:"type"`
-	Age      int                `bson:"age" json:"age"`
-	Gender   string             `bson:"gender" json:"gender"`
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Email           string             `bson:"email" json:"email"`
+	Password        string             `bson:"password" json:"password"`
+	Type            string             `bson:"type" json:"type"`
+	Age             int                `bson:"age" json:"age"`
+	Gender          string             `bson:"gender" json:"gender"`
+	EmailVerified   bool               `bson:"email_verified" json:"email_verified"`
+	EmailVerifiedAt *time.Time         `bson:"email_verified_at,omitempty" json:"email_verified_at,omitempty"`
+
+	// Anthropometrics are informed by the patient's nutritionist
+	Anthropometrics *Anthropometrics `bson:"anthropometrics,omitempty" json:"anthropometrics,omitempty"`
+
+	// Nutritionist accounts are approved by an admin after checking the
+	// professional registration number (CRN)
+	NutritionistStatus       NutritionistStatus `bson:"nutritionist_status,omitempty" json:"nutritionist_status,omitempty"`
+	ProfessionalRegistration string             `bson:"professional_registration,omitempty" json:"professional_registration,omitempty"`
+	NutritionistReviewedBy   string             `bson:"nutritionist_reviewed_by,omitempty" json:"-"`
+	NutritionistReviewedAt   *time.Time         `bson:"nutritionist_reviewed_at,omitempty" json:"-"`
+	NutritionistReviewNote   string             `bson:"nutritionist_review_note,omitempty" json:"-"`
+
+	// Consecutive failed logins, used to lock the account against brute force
+	FailedLoginAttempts int        `bson:"failed_login_attempts" json:"-"`
+	LastFailedLoginAt   *time.Time `bson:"last_failed_login_at,omitempty" json:"-"`
+	LockedUntil         *time.Time `bson:"locked_until,omitempty" json:"-"`
+}
+
+type NutritionistReviewInput struct {
+	UserID     string
+	ReviewerID string
+	Approve    bool
+	Note       string
 }
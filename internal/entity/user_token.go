@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+type UserTokenPurpose string
+
+const (
+	PurposePasswordReset     UserTokenPurpose = "PASSWORD_RESET"
+	PurposeEmailVerification UserTokenPurpose = "EMAIL_VERIFICATION"
+)
+
+// UserToken is a single-use, expiring token sent to the user by email. Only
+// the hash of the token is stored.
+type UserToken struct {
+	ID        string           `bson:"_id" json:"id"`
+	UserID    string           `bson:"user_id" json:"user_id"`
+	Purpose   UserTokenPurpose `bson:"purpose" json:"purpose"`
+	TokenHash string           `bson:"token_hash" json:"-"`
+	CreatedAt time.Time        `bson:"created_at" json:"created_at"`
+	ExpiresAt time.Time        `bson:"expires_at" json:"expires_at"`
+	UsedAt    *time.Time       `bson:"used_at,omitempty" json:"used_at,omitempty"`
+}
+
+type ResetPasswordInput struct {
+	Token        string
+	PasswordHash string
+}
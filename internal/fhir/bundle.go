@@ -0,0 +1,119 @@
+package fhir
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ReadNutritionOrder reads a NutritionOrder sent alone or in a Bundle. The
+// email of the patient comes from the identifier of the reference or, when it
+// points to a Patient of the bundle, from the email of that Patient.
+func ReadNutritionOrder(body []byte) (*NutritionOrder, string, []Issue) {
+	var header struct {
+		ResourceType string `json:"resourceType"`
+	}
+	if err := json.Unmarshal(body, &header); err != nil {
+		return nil, "", []Issue{ErrorIssue(IssueStructure, "invalid JSON: "+err.Error(), "")}
+	}
+
+	switch header.ResourceType {
+	case "NutritionOrder":
+		order, issue := decodeOrder(body, "NutritionOrder")
+		if issue != nil {
+			return nil, "", []Issue{*issue}
+		}
+		return order, patientEmail(order.Patient, nil), nil
+	case "Bundle":
+	default:
+		return nil, "", []Issue{ErrorIssue(IssueStructure, fmt.Sprintf("expected a Bundle or a NutritionOrder, got %q", header.ResourceType), "")}
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(body, &bundle); err != nil {
+		return nil, "", []Issue{ErrorIssue(IssueStructure, "invalid Bundle: "+err.Error(), "Bundle")}
+	}
+
+	var order *NutritionOrder
+	patients := map[string]*Patient{}
+	for i, entry := range bundle.Entry {
+		path := fmt.Sprintf("Bundle.entry[%d].resource", i)
+
+		var resource struct {
+			ResourceType string `json:"resourceType"`
+		}
+		if err := json.Unmarshal(entry.Resource, &resource); err != nil {
+			return nil, "", []Issue{ErrorIssue(IssueStructure, "invalid resource: "+err.Error(), path)}
+		}
+
+		switch resource.ResourceType {
+		case "NutritionOrder":
+			if order != nil {
+				return nil, "", []Issue{ErrorIssue(IssueInvalid, "the bundle must have a single NutritionOrder", path)}
+			}
+			decoded, issue := decodeOrder(entry.Resource, path)
+			if issue != nil {
+				return nil, "", []Issue{*issue}
+			}
+			order = decoded
+		case "Patient":
+			var patient Patient
+			if err := json.Unmarshal(entry.Resource, &patient); err != nil {
+				return nil, "", []Issue{ErrorIssue(IssueStructure, "invalid Patient: "+err.Error(), path)}
+			}
+			if entry.FullURL != "" {
+				patients[entry.FullURL] = &patient
+			}
+			if patient.ID != "" {
+				patients["Patient/"+patient.ID] = &patient
+			}
+		}
+	}
+
+	if order == nil {
+		return nil, "", []Issue{ErrorIssue(IssueRequired, "the bundle has no NutritionOrder", "Bundle.entry")}
+	}
+	return order, patientEmail(order.Patient, patients), nil
+}
+
+func decodeOrder(body []byte, path string) (*NutritionOrder, *Issue) {
+	var order NutritionOrder
+	if err := json.Unmarshal(body, &order); err != nil {
+		issue := ErrorIssue(IssueStructure, "invalid NutritionOrder: "+err.Error(), path)
+		return nil, &issue
+	}
+	return &order, nil
+}
+
+// patientEmail finds the email of the referenced patient
+func patientEmail(reference Reference, patients map[string]*Patient) string {
+	if email := mailto(reference.Identifier); email != "" {
+		return email
+	}
+
+	patient, ok := patients[reference.Reference]
+	if !ok {
+		return ""
+	}
+	for _, telecom := range patient.Telecom {
+		if telecom.System == "email" && telecom.Value != "" {
+			return telecom.Value
+		}
+	}
+	for i := range patient.Identifier {
+		if email := mailto(&patient.Identifier[i]); email != "" {
+			return email
+		}
+	}
+	return ""
+}
+
+func mailto(identifier *Identifier) string {
+	if identifier == nil {
+		return ""
+	}
+	if email, ok := strings.CutPrefix(identifier.Value, "mailto:"); ok {
+		return email
+	}
+	return ""
+}
@@ -0,0 +1,436 @@
+package fhir
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/mealtime"
+	"github.com/victorgiudicissi/your-diet/internal/units"
+)
+
+const baseURL = "https://your-diet.app/fhir"
+
+// Extensions and code systems of the API. The extensions keep what the
+// NutritionOrder has no element for (the foods of each meal, the day
+// templates), so an exported diet can be imported back without losses.
+const (
+	ExtensionDiet = baseURL + "/StructureDefinition/diet"
+	ExtensionMeal = baseURL + "/StructureDefinition/meal"
+
+	SystemDiet     = baseURL + "/NamingSystem/diet"
+	SystemTexture  = baseURL + "/CodeSystem/texture"
+	SystemNutrient = baseURL + "/CodeSystem/nutrient"
+	SystemUnit     = baseURL + "/CodeSystem/unit"
+	SystemUCUM     = "http://unitsofmeasure.org"
+	// SystemURI identifies people by a URI, such as mailto:ana@example.com
+	SystemURI = "urn:ietf:rfc:3986"
+)
+
+// Date layouts of the FHIR date and time types
+const (
+	DateLayout = "2006-01-02"
+	TimeLayout = "15:04:05"
+)
+
+var weekdayCodes = map[entity.Weekday]string{
+	entity.Monday:    "mon",
+	entity.Tuesday:   "tue",
+	entity.Wednesday: "wed",
+	entity.Thursday:  "thu",
+	entity.Friday:    "fri",
+	entity.Saturday:  "sat",
+	entity.Sunday:    "sun",
+}
+
+var weekOrder = []entity.Weekday{
+	entity.Monday, entity.Tuesday, entity.Wednesday, entity.Thursday, entity.Friday, entity.Saturday, entity.Sunday,
+}
+
+// Weekday converts a FHIR day of week ("mon") to the weekday of the diet
+func Weekday(code string) (entity.Weekday, bool) {
+	for weekday, value := range weekdayCodes {
+		if value == code {
+			return weekday, true
+		}
+	}
+	return "", false
+}
+
+// textures follow the IDDSI food levels
+var textures = map[entity.DietTexture]string{
+	entity.TextureRegular:          "IDDSI 7 - Regular",
+	entity.TextureEasyToChew:       "IDDSI 7 - Easy to chew",
+	entity.TextureSoftAndBiteSized: "IDDSI 6 - Soft and bite-sized",
+	entity.TextureMincedAndMoist:   "IDDSI 5 - Minced and moist",
+	entity.TexturePureed:           "IDDSI 4 - Pureed",
+	entity.TextureLiquidised:       "IDDSI 3 - Liquidised",
+}
+
+// ucum maps the metric units to their UCUM codes
+var ucum = map[string]string{
+	"mg": "mg",
+	"g":  "g",
+	"kg": "kg",
+	"ml": "mL",
+	"l":  "L",
+}
+
+// DietStatus maps the status of the diet to the status of the request
+func DietStatus(diet *entity.Diet) string {
+	if diet.IsDeleted() {
+		return "entered-in-error"
+	}
+
+	switch entity.DietStatus(diet.Status) {
+	case entity.DietDraft:
+		return "draft"
+	case entity.DietScheduled, entity.DietActive, entity.Enabled:
+		return "active"
+	case entity.DietExpired:
+		return "completed"
+	case entity.DietArchived, entity.Disabled:
+		return "revoked"
+	default:
+		return "unknown"
+	}
+}
+
+// NutritionOrderFromDiet describes the diet as an oral diet order. Each meal
+// is a schedule with its time and the days it happens; the foods go in the
+// meal extension and, readable by any system, in the instruction. The daily
+// nutrients are included when the nutrition of the diet was calculated.
+func NutritionOrderFromDiet(diet *entity.Diet, nutritionist *entity.User) *NutritionOrder {
+	order := &NutritionOrder{
+		ResourceType: "NutritionOrder",
+		ID:           diet.ID,
+		Meta: &Meta{
+			VersionID:   strconv.FormatInt(diet.Version, 10),
+			LastUpdated: diet.UpdatedAt.UTC().Format(time.RFC3339),
+		},
+		Extension:  []Extension{dietExtension(diet)},
+		Identifier: []Identifier{{System: SystemDiet, Value: diet.ID}},
+		Status:     DietStatus(diet),
+		Intent:     "order",
+		Patient:    emailReference(diet.UserEmail),
+		DateTime:   diet.CreatedAt.UTC().Format(time.RFC3339),
+		OralDiet: &OralDiet{
+			Type:        []CodeableConcept{{Text: diet.DietName}},
+			Schedule:    dietSchedules(diet),
+			Nutrient:    dietNutrients(diet.Nutrition),
+			Instruction: dietInstruction(diet),
+		},
+	}
+
+	if nutritionist != nil {
+		orderer := emailReference(nutritionist.Email)
+		if nutritionist.ProfessionalRegistration != "" {
+			orderer.Display += " - CRN " + nutritionist.ProfessionalRegistration
+		}
+		order.Orderer = &orderer
+	}
+
+	if display, ok := textures[diet.Texture]; ok {
+		order.OralDiet.Texture = []OralDietTexture{{
+			Modifier: &CodeableConcept{
+				Coding: []Coding{{System: SystemTexture, Code: string(diet.Texture), Display: display}},
+				Text:   display,
+			},
+		}}
+	}
+
+	if strings.TrimSpace(diet.Observations) != "" {
+		order.Note = []Annotation{{Text: diet.Observations}}
+	}
+	return order
+}
+
+func emailReference(email string) Reference {
+	return Reference{
+		Identifier: &Identifier{System: SystemURI, Value: "mailto:" + email},
+		Display:    email,
+	}
+}
+
+// dietExtension keeps the duration and the rule of the day templates
+func dietExtension(diet *entity.Diet) Extension {
+	duration := int64(diet.DurationInDays)
+	extension := Extension{
+		URL:       ExtensionDiet,
+		Extension: []Extension{{URL: "durationInDays", ValueInteger: &duration}},
+	}
+
+	if diet.DayRule != nil {
+		extension.Extension = append(extension.Extension, codeExtension("dayRule", string(diet.DayRule.Type)))
+		for _, name := range diet.DayRule.Rotation {
+			extension.Extension = append(extension.Extension, stringExtension("rotation", name))
+		}
+	}
+	return extension
+}
+
+// dietSchedules lists the meals of the diet and of each day template, with
+// the days they repeat on
+func dietSchedules(diet *entity.Diet) []Timing {
+	var bounds *Period
+	if diet.StartsAt != nil {
+		bounds = &Period{Start: diet.StartsAt.Format(DateLayout)}
+		if diet.EndsAt != nil {
+			// EndsAt é exclusivo; o fim do período é o último dia
+			bounds.End = diet.EndsAt.AddDate(0, 0, -1).Format(DateLayout)
+		}
+	}
+
+	var schedules []Timing
+
+	switch {
+	case len(diet.Days) > 0 && diet.DayRule != nil && diet.DayRule.Type == entity.DayRuleWeekday:
+		assigned := map[entity.Weekday]bool{}
+		for _, day := range diet.Days {
+			for _, weekday := range day.Weekdays {
+				assigned[weekday] = true
+			}
+			schedules = append(schedules, mealSchedules(day.Meals, day.Name, &TimingRepeat{BoundsPeriod: bounds, DayOfWeek: dayCodes(day.Weekdays)})...)
+		}
+
+		var free []entity.Weekday
+		for _, weekday := range weekOrder {
+			if !assigned[weekday] {
+				free = append(free, weekday)
+			}
+		}
+		if len(free) > 0 {
+			schedules = append(mealSchedules(diet.Meals, "", &TimingRepeat{BoundsPeriod: bounds, DayOfWeek: dayCodes(free)}), schedules...)
+		}
+	case len(diet.Days) > 0 && diet.DayRule != nil && diet.DayRule.Type == entity.DayRuleRotation && len(diet.DayRule.Rotation) > 0:
+		// Cada modelo acontece tantas vezes quanto aparece em cada ciclo
+		for _, day := range diet.Days {
+			times := 0
+			for _, name := range diet.DayRule.Rotation {
+				if name == day.Name {
+					times++
+				}
+			}
+			schedules = append(schedules, mealSchedules(day.Meals, day.Name, &TimingRepeat{
+				BoundsPeriod: bounds,
+				Frequency:    times,
+				Period:       float64(len(diet.DayRule.Rotation)),
+				PeriodUnit:   "d",
+			})...)
+		}
+	default:
+		schedules = mealSchedules(diet.Meals, "", &TimingRepeat{BoundsPeriod: bounds, Frequency: 1, Period: 1, PeriodUnit: "d"})
+	}
+	return schedules
+}
+
+func dayCodes(weekdays []entity.Weekday) []string {
+	codes := make([]string, 0, len(weekdays))
+	for _, weekday := range weekdays {
+		codes = append(codes, weekdayCodes[weekday])
+	}
+	return codes
+}
+
+func mealSchedules(meals []entity.Meal, dayName string, repeat *TimingRepeat) []Timing {
+	schedules := make([]Timing, 0, len(meals))
+	for _, meal := range meals {
+		mealRepeat := *repeat
+		if minutes, err := mealtime.ParseClock(meal.TimeOfDay); err == nil {
+			mealRepeat.TimeOfDay = []string{clockTime(minutes)}
+		}
+		schedules = append(schedules, Timing{
+			Extension: []Extension{mealExtension(meal, dayName)},
+			Repeat:    &mealRepeat,
+		})
+	}
+	return schedules
+}
+
+func mealExtension(meal entity.Meal, dayName string) Extension {
+	extension := Extension{URL: ExtensionMeal, Extension: []Extension{stringExtension("name", meal.Name)}}
+	if meal.Description != "" {
+		extension.Extension = append(extension.Extension, stringExtension("description", meal.Description))
+	}
+	if meal.TimeOfDay != "" {
+		extension.Extension = append(extension.Extension, timeExtension("timeOfDay", meal.TimeOfDay))
+	}
+	if meal.WindowStart != "" && meal.WindowEnd != "" {
+		extension.Extension = append(extension.Extension,
+			timeExtension("windowStart", meal.WindowStart),
+			timeExtension("windowEnd", meal.WindowEnd))
+	}
+	if meal.Slot != "" {
+		extension.Extension = append(extension.Extension, codeExtension("slot", string(meal.Slot)))
+	}
+	if dayName != "" {
+		extension.Extension = append(extension.Extension, stringExtension("dayTemplate", dayName))
+	}
+	for _, ingredient := range meal.Ingredients {
+		extension.Extension = append(extension.Extension, ingredientExtension("ingredient", ingredient))
+	}
+	return extension
+}
+
+func ingredientExtension(url string, ingredient entity.Ingredient) Extension {
+	quantity := ingredient.Quantity
+	extension := Extension{URL: url, Extension: []Extension{
+		stringExtension("description", ingredient.Description),
+		{URL: "quantity", ValueQuantity: unitQuantity(&quantity, ingredient.Unit)},
+	}}
+	if ingredient.FoodID != "" {
+		extension.Extension = append(extension.Extension, stringExtension("foodId", ingredient.FoodID))
+	}
+	if ingredient.UnitWeightG > 0 {
+		weight := ingredient.UnitWeightG
+		extension.Extension = append(extension.Extension, Extension{URL: "unitWeightG", ValueDecimal: &weight})
+	}
+	for _, substitute := range ingredient.Substitutes {
+		extension.Extension = append(extension.Extension, ingredientExtension("substitute", substitute))
+	}
+	return extension
+}
+
+// unitQuantity codes metric units with UCUM and household measures with the
+// units of the API
+func unitQuantity(value *float64, unitCode string) *Quantity {
+	quantity := &Quantity{Value: value, Unit: unitCode, System: SystemUnit, Code: unitCode}
+	if unit, err := units.Parse(unitCode); err == nil {
+		quantity.Unit = unit.Name
+		quantity.Code = unit.Code
+		if code, ok := ucum[unit.Code]; ok {
+			quantity.System = SystemUCUM
+			quantity.Code = code
+		}
+	}
+	return quantity
+}
+
+var nutrients = []struct {
+	code    string
+	display string
+	unit    string
+	value   func(entity.Nutrients) float64
+}{
+	{"energy", "Energia", "kcal", func(n entity.Nutrients) float64 { return n.EnergyKcal }},
+	{"protein", "Proteínas", "g", func(n entity.Nutrients) float64 { return n.ProteinG }},
+	{"carbohydrate", "Carboidratos", "g", func(n entity.Nutrients) float64 { return n.CarbohydrateG }},
+	{"fat", "Gorduras", "g", func(n entity.Nutrients) float64 { return n.FatG }},
+	{"fiber", "Fibras", "g", func(n entity.Nutrients) float64 { return n.FiberG }},
+	{"sodium", "Sódio", "mg", func(n entity.Nutrients) float64 { return n.SodiumMg }},
+}
+
+// dietNutrients lists the daily amounts of the main nutrients
+func dietNutrients(nutrition *entity.DietNutrition) []OralDietNutrient {
+	if nutrition == nil {
+		return nil
+	}
+
+	var list []OralDietNutrient
+	for _, nutrient := range nutrients {
+		value := nutrient.value(nutrition.PerDay)
+		if value <= 0 {
+			continue
+		}
+		rounded, _ := strconv.ParseFloat(strconv.FormatFloat(value, 'f', 1, 64), 64)
+		list = append(list, OralDietNutrient{
+			Modifier: &CodeableConcept{
+				Coding: []Coding{{System: SystemNutrient, Code: nutrient.code, Display: nutrient.display}},
+				Text:   nutrient.display,
+			},
+			Amount: &Quantity{Value: &rounded, Unit: nutrient.unit, System: SystemUCUM, Code: nutrient.unit},
+		})
+	}
+	return list
+}
+
+// dietInstruction writes the meals as text, for systems that do not read
+// the extensions
+func dietInstruction(diet *entity.Diet) string {
+	var lines []string
+	write := func(prefix string, meals []entity.Meal) {
+		sorted := append([]entity.Meal(nil), meals...)
+		mealtime.Sort(sorted)
+		for _, meal := range sorted {
+			items := make([]string, 0, len(meal.Ingredients))
+			for _, ingredient := range meal.Ingredients {
+				item := ingredientText(ingredient)
+				for _, substitute := range ingredient.Substitutes {
+					item += " ou " + ingredientText(substitute)
+				}
+				items = append(items, item)
+			}
+
+			title := prefix + meal.Name
+			if meal.TimeOfDay != "" {
+				title += " (" + meal.TimeOfDay + ")"
+			}
+			lines = append(lines, title+": "+strings.Join(items, "; "))
+		}
+	}
+
+	write("", diet.Meals)
+	for _, day := range diet.Days {
+		write(day.Name+" - ", day.Meals)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func ingredientText(ingredient entity.Ingredient) string {
+	unit := ingredient.Unit
+	if parsed, err := units.Parse(unit); err == nil {
+		unit = parsed.Name
+	}
+	quantity := strings.Replace(strconv.FormatFloat(ingredient.Quantity, 'f', -1, 64), ".", ",", 1)
+	return fmt.Sprintf("%s %s %s", ingredient.Description, quantity, unit)
+}
+
+// clockTime formats minutes since midnight as a FHIR time
+func clockTime(minutes int) string {
+	return mealtime.FormatClock(minutes) + ":00"
+}
+
+func stringExtension(url, value string) Extension {
+	return Extension{URL: url, ValueString: &value}
+}
+
+func codeExtension(url, value string) Extension {
+	return Extension{URL: url, ValueCode: &value}
+}
+
+// timeExtension stores an HH:MM time as a FHIR time, keeping free text as a string
+func timeExtension(url, clock string) Extension {
+	if minutes, err := mealtime.ParseClock(clock); err == nil {
+		value := clockTime(minutes)
+		return Extension{URL: url, ValueTime: &value}
+	}
+	return stringExtension(url, clock)
+}
+
+// FindExtension returns the first extension with the url
+func FindExtension(extensions []Extension, url string) *Extension {
+	for i := range extensions {
+		if extensions[i].URL == url {
+			return &extensions[i]
+		}
+	}
+	return nil
+}
+
+// Text returns the string, code or time value of the extension
+func (e *Extension) Text() string {
+	switch {
+	case e == nil:
+		return ""
+	case e.ValueString != nil:
+		return *e.ValueString
+	case e.ValueCode != nil:
+		return *e.ValueCode
+	case e.ValueTime != nil:
+		return *e.ValueTime
+	default:
+		return ""
+	}
+}
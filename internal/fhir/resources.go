@@ -0,0 +1,183 @@
+// Package fhir maps diets to HL7 FHIR R4 resources. Only the elements used by
+// the NutritionOrder exchange are modeled.
+package fhir
+
+import "encoding/json"
+
+// ContentType is the media type of FHIR resources in JSON
+const ContentType = "application/fhir+json"
+
+type Meta struct {
+	VersionID   string `json:"versionId,omitempty"`
+	LastUpdated string `json:"lastUpdated,omitempty"`
+}
+
+type Identifier struct {
+	System string `json:"system,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+type Reference struct {
+	Reference  string      `json:"reference,omitempty"`
+	Identifier *Identifier `json:"identifier,omitempty"`
+	Display    string      `json:"display,omitempty"`
+}
+
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+type CodeableConcept struct {
+	Coding []Coding `json:"coding,omitempty"`
+	Text   string   `json:"text,omitempty"`
+}
+
+type Quantity struct {
+	Value  *float64 `json:"value,omitempty"`
+	Unit   string   `json:"unit,omitempty"`
+	System string   `json:"system,omitempty"`
+	Code   string   `json:"code,omitempty"`
+}
+
+type Period struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+type Annotation struct {
+	Text string `json:"text"`
+}
+
+// Extension carries the data FHIR has no element for. Complex extensions
+// hold other extensions instead of a value.
+type Extension struct {
+	URL           string      `json:"url"`
+	ValueString   *string     `json:"valueString,omitempty"`
+	ValueCode     *string     `json:"valueCode,omitempty"`
+	ValueInteger  *int64      `json:"valueInteger,omitempty"`
+	ValueDecimal  *float64    `json:"valueDecimal,omitempty"`
+	ValueTime     *string     `json:"valueTime,omitempty"`
+	ValueQuantity *Quantity   `json:"valueQuantity,omitempty"`
+	Extension     []Extension `json:"extension,omitempty"`
+}
+
+type Timing struct {
+	Extension []Extension      `json:"extension,omitempty"`
+	Repeat    *TimingRepeat    `json:"repeat,omitempty"`
+	Code      *CodeableConcept `json:"code,omitempty"`
+}
+
+type TimingRepeat struct {
+	BoundsPeriod *Period  `json:"boundsPeriod,omitempty"`
+	Frequency    int      `json:"frequency,omitempty"`
+	Period       float64  `json:"period,omitempty"`
+	PeriodUnit   string   `json:"periodUnit,omitempty"`
+	DayOfWeek    []string `json:"dayOfWeek,omitempty"`
+	TimeOfDay    []string `json:"timeOfDay,omitempty"`
+}
+
+type NutritionOrder struct {
+	ResourceType string       `json:"resourceType"`
+	ID           string       `json:"id,omitempty"`
+	Meta         *Meta        `json:"meta,omitempty"`
+	Extension    []Extension  `json:"extension,omitempty"`
+	Identifier   []Identifier `json:"identifier,omitempty"`
+	Status       string       `json:"status"`
+	Intent       string       `json:"intent"`
+	Patient      Reference    `json:"patient"`
+	DateTime     string       `json:"dateTime"`
+	Orderer      *Reference   `json:"orderer,omitempty"`
+	OralDiet     *OralDiet    `json:"oralDiet,omitempty"`
+	Note         []Annotation `json:"note,omitempty"`
+}
+
+type OralDiet struct {
+	Type        []CodeableConcept  `json:"type,omitempty"`
+	Schedule    []Timing           `json:"schedule,omitempty"`
+	Nutrient    []OralDietNutrient `json:"nutrient,omitempty"`
+	Texture     []OralDietTexture  `json:"texture,omitempty"`
+	Instruction string             `json:"instruction,omitempty"`
+}
+
+type OralDietNutrient struct {
+	Modifier *CodeableConcept `json:"modifier,omitempty"`
+	Amount   *Quantity        `json:"amount,omitempty"`
+}
+
+type OralDietTexture struct {
+	Modifier *CodeableConcept `json:"modifier,omitempty"`
+	FoodType *CodeableConcept `json:"foodType,omitempty"`
+}
+
+type ContactPoint struct {
+	System string `json:"system,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+// Patient is read from the bundle only to find the email of the patient
+type Patient struct {
+	ResourceType string         `json:"resourceType"`
+	ID           string         `json:"id,omitempty"`
+	Identifier   []Identifier   `json:"identifier,omitempty"`
+	Telecom      []ContactPoint `json:"telecom,omitempty"`
+}
+
+type Bundle struct {
+	ResourceType string        `json:"resourceType"`
+	Type         string        `json:"type,omitempty"`
+	Entry        []BundleEntry `json:"entry,omitempty"`
+}
+
+type BundleEntry struct {
+	FullURL  string          `json:"fullUrl,omitempty"`
+	Resource json.RawMessage `json:"resource,omitempty"`
+}
+
+type OperationOutcome struct {
+	ResourceType string  `json:"resourceType"`
+	Issue        []Issue `json:"issue"`
+}
+
+// Issue is a problem found in the request. Expression points to the element
+// with a FHIRPath such as "NutritionOrder.oralDiet.schedule[1]".
+type Issue struct {
+	Severity    string   `json:"severity"`
+	Code        string   `json:"code"`
+	Diagnostics string   `json:"diagnostics,omitempty"`
+	Expression  []string `json:"expression,omitempty"`
+}
+
+// Severities and codes of the issues reported by the API
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+
+	IssueInvalid   = "invalid"
+	IssueRequired  = "required"
+	IssueValue     = "value"
+	IssueStructure = "structure"
+	IssueNotFound  = "not-found"
+	IssueForbidden = "forbidden"
+	IssueConflict  = "conflict"
+	IssueException = "exception"
+	IssueLogin     = "login"
+)
+
+// NewOperationOutcome wraps the issues in an OperationOutcome
+func NewOperationOutcome(issues ...Issue) *OperationOutcome {
+	if issues == nil {
+		issues = []Issue{}
+	}
+	return &OperationOutcome{ResourceType: "OperationOutcome", Issue: issues}
+}
+
+// ErrorIssue is an error with an optional FHIRPath of the element
+func ErrorIssue(code, diagnostics, expression string) Issue {
+	issue := Issue{Severity: SeverityError, Code: code, Diagnostics: diagnostics}
+	if expression != "" {
+		issue.Expression = []string{expression}
+	}
+	return issue
+}
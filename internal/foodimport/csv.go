@@ -0,0 +1,93 @@
+// Package foodimport parses food composition tables into catalog foods.
+package foodimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// newCSVReader skips a UTF-8 byte order mark and detects whether the file is
+// separated by ";" (common in Brazilian spreadsheets) or ",".
+func newCSVReader(r io.Reader) (*csv.Reader, error) {
+	buffered := bufio.NewReader(r)
+
+	if bom, err := buffered.Peek(len(utf8BOM)); err == nil && bytes.Equal(bom, utf8BOM) {
+		if _, err := buffered.Discard(len(utf8BOM)); err != nil {
+			return nil, err
+		}
+	}
+
+	head, err := buffered.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	if end := bytes.IndexByte(head, '\n'); end >= 0 {
+		head = head[:end]
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if bytes.Count(head, []byte(";")) > bytes.Count(head, []byte(",")) {
+		reader.Comma = ';'
+	}
+	return reader, nil
+}
+
+// headerIndex maps normalized column names to their position
+type headerIndex map[string]int
+
+func newHeaderIndex(header []string) headerIndex {
+	index := make(headerIndex, len(header))
+	for i, name := range header {
+		index[utils.NormalizeText(name)] = i
+	}
+	return index
+}
+
+// find returns the first column whose normalized name starts with one of the
+// prefixes, or -1.
+func (h headerIndex) find(prefixes ...string) int {
+	for _, prefix := range prefixes {
+		best := -1
+		for name, i := range h {
+			if strings.HasPrefix(name, prefix) && (best == -1 || i < best) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			return best
+		}
+	}
+	return -1
+}
+
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// parseAmount reads a nutrient value. Decimal commas are accepted; traces
+// ("Tr") and missing values ("NA", "*", "-", empty) count as zero.
+func parseAmount(value string) (float64, bool) {
+	switch strings.ToLower(value) {
+	case "", "na", "tr", "*", "-":
+		return 0, true
+	}
+
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil {
+		return 0, false
+	}
+	return amount, true
+}
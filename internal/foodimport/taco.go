@@ -0,0 +1,123 @@
+package foodimport
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+// tacoColumns maps each nutrient to the header prefixes used by the TACO
+// spreadsheets (4th edition), after normalization.
+var tacoColumns = []struct {
+	prefixes []string
+	set      func(n *entity.Nutrients, v float64)
+}{
+	{[]string{"energia kcal"}, func(n *entity.Nutrients, v float64) { n.EnergyKcal = v }},
+	{[]string{"proteina"}, func(n *entity.Nutrients, v float64) { n.ProteinG = v }},
+	{[]string{"carboidrato"}, func(n *entity.Nutrients, v float64) { n.CarbohydrateG = v }},
+	{[]string{"lipideos", "lipidios"}, func(n *entity.Nutrients, v float64) { n.FatG = v }},
+	{[]string{"fibra"}, func(n *entity.Nutrients, v float64) { n.FiberG = v }},
+	{[]string{"colesterol"}, func(n *entity.Nutrients, v float64) { n.CholesterolMg = v }},
+	{[]string{"sodio"}, func(n *entity.Nutrients, v float64) { n.SodiumMg = v }},
+	{[]string{"calcio"}, func(n *entity.Nutrients, v float64) { n.CalciumMg = v }},
+	{[]string{"ferro"}, func(n *entity.Nutrients, v float64) { n.IronMg = v }},
+	{[]string{"potassio"}, func(n *entity.Nutrients, v float64) { n.PotassiumMg = v }},
+	{[]string{"magnesio"}, func(n *entity.Nutrients, v float64) { n.MagnesiumMg = v }},
+	{[]string{"vitamina c"}, func(n *entity.Nutrients, v float64) { n.VitaminCMg = v }},
+}
+
+// ParseTACO reads the TACO table exported as CSV. Columns are found by name,
+// so exports with extra or reordered columns work. When there is no category
+// column, rows without a food number are taken as the category of the rows
+// that follow them, as in the original spreadsheet.
+func ParseTACO(r io.Reader) ([]*entity.Food, error) {
+	reader, err := newCSVReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading TACO header: %w", err)
+	}
+
+	columns := newHeaderIndex(header)
+	idCol := columns.find("numero", "codigo", "id")
+	nameCol := columns.find("descricao", "alimento", "nome")
+	categoryCol := columns.find("categoria", "grupo")
+	if idCol < 0 || nameCol < 0 || columns.find("energia kcal") < 0 {
+		return nil, fmt.Errorf("TACO file must have food number, description and energy (kcal) columns")
+	}
+
+	nutrientCols := make([]int, len(tacoColumns))
+	for i, column := range tacoColumns {
+		nutrientCols[i] = columns.find(column.prefixes...)
+	}
+
+	now := time.Now()
+	foods := []*entity.Food{}
+	category := ""
+	line := 1
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		sourceID := field(record, idCol)
+		if sourceID == "" {
+			if categoryCol < 0 {
+				if name := firstValue(record); name != "" {
+					category = name
+				}
+			}
+			continue
+		}
+
+		food := &entity.Food{
+			ID:         entity.FoodID(entity.FoodSourceTACO, sourceID),
+			Source:     entity.FoodSourceTACO,
+			SourceID:   sourceID,
+			Name:       field(record, nameCol),
+			Category:   category,
+			ImportedAt: now,
+		}
+		if categoryCol >= 0 {
+			food.Category = field(record, categoryCol)
+		}
+		food.NormalizedName = utils.NormalizeText(food.Name)
+
+		for i, column := range tacoColumns {
+			if nutrientCols[i] < 0 {
+				continue
+			}
+			value := field(record, nutrientCols[i])
+			amount, ok := parseAmount(value)
+			if !ok {
+				return nil, fmt.Errorf("line %d: invalid value %q for %s", line, value, header[nutrientCols[i]])
+			}
+			column.set(&food.Nutrients, amount)
+		}
+
+		foods = append(foods, food)
+	}
+
+	return foods, nil
+}
+
+func firstValue(record []string) string {
+	for i := range record {
+		if value := field(record, i); value != "" {
+			return value
+		}
+	}
+	return ""
+}
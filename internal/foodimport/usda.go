@@ -0,0 +1,227 @@
+package foodimport
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+// usdaNutrients maps each nutrient to the FoodData Central nutrient ids that
+// carry it, in order of preference. Foundation foods often report energy only
+// as Atwater factors (2047/2048) and carbohydrates by summation (1050).
+var usdaNutrients = []struct {
+	ids []int
+	set func(n *entity.Nutrients, v float64)
+}{
+	{[]int{1008, 2047, 2048}, func(n *entity.Nutrients, v float64) { n.EnergyKcal = v }},
+	{[]int{1003}, func(n *entity.Nutrients, v float64) { n.ProteinG = v }},
+	{[]int{1005, 1050}, func(n *entity.Nutrients, v float64) { n.CarbohydrateG = v }},
+	{[]int{1004, 1085}, func(n *entity.Nutrients, v float64) { n.FatG = v }},
+	{[]int{1079}, func(n *entity.Nutrients, v float64) { n.FiberG = v }},
+	{[]int{1253}, func(n *entity.Nutrients, v float64) { n.CholesterolMg = v }},
+	{[]int{1093}, func(n *entity.Nutrients, v float64) { n.SodiumMg = v }},
+	{[]int{1087}, func(n *entity.Nutrients, v float64) { n.CalciumMg = v }},
+	{[]int{1089}, func(n *entity.Nutrients, v float64) { n.IronMg = v }},
+	{[]int{1092}, func(n *entity.Nutrients, v float64) { n.PotassiumMg = v }},
+	{[]int{1090}, func(n *entity.Nutrients, v float64) { n.MagnesiumMg = v }},
+	{[]int{1162}, func(n *entity.Nutrients, v float64) { n.VitaminCMg = v }},
+}
+
+// USDAFiles are the CSV files of a FoodData Central download used by the
+// importer. Categories is optional.
+type USDAFiles struct {
+	Foods         io.Reader // food.csv
+	FoodNutrients io.Reader // food_nutrient.csv
+	Categories    io.Reader // food_category.csv
+}
+
+// ParseUSDA reads a FoodData Central CSV download. Only foods whose data_type
+// is in dataTypes are kept (all of them when empty); food_nutrient.csv is
+// streamed, so full downloads do not need to fit in memory.
+func ParseUSDA(files USDAFiles, dataTypes []string) ([]*entity.Food, error) {
+	categories := map[string]string{}
+	if files.Categories != nil {
+		var err error
+		if categories, err = readUSDACategories(files.Categories); err != nil {
+			return nil, fmt.Errorf("food_category.csv: %w", err)
+		}
+	}
+
+	foods, err := readUSDAFoods(files.Foods, categories, dataTypes)
+	if err != nil {
+		return nil, fmt.Errorf("food.csv: %w", err)
+	}
+
+	if err := readUSDANutrients(files.FoodNutrients, foods); err != nil {
+		return nil, fmt.Errorf("food_nutrient.csv: %w", err)
+	}
+
+	result := make([]*entity.Food, 0, len(foods))
+	for _, food := range foods {
+		result = append(result, food)
+	}
+	return result, nil
+}
+
+func readUSDACategories(r io.Reader) (map[string]string, error) {
+	reader, err := newCSVReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := newHeaderIndex(header)
+	idCol, descriptionCol := columns.find("id"), columns.find("description")
+	if idCol < 0 || descriptionCol < 0 {
+		return nil, fmt.Errorf("missing id or description column")
+	}
+
+	categories := map[string]string{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return categories, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		categories[field(record, idCol)] = field(record, descriptionCol)
+	}
+}
+
+func readUSDAFoods(r io.Reader, categories map[string]string, dataTypes []string) (map[string]*entity.Food, error) {
+	reader, err := newCSVReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := newHeaderIndex(header)
+	idCol := columns.find("fdc id")
+	typeCol := columns.find("data type")
+	descriptionCol := columns.find("description")
+	categoryCol := columns.find("food category id")
+	if idCol < 0 || descriptionCol < 0 {
+		return nil, fmt.Errorf("missing fdc_id or description column")
+	}
+
+	allowed := map[string]bool{}
+	for _, dataType := range dataTypes {
+		allowed[dataType] = true
+	}
+
+	now := time.Now()
+	foods := map[string]*entity.Food{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return foods, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(allowed) > 0 && !allowed[field(record, typeCol)] {
+			continue
+		}
+
+		sourceID := field(record, idCol)
+		name := field(record, descriptionCol)
+		foods[sourceID] = &entity.Food{
+			ID:             entity.FoodID(entity.FoodSourceUSDA, sourceID),
+			Source:         entity.FoodSourceUSDA,
+			SourceID:       sourceID,
+			Name:           name,
+			NormalizedName: utils.NormalizeText(name),
+			Category:       categories[field(record, categoryCol)],
+			ImportedAt:     now,
+		}
+	}
+}
+
+func readUSDANutrients(r io.Reader, foods map[string]*entity.Food) error {
+	reader, err := newCSVReader(r)
+	if err != nil {
+		return err
+	}
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return err
+	}
+
+	columns := newHeaderIndex(header)
+	foodCol := columns.find("fdc id")
+	nutrientCol := columns.find("nutrient id")
+	amountCol := columns.find("amount")
+	if foodCol < 0 || nutrientCol < 0 || amountCol < 0 {
+		return fmt.Errorf("missing fdc_id, nutrient_id or amount column")
+	}
+
+	// rank remembers the preference of the id that set each nutrient, so a
+	// fallback id never overrides the preferred one
+	type key struct {
+		food     string
+		nutrient int
+	}
+	rank := map[key]int{}
+
+	positions := map[int][2]int{}
+	for n, nutrient := range usdaNutrients {
+		for preference, id := range nutrient.ids {
+			positions[id] = [2]int{n, preference}
+		}
+	}
+
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		line++
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		food, ok := foods[field(record, foodCol)]
+		if !ok {
+			continue
+		}
+
+		nutrientID, err := strconv.Atoi(field(record, nutrientCol))
+		if err != nil {
+			continue
+		}
+
+		position, ok := positions[nutrientID]
+		if !ok {
+			continue
+		}
+
+		amount, ok := parseAmount(field(record, amountCol))
+		if !ok {
+			return fmt.Errorf("line %d: invalid amount %q", line, field(record, amountCol))
+		}
+
+		k := key{food: food.SourceID, nutrient: position[0]}
+		if current, seen := rank[k]; seen && current <= position[1] {
+			continue
+		}
+		rank[k] = position[1]
+		usdaNutrients[position[0]].set(&food.Nutrients, amount)
+	}
+}
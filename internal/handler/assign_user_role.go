@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type AssignUserRoleHandler struct {
+	assignUserRoleUseCase usecase.AssignUserRoleUseCase
+}
+
+func NewAssignUserRoleHandler(assignUserRoleUC usecase.AssignUserRoleUseCase) *AssignUserRoleHandler {
+	return &AssignUserRoleHandler{
+		assignUserRoleUseCase: assignUserRoleUC,
+	}
+}
+
+func (h *AssignUserRoleHandler) Handle(c *gin.Context) {
+	var req dto.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[AssignUserRoleHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong binding request data", err.Error()))
+		return
+	}
+
+	if err := h.assignUserRoleUseCase.Execute(c.Request.Context(), c.Param("id"), req.Role); err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) || errors.Is(err, usecase.ErrRoleNotFound) {
+			log.Printf("[AssignUserRoleHandler] %v", err)
+			c.JSON(http.StatusNotFound, dto.NewError("something went wrong assigning role", err.Error()))
+			return
+		}
+		log.Printf("[AssignUserRoleHandler] Failed to assign role: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong assigning role", "failed to assign role"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "role assigned successfully",
+	})
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// CalendarFeedHandler enables and revokes the calendar link of the logged user
+type CalendarFeedHandler struct {
+	enableCalendarFeedUseCase  usecase.EnableCalendarFeedUseCase
+	disableCalendarFeedUseCase usecase.DisableCalendarFeedUseCase
+}
+
+func NewCalendarFeedHandler(enableCalendarFeedUseCase usecase.EnableCalendarFeedUseCase, disableCalendarFeedUseCase usecase.DisableCalendarFeedUseCase) *CalendarFeedHandler {
+	return &CalendarFeedHandler{
+		enableCalendarFeedUseCase:  enableCalendarFeedUseCase,
+		disableCalendarFeedUseCase: disableCalendarFeedUseCase,
+	}
+}
+
+func (h *CalendarFeedHandler) HandleEnable(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[CalendarFeedHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	output, err := h.enableCalendarFeedUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID)
+	if err != nil {
+		log.Printf("[CalendarFeedHandler] Failed to enable calendar feed: %v", err)
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, dto.NewError("something went wrong enabling calendar feed", "usuário não encontrado"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong enabling calendar feed", "failed to enable calendar feed: "+err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, output)
+}
+
+func (h *CalendarFeedHandler) HandleDisable(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[CalendarFeedHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	if err := h.disableCalendarFeedUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID); err != nil {
+		log.Printf("[CalendarFeedHandler] Failed to disable calendar feed: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong disabling calendar feed", "failed to disable calendar feed: "+err.Error()))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
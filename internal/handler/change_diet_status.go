@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// ChangeDietStatusHandler move uma dieta pelo seu ciclo de vida
+type ChangeDietStatusHandler struct {
+	changeDietStatusUseCase usecase.ChangeDietStatusUseCase
+}
+
+func NewChangeDietStatusHandler(changeDietStatusUseCase usecase.ChangeDietStatusUseCase) *ChangeDietStatusHandler {
+	return &ChangeDietStatusHandler{
+		changeDietStatusUseCase: changeDietStatusUseCase,
+	}
+}
+
+func (h *ChangeDietStatusHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ChangeDietStatusHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var req dto.DietStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[ChangeDietStatusHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong changing diet status", "dados inválidos: "+err.Error()))
+		return
+	}
+
+	diet, err := h.changeDietStatusUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), entity.DietStatus(req.Status), ifMatchVersion(c))
+	if err != nil {
+		log.Printf("[ChangeDietStatusHandler] Failed to change diet status: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong changing diet status", message))
+		return
+	}
+
+	setDietETag(c, diet)
+	c.JSON(http.StatusOK, dto.NewDietResponse(diet))
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// CloneDietHandler copies a diet to another patient
+type CloneDietHandler struct {
+	cloneDietUseCase usecase.CloneDietUseCase
+}
+
+func NewCloneDietHandler(cloneDietUseCase usecase.CloneDietUseCase) *CloneDietHandler {
+	return &CloneDietHandler{
+		cloneDietUseCase: cloneDietUseCase,
+	}
+}
+
+func (h *CloneDietHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[CloneDietHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var req dto.DietCopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[CloneDietHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong cloning diet", "dados inválidos: "+err.Error()))
+		return
+	}
+
+	diet, err := h.cloneDietUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), &req)
+	if err != nil {
+		log.Printf("[CloneDietHandler] Failed to clone diet: %v", err)
+		status, message := dietCopyErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong cloning diet", message))
+		return
+	}
+
+	setDietETag(c, diet)
+	c.JSON(http.StatusCreated, dto.NewDietResponse(diet))
+}
+
+// dietCopyErrorStatus maps the errors of creating a diet from a template or
+// another diet to HTTP responses
+func dietCopyErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidDietScaling), errors.Is(err, usecase.ErrAnthropometricsNotFound),
+		errors.Is(err, usecase.ErrFoodNotFound):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	default:
+		return dietErrorStatus(err)
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
@@ -51,6 +52,22 @@ func (h *CreateDietHandler) Handle(c *gin.Context) {
 
 	if err := h.createDietUseCase.Execute(c.Request.Context(), diet); err != nil {
 		log.Printf("[CreateDietHandler] Failed to execute use case: %v", err)
+		if errors.Is(err, usecase.ErrPatientNotLinked) {
+			c.JSON(http.StatusForbidden, dto.NewError("something went wrong creating diet", err.Error()))
+			return
+		}
+		if errors.Is(err, usecase.ErrFoodNotFound) {
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong creating diet", err.Error()))
+			return
+		}
+		if errors.Is(err, usecase.ErrActiveDietConflict) {
+			c.JSON(http.StatusConflict, dto.NewError("something went wrong creating diet", err.Error()))
+			return
+		}
+		if errors.Is(err, usecase.ErrCalendarBusy) {
+			c.JSON(http.StatusServiceUnavailable, dto.NewError("something went wrong creating diet", err.Error()))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong creating diet", "failed to create diet request: "+err.Error()))
 		return
 	}
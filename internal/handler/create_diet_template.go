@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// CreateDietTemplateHandler creates diet templates
+type CreateDietTemplateHandler struct {
+	createDietTemplateUseCase usecase.CreateDietTemplateUseCase
+}
+
+func NewCreateDietTemplateHandler(createDietTemplateUseCase usecase.CreateDietTemplateUseCase) *CreateDietTemplateHandler {
+	return &CreateDietTemplateHandler{
+		createDietTemplateUseCase: createDietTemplateUseCase,
+	}
+}
+
+func (h *CreateDietTemplateHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[CreateDietTemplateHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var req dto.DietTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[CreateDietTemplateHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong binding request data", err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Printf("[CreateDietTemplateHandler] Validation error: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong validating request data", err.Error()))
+		return
+	}
+
+	userID := claimsValue.(*middleware.Claims).UserID
+	template, err := dto.ConvertToDietTemplate(userID, &req)
+	if err != nil {
+		log.Printf("[CreateDietTemplateHandler] Failed to convert to diet template: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong creating diet template", "invalid ingredients: "+err.Error()))
+		return
+	}
+
+	template, err = h.createDietTemplateUseCase.Execute(c.Request.Context(), userID, template)
+	if err != nil {
+		log.Printf("[CreateDietTemplateHandler] Failed to create diet template: %v", err)
+		status, message := dietTemplateErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong creating diet template", message))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewDietTemplateResponse(template))
+}
+
+// dietTemplateErrorStatus maps the errors of the template use cases to HTTP
+// responses, falling back to the errors of the diets created from them
+func dietTemplateErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecase.ErrDietTemplateNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrUnauthorized):
+		return http.StatusForbidden, "only the owner can use this diet template"
+	default:
+		return dietCopyErrorStatus(err)
+	}
+}
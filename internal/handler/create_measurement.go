@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// CreateMeasurementHandler records body measurements
+type CreateMeasurementHandler struct {
+	createMeasurementUseCase usecase.CreateMeasurementUseCase
+}
+
+func NewCreateMeasurementHandler(createMeasurementUseCase usecase.CreateMeasurementUseCase) *CreateMeasurementHandler {
+	return &CreateMeasurementHandler{
+		createMeasurementUseCase: createMeasurementUseCase,
+	}
+}
+
+// Handle records a measurement of a patient linked to the nutritionist
+func (h *CreateMeasurementHandler) Handle(c *gin.Context) {
+	h.handle(c, c.Param("id"))
+}
+
+// HandleMe records a measurement of the logged user
+func (h *CreateMeasurementHandler) HandleMe(c *gin.Context) {
+	h.handle(c, "")
+}
+
+func (h *CreateMeasurementHandler) handle(c *gin.Context, linkID string) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[CreateMeasurementHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var req dto.MeasurementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[CreateMeasurementHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong creating measurement", "dados inválidos: "+err.Error()))
+		return
+	}
+
+	measurement, err := h.createMeasurementUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, linkID, dto.ConvertToMeasurement(&req))
+	if err != nil {
+		log.Printf("[CreateMeasurementHandler] Failed to create measurement: %v", err)
+		status, message := measurementErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong creating measurement", message))
+		return
+	}
+
+	c.JSON(http.StatusCreated, measurement)
+}
+
+// measurementErrorStatus maps measurement errors to HTTP responses
+func measurementErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidMeasurement), errors.Is(err, usecase.ErrInvalidListParams):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, usecase.ErrMeasurementNotFound), errors.Is(err, usecase.ErrPatientLinkNotFound), errors.Is(err, usecase.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrPatientNotLinked):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, usecase.ErrUnauthorized):
+		return http.StatusForbidden, "only the author can change this measurement"
+	default:
+		return http.StatusInternalServerError, "failed to process measurement"
+	}
+}
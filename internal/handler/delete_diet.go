@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// DeleteDietHandler lida com a remoção (soft delete) de dietas
+type DeleteDietHandler struct {
+	deleteDietUseCase usecase.DeleteDietUseCase
+}
+
+func NewDeleteDietHandler(deleteDietUseCase usecase.DeleteDietUseCase) *DeleteDietHandler {
+	return &DeleteDietHandler{
+		deleteDietUseCase: deleteDietUseCase,
+	}
+}
+
+func (h *DeleteDietHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[DeleteDietHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	if err := h.deleteDietUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id")); err != nil {
+		log.Printf("[DeleteDietHandler] Failed to delete diet: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong deleting diet", message))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
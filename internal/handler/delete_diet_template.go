@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// DeleteDietTemplateHandler removes a template of the nutritionist
+type DeleteDietTemplateHandler struct {
+	deleteDietTemplateUseCase usecase.DeleteDietTemplateUseCase
+}
+
+func NewDeleteDietTemplateHandler(deleteDietTemplateUseCase usecase.DeleteDietTemplateUseCase) *DeleteDietTemplateHandler {
+	return &DeleteDietTemplateHandler{
+		deleteDietTemplateUseCase: deleteDietTemplateUseCase,
+	}
+}
+
+func (h *DeleteDietTemplateHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[DeleteDietTemplateHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	if err := h.deleteDietTemplateUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id")); err != nil {
+		log.Printf("[DeleteDietTemplateHandler] Failed to delete diet template: %v", err)
+		status, message := dietTemplateErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong deleting diet template", message))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
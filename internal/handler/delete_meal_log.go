@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// DeleteMealLogHandler lets the patient remove a meal log
+type DeleteMealLogHandler struct {
+	deleteMealLogUseCase usecase.DeleteMealLogUseCase
+}
+
+func NewDeleteMealLogHandler(deleteMealLogUseCase usecase.DeleteMealLogUseCase) *DeleteMealLogHandler {
+	return &DeleteMealLogHandler{
+		deleteMealLogUseCase: deleteMealLogUseCase,
+	}
+}
+
+func (h *DeleteMealLogHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[DeleteMealLogHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	err := h.deleteMealLogUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), c.Param("logId"))
+	if err != nil {
+		log.Printf("[DeleteMealLogHandler] Failed to delete meal log: %v", err)
+		status, message := mealLogErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong deleting meal log", message))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// DeleteMeasurementHandler removes a measurement
+type DeleteMeasurementHandler struct {
+	deleteMeasurementUseCase usecase.DeleteMeasurementUseCase
+}
+
+func NewDeleteMeasurementHandler(deleteMeasurementUseCase usecase.DeleteMeasurementUseCase) *DeleteMeasurementHandler {
+	return &DeleteMeasurementHandler{
+		deleteMeasurementUseCase: deleteMeasurementUseCase,
+	}
+}
+
+// Handle removes a measurement of a patient linked to the nutritionist
+func (h *DeleteMeasurementHandler) Handle(c *gin.Context) {
+	h.handle(c, c.Param("id"))
+}
+
+// HandleMe removes a measurement of the logged user
+func (h *DeleteMeasurementHandler) HandleMe(c *gin.Context) {
+	h.handle(c, "")
+}
+
+func (h *DeleteMeasurementHandler) handle(c *gin.Context, linkID string) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[DeleteMeasurementHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	err := h.deleteMeasurementUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, linkID, c.Param("measurementId"))
+	if err != nil {
+		log.Printf("[DeleteMeasurementHandler] Failed to delete measurement: %v", err)
+		status, message := measurementErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong deleting measurement", message))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
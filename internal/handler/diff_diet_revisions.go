@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// DiffDietRevisionsHandler compara duas revisões de uma dieta
+type DiffDietRevisionsHandler struct {
+	diffDietRevisionsUseCase usecase.DiffDietRevisionsUseCase
+}
+
+func NewDiffDietRevisionsHandler(diffDietRevisionsUseCase usecase.DiffDietRevisionsUseCase) *DiffDietRevisionsHandler {
+	return &DiffDietRevisionsHandler{
+		diffDietRevisionsUseCase: diffDietRevisionsUseCase,
+	}
+}
+
+func (h *DiffDietRevisionsHandler) Handle(c *gin.Context) {
+	from, fromErr := strconv.Atoi(c.Query("from"))
+	to, toErr := strconv.Atoi(c.Query("to"))
+	if fromErr != nil || toErr != nil || from < 1 || to < 1 {
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong comparing diet revisions", "os parâmetros from e to devem ser números de revisão"))
+		return
+	}
+
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[DiffDietRevisionsHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	diff, err := h.diffDietRevisionsUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), from, to)
+	if err != nil {
+		log.Printf("[DiffDietRevisionsHandler] Failed to diff revisions: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong comparing diet revisions", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
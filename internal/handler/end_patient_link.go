@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type EndPatientLinkHandler struct {
+	endPatientLinkUseCase usecase.EndPatientLinkUseCase
+}
+
+func NewEndPatientLinkHandler(endPatientLinkUC usecase.EndPatientLinkUseCase) *EndPatientLinkHandler {
+	return &EndPatientLinkHandler{
+		endPatientLinkUseCase: endPatientLinkUC,
+	}
+}
+
+func (h *EndPatientLinkHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[EndPatientLinkHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	link, err := h.endPatientLinkUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"))
+	if err != nil {
+		log.Printf("[EndPatientLinkHandler] Failed to end patient link: %v", err)
+		status, message := patientLinkErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong ending patient link", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// patientLinkErrorStatus maps patient link errors to HTTP responses
+func patientLinkErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecase.ErrPatientLinkNotFound), errors.Is(err, usecase.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrInvalidLinkTransition):
+		return http.StatusConflict, err.Error()
+	default:
+		return http.StatusInternalServerError, "failed to update patient link"
+	}
+}
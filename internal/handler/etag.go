@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// dietETag builds the ETag of a diet from its version
+func dietETag(diet *entity.Diet) string {
+	return `"` + strconv.FormatInt(diet.Version, 10) + `"`
+}
+
+// setDietETag adds the ETag header for the diet to the response
+func setDietETag(c *gin.Context, diet *entity.Diet) {
+	c.Header("ETag", dietETag(diet))
+}
+
+// ifMatchVersion reads the If-Match header. It returns nil when the header is
+// absent or "*". ETags that are not a version never match, so the update fails
+// with 412 instead of being applied.
+func ifMatchVersion(c *gin.Context) *int64 {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	version, err := strconv.ParseInt(strings.Trim(tag, `"`), 10, 64)
+	if err != nil {
+		version = -1
+	}
+	return &version
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/fhir"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// ExportDietFhirHandler returns the diet as a FHIR NutritionOrder
+type ExportDietFhirHandler struct {
+	exportDietFhirUseCase usecase.ExportDietFhirUseCase
+}
+
+func NewExportDietFhirHandler(exportDietFhirUseCase usecase.ExportDietFhirUseCase) *ExportDietFhirHandler {
+	return &ExportDietFhirHandler{
+		exportDietFhirUseCase: exportDietFhirUseCase,
+	}
+}
+
+func (h *ExportDietFhirHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ExportDietFhirHandler] Missing user claims in context")
+		fhirError(c, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+
+	order, err := h.exportDietFhirUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"))
+	if err != nil {
+		log.Printf("[ExportDietFhirHandler] Failed to export diet: %v", err)
+		status, message := dietErrorStatus(err)
+		fhirError(c, status, message)
+		return
+	}
+
+	fhirJSON(c, http.StatusOK, order)
+}
+
+// fhirJSON writes a FHIR resource with the FHIR media type
+func fhirJSON(c *gin.Context, status int, resource interface{}) {
+	c.Header("Content-Type", fhir.ContentType+"; charset=utf-8")
+	c.JSON(status, resource)
+}
+
+// fhirError reports an error as an OperationOutcome, as FHIR clients expect
+func fhirError(c *gin.Context, status int, message string) {
+	code := fhir.IssueException
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusUnsupportedMediaType, http.StatusRequestEntityTooLarge:
+		code = fhir.IssueInvalid
+	case http.StatusUnauthorized:
+		code = fhir.IssueLogin
+	case http.StatusForbidden:
+		code = fhir.IssueForbidden
+	case http.StatusNotFound:
+		code = fhir.IssueNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		code = fhir.IssueConflict
+	}
+	fhirJSON(c, status, fhir.NewOperationOutcome(fhir.ErrorIssue(code, message, "")))
+}
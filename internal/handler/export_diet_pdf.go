@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+// ExportDietPdfHandler downloads the diet as a PDF to be printed
+type ExportDietPdfHandler struct {
+	exportDietPdfUseCase usecase.ExportDietPdfUseCase
+}
+
+func NewExportDietPdfHandler(exportDietPdfUseCase usecase.ExportDietPdfUseCase) *ExportDietPdfHandler {
+	return &ExportDietPdfHandler{
+		exportDietPdfUseCase: exportDietPdfUseCase,
+	}
+}
+
+func (h *ExportDietPdfHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ExportDietPdfHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	diet, document, err := h.exportDietPdfUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"))
+	if err != nil {
+		log.Printf("[ExportDietPdfHandler] Failed to export diet: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong exporting diet", message))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", exportFileName(diet.DietName, "pdf")))
+	c.Data(http.StatusOK, "application/pdf", document)
+}
+
+// exportFileName turns the diet name into a safe file name, like "dieta-de-verao.pdf"
+func exportFileName(name, extension string) string {
+	var slug []rune
+	for _, r := range utils.NormalizeText(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			slug = append(slug, r)
+		case len(slug) > 0 && slug[len(slug)-1] != '-':
+			slug = append(slug, '-')
+		}
+	}
+
+	file := strings.TrimRight(string(slug), "-")
+	if file == "" {
+		file = "dieta"
+	}
+	return file + "." + extension
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/tabular"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// ExportDietTableHandler downloads the diet as a spreadsheet in the same
+// format accepted by ImportDietHandler
+type ExportDietTableHandler struct {
+	getDietUseCase usecase.GetDietUseCase
+}
+
+func NewExportDietTableHandler(getDietUseCase usecase.GetDietUseCase) *ExportDietTableHandler {
+	return &ExportDietTableHandler{
+		getDietUseCase: getDietUseCase,
+	}
+}
+
+// HandleCSV writes the diet as CSV
+func (h *ExportDietTableHandler) HandleCSV(c *gin.Context) {
+	h.export(c, "csv", "text/csv; charset=utf-8")
+}
+
+// HandleXLSX writes the diet as an Excel workbook
+func (h *ExportDietTableHandler) HandleXLSX(c *gin.Context) {
+	h.export(c, "xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+}
+
+func (h *ExportDietTableHandler) export(c *gin.Context, format, contentType string) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ExportDietTableHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	diet, err := h.getDietUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"))
+	if err != nil {
+		log.Printf("[ExportDietTableHandler] Failed to get diet: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong exporting diet", message))
+		return
+	}
+
+	rows := dto.NewDietTable(diet)
+
+	var content bytes.Buffer
+	if format == "xlsx" {
+		err = tabular.WriteXLSX(&content, "Dieta", rows)
+	} else {
+		err = tabular.WriteCSV(&content, rows)
+	}
+	if err != nil {
+		log.Printf("[ExportDietTableHandler] Failed to write %s: %v", format, err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong exporting diet", "failed to write spreadsheet: "+err.Error()))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", exportFileName(diet.DietName, format)))
+	c.Data(http.StatusOK, contentType, content.Bytes())
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type ForgotPasswordHandler struct {
+	requestPasswordResetUseCase usecase.RequestPasswordResetUseCase
+}
+
+func NewForgotPasswordHandler(requestPasswordResetUC usecase.RequestPasswordResetUseCase) *ForgotPasswordHandler {
+	return &ForgotPasswordHandler{
+		requestPasswordResetUseCase: requestPasswordResetUC,
+	}
+}
+
+// Handle always answers with the same message so it does not reveal whether
+// the email belongs to an account.
+func (h *ForgotPasswordHandler) Handle(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[ForgotPasswordHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong binding request data", err.Error()))
+		return
+	}
+
+	if err := h.requestPasswordResetUseCase.Execute(c.Request.Context(), req.Email); err != nil {
+		log.Printf("[ForgotPasswordHandler] Failed to request password reset: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong requesting password reset", "failed to send password reset email"))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.MessageResponse{
+		Message: "if the email is registered, a password reset link has been sent",
+	})
+}
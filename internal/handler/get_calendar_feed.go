@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// GetCalendarFeedHandler serves the iCalendar feed to calendar apps. It is
+// public: the secret token in the URL identifies the user.
+type GetCalendarFeedHandler struct {
+	getCalendarFeedUseCase usecase.GetCalendarFeedUseCase
+}
+
+func NewGetCalendarFeedHandler(getCalendarFeedUseCase usecase.GetCalendarFeedUseCase) *GetCalendarFeedHandler {
+	return &GetCalendarFeedHandler{
+		getCalendarFeedUseCase: getCalendarFeedUseCase,
+	}
+}
+
+func (h *GetCalendarFeedHandler) Handle(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	calendar, err := h.getCalendarFeedUseCase.Execute(c.Request.Context(), token)
+	if err != nil {
+		if errors.Is(err, usecase.ErrCalendarFeedNotFound) {
+			c.JSON(http.StatusNotFound, dto.NewError("something went wrong getting calendar feed", "calendário não encontrado"))
+			return
+		}
+		log.Printf("[GetCalendarFeedHandler] Failed to get calendar feed: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong getting calendar feed", "failed to get calendar feed: "+err.Error()))
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// GetDietHandler lida com a leitura de uma única dieta
+type GetDietHandler struct {
+	getDietUseCase usecase.GetDietUseCase
+}
+
+func NewGetDietHandler(getDietUseCase usecase.GetDietUseCase) *GetDietHandler {
+	return &GetDietHandler{
+		getDietUseCase: getDietUseCase,
+	}
+}
+
+func (h *GetDietHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[GetDietHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	diet, err := h.getDietUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"))
+	if err != nil {
+		log.Printf("[GetDietHandler] Failed to get diet: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong getting diet", message))
+		return
+	}
+
+	etag := dietETag(diet)
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDietResponse(diet))
+}
+
+// dietErrorStatus maps the errors of the single-diet use cases to HTTP responses
+func dietErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecase.ErrDietNotFound), errors.Is(err, usecase.ErrRevisionNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrUnauthorized):
+		return http.StatusForbidden, "you do not have permission to access this diet"
+	case errors.Is(err, usecase.ErrPatientNotLinked):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, usecase.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed, err.Error()
+	case errors.Is(err, usecase.ErrDietNotDeleted), errors.Is(err, usecase.ErrVersionConflict),
+		errors.Is(err, usecase.ErrInvalidDietTransition), errors.Is(err, usecase.ErrDietArchived),
+		errors.Is(err, usecase.ErrActiveDietConflict):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, usecase.ErrInvalidListParams):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, usecase.ErrCalendarBusy):
+		return http.StatusServiceUnavailable, err.Error()
+	default:
+		return http.StatusInternalServerError, "failed to process diet: " + err.Error()
+	}
+}
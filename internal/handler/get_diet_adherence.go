@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// GetDietAdherenceHandler returns the adherence statistics of a diet
+type GetDietAdherenceHandler struct {
+	getDietAdherenceUseCase usecase.GetDietAdherenceUseCase
+}
+
+func NewGetDietAdherenceHandler(getDietAdherenceUseCase usecase.GetDietAdherenceUseCase) *GetDietAdherenceHandler {
+	return &GetDietAdherenceHandler{
+		getDietAdherenceUseCase: getDietAdherenceUseCase,
+	}
+}
+
+func (h *GetDietAdherenceHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[GetDietAdherenceHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	adherence, err := h.getDietAdherenceUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		log.Printf("[GetDietAdherenceHandler] Failed to compute adherence: %v", err)
+		status, message := mealLogErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong getting diet adherence", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, adherence)
+}
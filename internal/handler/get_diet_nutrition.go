@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// GetDietNutritionHandler retorna o detalhamento nutricional de uma dieta
+type GetDietNutritionHandler struct {
+	getDietNutritionUseCase usecase.GetDietNutritionUseCase
+}
+
+func NewGetDietNutritionHandler(getDietNutritionUseCase usecase.GetDietNutritionUseCase) *GetDietNutritionHandler {
+	return &GetDietNutritionHandler{
+		getDietNutritionUseCase: getDietNutritionUseCase,
+	}
+}
+
+func (h *GetDietNutritionHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[GetDietNutritionHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	nutrition, err := h.getDietNutritionUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"))
+	if err != nil {
+		log.Printf("[GetDietNutritionHandler] Failed to compute nutrition: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong computing diet nutrition", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, nutrition)
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// GetDietRevisionHandler lida com a leitura de uma revisão de dieta
+type GetDietRevisionHandler struct {
+	getDietRevisionUseCase usecase.GetDietRevisionUseCase
+}
+
+func NewGetDietRevisionHandler(getDietRevisionUseCase usecase.GetDietRevisionUseCase) *GetDietRevisionHandler {
+	return &GetDietRevisionHandler{
+		getDietRevisionUseCase: getDietRevisionUseCase,
+	}
+}
+
+func (h *GetDietRevisionHandler) Handle(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong getting diet revision", "número da revisão inválido"))
+		return
+	}
+
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[GetDietRevisionHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	revision, err := h.getDietRevisionUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), number)
+	if err != nil {
+		log.Printf("[GetDietRevisionHandler] Failed to get revision: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong getting diet revision", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// GetDietScheduleHandler returns the meals of a diet date by date
+type GetDietScheduleHandler struct {
+	getDietScheduleUseCase usecase.GetDietScheduleUseCase
+}
+
+func NewGetDietScheduleHandler(getDietScheduleUseCase usecase.GetDietScheduleUseCase) *GetDietScheduleHandler {
+	return &GetDietScheduleHandler{
+		getDietScheduleUseCase: getDietScheduleUseCase,
+	}
+}
+
+func (h *GetDietScheduleHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[GetDietScheduleHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	schedule, err := h.getDietScheduleUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		log.Printf("[GetDietScheduleHandler] Failed to expand schedule: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong getting diet schedule", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// GetDietTemplateHandler returns a template of the nutritionist
+type GetDietTemplateHandler struct {
+	getDietTemplateUseCase usecase.GetDietTemplateUseCase
+}
+
+func NewGetDietTemplateHandler(getDietTemplateUseCase usecase.GetDietTemplateUseCase) *GetDietTemplateHandler {
+	return &GetDietTemplateHandler{
+		getDietTemplateUseCase: getDietTemplateUseCase,
+	}
+}
+
+func (h *GetDietTemplateHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[GetDietTemplateHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	template, err := h.getDietTemplateUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"))
+	if err != nil {
+		log.Printf("[GetDietTemplateHandler] Failed to get diet template: %v", err)
+		status, message := dietTemplateErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong getting diet template", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDietTemplateResponse(template))
+}
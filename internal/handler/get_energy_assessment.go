@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// GetEnergyAssessmentHandler returns the energy expenditure and targets of a patient
+type GetEnergyAssessmentHandler struct {
+	getEnergyAssessmentUseCase usecase.GetEnergyAssessmentUseCase
+}
+
+func NewGetEnergyAssessmentHandler(getEnergyAssessmentUseCase usecase.GetEnergyAssessmentUseCase) *GetEnergyAssessmentHandler {
+	return &GetEnergyAssessmentHandler{
+		getEnergyAssessmentUseCase: getEnergyAssessmentUseCase,
+	}
+}
+
+// Handle returns the assessment of a patient linked to the nutritionist
+func (h *GetEnergyAssessmentHandler) Handle(c *gin.Context) {
+	h.handle(c, c.Param("id"))
+}
+
+// HandleMe returns the assessment of the logged user
+func (h *GetEnergyAssessmentHandler) HandleMe(c *gin.Context) {
+	h.handle(c, "")
+}
+
+func (h *GetEnergyAssessmentHandler) handle(c *gin.Context, linkID string) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[GetEnergyAssessmentHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	equation := entity.EnergyEquation(strings.ToUpper(c.Query("equation")))
+
+	assessment, err := h.getEnergyAssessmentUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, linkID, equation)
+	if err != nil {
+		log.Printf("[GetEnergyAssessmentHandler] Failed to assess energy: %v", err)
+		status, message := energyErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong getting energy assessment", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, assessment)
+}
+
+// energyErrorStatus maps anthropometrics and energy errors to HTTP responses
+func energyErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidAnthropometrics):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, usecase.ErrAnthropometricsNotFound), errors.Is(err, usecase.ErrPatientLinkNotFound), errors.Is(err, usecase.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrPatientNotLinked):
+		return http.StatusForbidden, err.Error()
+	default:
+		return http.StatusInternalServerError, "failed to assess energy expenditure"
+	}
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// GetExportTemplateHandler returns the export template of the logged nutritionist
+type GetExportTemplateHandler struct {
+	getExportTemplateUseCase usecase.GetExportTemplateUseCase
+}
+
+func NewGetExportTemplateHandler(getExportTemplateUseCase usecase.GetExportTemplateUseCase) *GetExportTemplateHandler {
+	return &GetExportTemplateHandler{
+		getExportTemplateUseCase: getExportTemplateUseCase,
+	}
+}
+
+func (h *GetExportTemplateHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[GetExportTemplateHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	template, err := h.getExportTemplateUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID)
+	if err != nil {
+		log.Printf("[GetExportTemplateHandler] Failed to get export template: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong getting export template", "failed to get export template: "+err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewExportTemplateResponse(template))
+}
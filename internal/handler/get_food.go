@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// GetFoodHandler returns a single food of the catalog
+type GetFoodHandler struct {
+	getFoodUseCase usecase.GetFoodUseCase
+}
+
+func NewGetFoodHandler(getFoodUseCase usecase.GetFoodUseCase) *GetFoodHandler {
+	return &GetFoodHandler{
+		getFoodUseCase: getFoodUseCase,
+	}
+}
+
+func (h *GetFoodHandler) Handle(c *gin.Context) {
+	food, err := h.getFoodUseCase.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, usecase.ErrFoodNotFound) {
+			c.JSON(http.StatusNotFound, dto.NewError("something went wrong getting food", err.Error()))
+			return
+		}
+		log.Printf("[GetFoodHandler] Failed to get food: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong getting food", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, food)
+}
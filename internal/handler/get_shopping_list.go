@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/shopping"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// GetShoppingListHandler returns the shopping list of a diet as JSON, plain
+// text or Markdown
+type GetShoppingListHandler struct {
+	getShoppingListUseCase usecase.GetShoppingListUseCase
+}
+
+func NewGetShoppingListHandler(getShoppingListUseCase usecase.GetShoppingListUseCase) *GetShoppingListHandler {
+	return &GetShoppingListHandler{
+		getShoppingListUseCase: getShoppingListUseCase,
+	}
+}
+
+func (h *GetShoppingListHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[GetShoppingListHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	days := 0
+	if value := c.Query("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong getting shopping list", "days inválido: "+value))
+			return
+		}
+		days = parsed
+	}
+
+	withPackages := false
+	if value := c.Query("packages"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong getting shopping list", "packages inválido: "+value))
+			return
+		}
+		withPackages = parsed
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "text" && format != "markdown" {
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong getting shopping list", "format deve ser json, text ou markdown"))
+		return
+	}
+
+	list, err := h.getShoppingListUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), c.Query("from"), days, withPackages)
+	if err != nil {
+		log.Printf("[GetShoppingListHandler] Failed to build shopping list: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong getting shopping list", message))
+		return
+	}
+
+	switch format {
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(shopping.Text(list)))
+	case "markdown":
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(shopping.Markdown(list)))
+	default:
+		c.JSON(http.StatusOK, list)
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/tabular"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// maxImportSize limits the spreadsheet uploaded to import a diet
+const maxImportSize = 5 << 20
+
+// ImportDietHandler creates a diet from a CSV or XLSX spreadsheet
+type ImportDietHandler struct {
+	createDietUseCase usecase.CreateDiet
+}
+
+func NewImportDietHandler(createDietUseCase usecase.CreateDiet) *ImportDietHandler {
+	return &ImportDietHandler{
+		createDietUseCase: createDietUseCase,
+	}
+}
+
+func (h *ImportDietHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ImportDietHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong importing diet", "envie a planilha no campo file (multipart/form-data)"))
+		return
+	}
+
+	if header.Size > maxImportSize {
+		c.JSON(http.StatusRequestEntityTooLarge, dto.NewError("something went wrong importing diet", "a planilha deve ter no máximo 5 MB"))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		log.Printf("[ImportDietHandler] Failed to open upload: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong importing diet", "failed to read file: "+err.Error()))
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxImportSize))
+	if err != nil {
+		log.Printf("[ImportDietHandler] Failed to read upload: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong importing diet", "failed to read file: "+err.Error()))
+		return
+	}
+
+	var rows [][]string
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".csv":
+		rows, err = tabular.ReadCSV(bytes.NewReader(content))
+	case ".xlsx":
+		rows, err = tabular.ReadXLSX(bytes.NewReader(content), int64(len(content)))
+	default:
+		c.JSON(http.StatusUnsupportedMediaType, dto.NewError("something went wrong importing diet", "formato não suportado, envie um arquivo .csv ou .xlsx"))
+		return
+	}
+	if err != nil {
+		log.Printf("[ImportDietHandler] Failed to parse spreadsheet: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong importing diet", "planilha inválida: "+err.Error()))
+		return
+	}
+
+	req, rowErrors := dto.ParseDietTable(rows, c.PostForm("user_email"))
+	if len(rowErrors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, dto.DietImportErrorResponse{
+			Field:   "something went wrong validating spreadsheet",
+			Message: "a planilha tem erros, nenhuma dieta foi criada",
+			Errors:  rowErrors,
+		})
+		return
+	}
+
+	diet, err := dto.ConvertToDiet(claimsValue.(*middleware.Claims).UserID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong importing diet", "invalid ingredients: "+err.Error()))
+		return
+	}
+
+	if err := h.createDietUseCase.Execute(c.Request.Context(), diet); err != nil {
+		log.Printf("[ImportDietHandler] Failed to create diet: %v", err)
+		status, message := dietCopyErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong importing diet", message))
+		return
+	}
+
+	setDietETag(c, diet)
+	c.JSON(http.StatusCreated, dto.NewDietResponse(diet))
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/fhir"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// ImportDietFhirHandler creates a diet from a FHIR NutritionOrder, sent alone
+// or in a Bundle with its Patient. Errors are reported as OperationOutcome.
+type ImportDietFhirHandler struct {
+	createDietUseCase usecase.CreateDiet
+}
+
+func NewImportDietFhirHandler(createDietUseCase usecase.CreateDiet) *ImportDietFhirHandler {
+	return &ImportDietFhirHandler{
+		createDietUseCase: createDietUseCase,
+	}
+}
+
+func (h *ImportDietFhirHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ImportDietFhirHandler] Missing user claims in context")
+		fhirError(c, http.StatusUnauthorized, "usuário não autenticado")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxImportSize+1))
+	if err != nil {
+		log.Printf("[ImportDietFhirHandler] Failed to read body: %v", err)
+		fhirError(c, http.StatusBadRequest, "failed to read body: "+err.Error())
+		return
+	}
+
+	if len(body) > maxImportSize {
+		fhirError(c, http.StatusRequestEntityTooLarge, "o recurso deve ter no máximo 5 MB")
+		return
+	}
+
+	order, patientEmail, issues := fhir.ReadNutritionOrder(body)
+	if len(issues) > 0 {
+		fhirJSON(c, http.StatusBadRequest, fhir.NewOperationOutcome(issues...))
+		return
+	}
+
+	// O email do paciente pode ser informado quando o recurso não o traz
+	if patientEmail == "" {
+		patientEmail = c.Query("user_email")
+	}
+
+	req, issues := dto.ParseNutritionOrder(order, patientEmail)
+	if len(issues) > 0 {
+		fhirJSON(c, http.StatusUnprocessableEntity, fhir.NewOperationOutcome(issues...))
+		return
+	}
+
+	diet, err := dto.ConvertToDiet(claimsValue.(*middleware.Claims).UserID, req)
+	if err != nil {
+		fhirError(c, http.StatusBadRequest, "invalid ingredients: "+err.Error())
+		return
+	}
+
+	if err := h.createDietUseCase.Execute(c.Request.Context(), diet); err != nil {
+		log.Printf("[ImportDietFhirHandler] Failed to create diet: %v", err)
+		status, message := dietCopyErrorStatus(err)
+		fhirError(c, status, message)
+		return
+	}
+
+	setDietETag(c, diet)
+	c.JSON(http.StatusCreated, dto.NewDietResponse(diet))
+}
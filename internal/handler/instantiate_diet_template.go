@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// InstantiateDietTemplateHandler creates a diet for a patient from a template
+type InstantiateDietTemplateHandler struct {
+	instantiateDietTemplateUseCase usecase.InstantiateDietTemplateUseCase
+}
+
+func NewInstantiateDietTemplateHandler(instantiateDietTemplateUseCase usecase.InstantiateDietTemplateUseCase) *InstantiateDietTemplateHandler {
+	return &InstantiateDietTemplateHandler{
+		instantiateDietTemplateUseCase: instantiateDietTemplateUseCase,
+	}
+}
+
+func (h *InstantiateDietTemplateHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[InstantiateDietTemplateHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var req dto.DietCopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[InstantiateDietTemplateHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong instantiating diet template", "dados inválidos: "+err.Error()))
+		return
+	}
+
+	diet, err := h.instantiateDietTemplateUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), &req)
+	if err != nil {
+		log.Printf("[InstantiateDietTemplateHandler] Failed to instantiate diet template: %v", err)
+		status, message := dietTemplateErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong instantiating diet template", message))
+		return
+	}
+
+	setDietETag(c, diet)
+	c.JSON(http.StatusCreated, dto.NewDietResponse(diet))
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type InvitePatientHandler struct {
+	invitePatientUseCase usecase.InvitePatientUseCase
+}
+
+func NewInvitePatientHandler(invitePatientUC usecase.InvitePatientUseCase) *InvitePatientHandler {
+	return &InvitePatientHandler{
+		invitePatientUseCase: invitePatientUC,
+	}
+}
+
+func (h *InvitePatientHandler) Handle(c *gin.Context) {
+	var req dto.InvitePatientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[InvitePatientHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong binding request data", err.Error()))
+		return
+	}
+
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[InvitePatientHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	link, err := h.invitePatientUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, req.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrPatientLinkExists):
+			c.JSON(http.StatusConflict, dto.NewError("something went wrong inviting patient", err.Error()))
+		case errors.Is(err, usecase.ErrSelfInvitation):
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong inviting patient", err.Error()))
+		default:
+			log.Printf("[InvitePatientHandler] Failed to invite patient: %v", err)
+			c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong inviting patient", "failed to invite patient"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/auth"
+)
+
+// JWKSHandler publishes the public keys other services use to verify our tokens
+type JWKSHandler struct {
+	keySet *auth.KeySet
+}
+
+func NewJWKSHandler(keySet *auth.KeySet) *JWKSHandler {
+	return &JWKSHandler{
+		keySet: keySet,
+	}
+}
+
+func (h *JWKSHandler) Handle(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keySet.JWKS())
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// ListDietRevisionsHandler lida com a listagem do histórico de uma dieta
+type ListDietRevisionsHandler struct {
+	listDietRevisionsUseCase usecase.ListDietRevisionsUseCase
+}
+
+func NewListDietRevisionsHandler(listDietRevisionsUseCase usecase.ListDietRevisionsUseCase) *ListDietRevisionsHandler {
+	return &ListDietRevisionsHandler{
+		listDietRevisionsUseCase: listDietRevisionsUseCase,
+	}
+}
+
+func (h *ListDietRevisionsHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ListDietRevisionsHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	revisions, err := h.listDietRevisionsUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"))
+	if err != nil {
+		log.Printf("[ListDietRevisionsHandler] Failed to list revisions: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong listing diet revisions", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// ListDietTemplatesHandler lists the templates of the nutritionist
+type ListDietTemplatesHandler struct {
+	listDietTemplatesUseCase usecase.ListDietTemplatesUseCase
+}
+
+func NewListDietTemplatesHandler(listDietTemplatesUseCase usecase.ListDietTemplatesUseCase) *ListDietTemplatesHandler {
+	return &ListDietTemplatesHandler{
+		listDietTemplatesUseCase: listDietTemplatesUseCase,
+	}
+}
+
+func (h *ListDietTemplatesHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ListDietTemplatesHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	templates, err := h.listDietTemplatesUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID)
+	if err != nil {
+		log.Printf("[ListDietTemplatesHandler] Failed to list diet templates: %v", err)
+		status, message := dietTemplateErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong listing diet templates", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDietTemplatesResponse(templates))
+}
@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/victorgiudicissi/your-diet/internal/dto"
@@ -29,11 +33,13 @@ func (h *ListDietsHandler) Handle(c *gin.Context) {
 	if !exists {
 		log.Printf("[ListDietsHandler] Missing user claims in context")
 		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
 	}
 
 	// Obter os parâmetros da query string
 	userEmail := c.Query("userEmail")
 	createdBySearch := c.Query("createdBySearch") == "true"
+	includeDeleted := c.Query("includeDeleted") == "true"
 	userID := claimsValue.(*middleware.Claims).UserID
 
 	// Criar o input para o caso de uso
@@ -41,6 +47,18 @@ func (h *ListDietsHandler) Handle(c *gin.Context) {
 		UserEmail:       userEmail,
 		CreatedBySearch: createdBySearch,
 		UserID:          userID,
+		IncludeDeleted:  includeDeleted,
+		Status:          c.Query("status"),
+		Name:            c.Query("name"),
+		SortBy:          c.Query("sortBy"),
+		Order:           c.Query("order"),
+		Cursor:          c.Query("cursor"),
+	}
+
+	if err := bindListDietsQuery(c, input); err != nil {
+		log.Printf("[ListDietsHandler] Invalid query parameters: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong listing diets", err.Error()))
+		return
 	}
 
 	// Executar o caso de uso
@@ -48,9 +66,71 @@ func (h *ListDietsHandler) Handle(c *gin.Context) {
 
 	if err != nil {
 		log.Printf("[ListDietsHandler] Failed to list diets: %v", err)
+		if errors.Is(err, usecase.ErrPatientNotLinked) {
+			c.JSON(http.StatusForbidden, dto.NewError("something went wrong listing diets", err.Error()))
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidCursor) || errors.Is(err, usecase.ErrInvalidListParams) {
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong listing diets", err.Error()))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong listing diets", err.Error()))
 		return
 	}
 
-	c.JSON(http.StatusOK, output.Diets)
+	c.JSON(http.StatusOK, dto.NewListDietsResponse(output))
+}
+
+// bindListDietsQuery lê o limite e os intervalos de datas da query string.
+// Datas aceitam RFC3339 ou AAAA-MM-DD; no fim do intervalo, AAAA-MM-DD inclui o dia inteiro.
+func bindListDietsQuery(c *gin.Context, input *dto.ListDietsInput) error {
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("limit inválido: %s", value)
+		}
+		input.Limit = limit
+	}
+
+	dates := []struct {
+		param  string
+		target **time.Time
+		end    bool
+	}{
+		{"createdFrom", &input.CreatedFrom, false},
+		{"createdTo", &input.CreatedTo, true},
+		{"updatedFrom", &input.UpdatedFrom, false},
+		{"updatedTo", &input.UpdatedTo, true},
+	}
+
+	for _, date := range dates {
+		value := c.Query(date.param)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := parseQueryDate(value, date.end)
+		if err != nil {
+			return fmt.Errorf("%s inválido: %s", date.param, value)
+		}
+		*date.target = &parsed
+	}
+
+	return nil
+}
+
+func parseQueryDate(value string, endOfDay bool) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if endOfDay {
+		parsed = parsed.Add(24*time.Hour - time.Millisecond)
+	}
+	return parsed, nil
 }
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// ListMealLogsHandler lists the meal logs of a diet
+type ListMealLogsHandler struct {
+	listMealLogsUseCase usecase.ListMealLogsUseCase
+}
+
+func NewListMealLogsHandler(listMealLogsUseCase usecase.ListMealLogsUseCase) *ListMealLogsHandler {
+	return &ListMealLogsHandler{
+		listMealLogsUseCase: listMealLogsUseCase,
+	}
+}
+
+func (h *ListMealLogsHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ListMealLogsHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	logs, err := h.listMealLogsUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		log.Printf("[ListMealLogsHandler] Failed to list meal logs: %v", err)
+		status, message := mealLogErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong listing meal logs", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
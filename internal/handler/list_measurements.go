@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// ListMeasurementsHandler returns the measurement series of a patient
+type ListMeasurementsHandler struct {
+	listMeasurementsUseCase usecase.ListMeasurementsUseCase
+}
+
+func NewListMeasurementsHandler(listMeasurementsUseCase usecase.ListMeasurementsUseCase) *ListMeasurementsHandler {
+	return &ListMeasurementsHandler{
+		listMeasurementsUseCase: listMeasurementsUseCase,
+	}
+}
+
+// Handle lists the measurements of a patient linked to the nutritionist
+func (h *ListMeasurementsHandler) Handle(c *gin.Context) {
+	h.handle(c, c.Param("id"))
+}
+
+// HandleMe lists the measurements of the logged user
+func (h *ListMeasurementsHandler) HandleMe(c *gin.Context) {
+	h.handle(c, "")
+}
+
+func (h *ListMeasurementsHandler) handle(c *gin.Context, linkID string) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ListMeasurementsHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var from, to *time.Time
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+		end    bool
+	}{{"from", &from, false}, {"to", &to, true}} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := parseQueryDate(value, param.end)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong listing measurements", param.name+" inválido: "+value))
+			return
+		}
+		*param.target = &parsed
+	}
+
+	series, err := h.listMeasurementsUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, linkID, from, to)
+	if err != nil {
+		log.Printf("[ListMeasurementsHandler] Failed to list measurements: %v", err)
+		status, message := measurementErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong listing measurements", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type ListNutritionistRequestsHandler struct {
+	listNutritionistRequestsUseCase usecase.ListNutritionistRequestsUseCase
+}
+
+func NewListNutritionistRequestsHandler(listNutritionistRequestsUC usecase.ListNutritionistRequestsUseCase) *ListNutritionistRequestsHandler {
+	return &ListNutritionistRequestsHandler{
+		listNutritionistRequestsUseCase: listNutritionistRequestsUC,
+	}
+}
+
+// Handle lists nutritionist accounts by status (PENDING by default)
+func (h *ListNutritionistRequestsHandler) Handle(c *gin.Context) {
+	status := entity.NutritionistStatus(strings.ToUpper(c.Query("status")))
+
+	users, err := h.listNutritionistRequestsUseCase.Execute(c.Request.Context(), status)
+	if err != nil {
+		log.Printf("[ListNutritionistRequestsHandler] Failed to list nutritionist requests: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong listing nutritionist requests", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewUserResponses(users))
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// ListNutritionistsHandler lists the nutritionists (links) of the authenticated patient
+type ListNutritionistsHandler struct {
+	listNutritionistsUseCase usecase.ListNutritionistsUseCase
+}
+
+func NewListNutritionistsHandler(listNutritionistsUC usecase.ListNutritionistsUseCase) *ListNutritionistsHandler {
+	return &ListNutritionistsHandler{
+		listNutritionistsUseCase: listNutritionistsUC,
+	}
+}
+
+func (h *ListNutritionistsHandler) Handle(c *gin.Context) {
+	h.list(c, linkStatusQuery(c))
+}
+
+// HandleInvitations lists only the pending invitations
+func (h *ListNutritionistsHandler) HandleInvitations(c *gin.Context) {
+	status := entity.PatientLinkPending
+	h.list(c, &status)
+}
+
+func (h *ListNutritionistsHandler) list(c *gin.Context, status *entity.PatientLinkStatus) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ListNutritionistsHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	links, err := h.listNutritionistsUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, status)
+	if err != nil {
+		log.Printf("[ListNutritionistsHandler] Failed to list nutritionists: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong listing nutritionists", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
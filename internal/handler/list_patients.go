@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// ListPatientsHandler lists the patients (links) of the authenticated nutritionist
+type ListPatientsHandler struct {
+	listPatientsUseCase usecase.ListPatientsUseCase
+}
+
+func NewListPatientsHandler(listPatientsUC usecase.ListPatientsUseCase) *ListPatientsHandler {
+	return &ListPatientsHandler{
+		listPatientsUseCase: listPatientsUC,
+	}
+}
+
+func (h *ListPatientsHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ListPatientsHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	links, err := h.listPatientsUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, linkStatusQuery(c))
+	if err != nil {
+		log.Printf("[ListPatientsHandler] Failed to list patients: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong listing patients", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// linkStatusQuery reads the optional ?status= filter for patient links
+func linkStatusQuery(c *gin.Context) *entity.PatientLinkStatus {
+	value := strings.ToUpper(c.Query("status"))
+	if value == "" {
+		return nil
+	}
+	status := entity.PatientLinkStatus(value)
+	return &status
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type ListRolesHandler struct {
+	listRolesUseCase usecase.ListRolesUseCase
+}
+
+func NewListRolesHandler(listRolesUC usecase.ListRolesUseCase) *ListRolesHandler {
+	return &ListRolesHandler{
+		listRolesUseCase: listRolesUC,
+	}
+}
+
+func (h *ListRolesHandler) Handle(c *gin.Context) {
+	roles, err := h.listRolesUseCase.Execute(c.Request.Context())
+	if err != nil {
+		log.Printf("[ListRolesHandler] Failed to list roles: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong listing roles", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/units"
+)
+
+// ListUnitsHandler lists the units accepted on ingredients
+type ListUnitsHandler struct{}
+
+func NewListUnitsHandler() *ListUnitsHandler {
+	return &ListUnitsHandler{}
+}
+
+func (h *ListUnitsHandler) Handle(c *gin.Context) {
+	c.JSON(http.StatusOK, units.All())
+}
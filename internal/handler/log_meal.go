@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// LogMealHandler lets the patient log a meal of the diet
+type LogMealHandler struct {
+	logMealUseCase usecase.LogMealUseCase
+}
+
+func NewLogMealHandler(logMealUseCase usecase.LogMealUseCase) *LogMealHandler {
+	return &LogMealHandler{
+		logMealUseCase: logMealUseCase,
+	}
+}
+
+func (h *LogMealHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[LogMealHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var req dto.MealLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[LogMealHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong logging meal", "dados inválidos: "+err.Error()))
+		return
+	}
+
+	mealLog, err := h.logMealUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), dto.ConvertToMealLog(&req))
+	if err != nil {
+		log.Printf("[LogMealHandler] Failed to log meal: %v", err)
+		status, message := mealLogErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong logging meal", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, mealLog)
+}
+
+// mealLogErrorStatus maps meal log and adherence errors to HTTP responses
+func mealLogErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidMealLog):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, usecase.ErrMealLogNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, usecase.ErrDietNotActive):
+		return http.StatusConflict, err.Error()
+	default:
+		return dietErrorStatus(err)
+	}
+}
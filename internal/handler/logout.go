@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type LogoutHandler struct {
+	logoutUseCase usecase.LogoutUseCase
+}
+
+func NewLogoutHandler(logoutUC usecase.LogoutUseCase) *LogoutHandler {
+	return &LogoutHandler{
+		logoutUseCase: logoutUC,
+	}
+}
+
+func (h *LogoutHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[LogoutHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	if err := h.logoutUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).SessionID); err != nil {
+		log.Printf("[LogoutHandler] Failed to revoke session: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("error", "logout failed"))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
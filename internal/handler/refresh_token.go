@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type RefreshTokenHandler struct {
+	refreshTokenUseCase usecase.RefreshTokenUseCase
+}
+
+func NewRefreshTokenHandler(refreshTokenUC usecase.RefreshTokenUseCase) *RefreshTokenHandler {
+	return &RefreshTokenHandler{
+		refreshTokenUseCase: refreshTokenUC,
+	}
+}
+
+func (h *RefreshTokenHandler) Handle(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[RefreshTokenHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("error", err.Error()))
+		return
+	}
+
+	result, err := h.refreshTokenUseCase.Execute(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidRefreshToken) || errors.Is(err, usecase.ErrRefreshTokenReused) {
+			log.Printf("[RefreshTokenHandler] Refresh rejected: %v", err)
+			c.JSON(http.StatusUnauthorized, dto.NewError("error", err.Error()))
+			return
+		}
+
+		log.Printf("[RefreshTokenHandler] Internal error: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("error", "token refresh failed"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LoginResponse{
+		Token:            result.Token,
+		ExpiresAt:        result.ExpiresAt,
+		RefreshToken:     result.RefreshToken,
+		RefreshExpiresAt: result.RefreshExpiresAt,
+	})
+}
@@ -5,9 +5,11 @@ import (
 	"log"
 	"net/http"
 	"regexp"
+	"strings"
 	"unicode"
 
 	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/constants"
 	"github.com/victorgiudicissi/your-diet/internal/dto"
 	"github.com/victorgiudicissi/your-diet/internal/entity"
 	"github.com/victorgiudicissi/your-diet/internal/usecase"
@@ -21,6 +23,8 @@ var (
 	ErrInvalidEmailFormat     = errors.New("invalid email format")
 	ErrPasswordTooShort       = errors.New("password must be at least 8 characters long")
 	ErrPasswordMissingSpecial = errors.New("password must contain at least one special character")
+
+	ErrMissingProfessionalRegistration = errors.New("professional_registration is required for nutritionist accounts")
 )
 
 // RegisterUserHandler handles HTTP requests related to users.
@@ -50,6 +54,12 @@ func (h *RegisterUserHandler) Handle(c *gin.Context) {
 		return
 	}
 
+	if req.IsNutritionist && strings.TrimSpace(req.ProfessionalRegistration) == "" {
+		log.Printf("[RegisterUserHandler] Missing professional registration for nutritionist: %s", req.Email)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong validating request data", ErrMissingProfessionalRegistration.Error()))
+		return
+	}
+
 	passworValidationErrs := validatePassword(req.Password)
 	if len(passworValidationErrs) > 0 {
 		log.Printf("[RegisterUserHandler] Password validation error: %s", passworValidationErrs[0])
@@ -65,20 +75,21 @@ func (h *RegisterUserHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	userType := "DEFAULT"
-
-	if req.IsNutritionist {
-		userType = "NUTRITIONIST"
-	}
-
+	// Nutritionist accounts start as regular users until an admin approves
+	// the professional registration number.
 	user := &entity.User{
 		Email:    req.Email,
 		Password: string(hashedPassword),
-		Type:     userType,
+		Type:     constants.TokenTypeDefault,
 		Age:      req.Age,
 		Gender:   req.Gender,
 	}
 
+	if req.IsNutritionist {
+		user.NutritionistStatus = entity.NutritionistPending
+		user.ProfessionalRegistration = strings.TrimSpace(req.ProfessionalRegistration)
+	}
+
 	err = h.createUserUseCase.Execute(c.Request.Context(), user)
 	if err != nil {
 		if errors.Is(err, usecase.ErrEmailAlreadyExists) {
@@ -91,8 +102,13 @@ func (h *RegisterUserHandler) Handle(c *gin.Context) {
 		return
 	}
 
+	message := "user registered successfully"
+	if req.IsNutritionist {
+		message = "user registered successfully, nutritionist account pending approval"
+	}
+
 	c.JSON(http.StatusCreated, dto.RegisterUserResponse{
-		Message: "user registered successfully",
+		Message: message,
 	})
 }
 
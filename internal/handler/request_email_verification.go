@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type RequestEmailVerificationHandler struct {
+	requestEmailVerificationUseCase usecase.RequestEmailVerificationUseCase
+}
+
+func NewRequestEmailVerificationHandler(requestEmailVerificationUC usecase.RequestEmailVerificationUseCase) *RequestEmailVerificationHandler {
+	return &RequestEmailVerificationHandler{
+		requestEmailVerificationUseCase: requestEmailVerificationUC,
+	}
+}
+
+func (h *RequestEmailVerificationHandler) Handle(c *gin.Context) {
+	var req dto.EmailVerificationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[RequestEmailVerificationHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong binding request data", err.Error()))
+		return
+	}
+
+	if err := h.requestEmailVerificationUseCase.Execute(c.Request.Context(), req.Email); err != nil {
+		log.Printf("[RequestEmailVerificationHandler] Failed to send verification email: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong requesting email verification", "failed to send verification email"))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.MessageResponse{
+		Message: "if the email is registered and not yet verified, a verification link has been sent",
+	})
+}
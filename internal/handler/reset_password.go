@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type ResetPasswordHandler struct {
+	resetPasswordUseCase usecase.ResetPasswordUseCase
+}
+
+func NewResetPasswordHandler(resetPasswordUC usecase.ResetPasswordUseCase) *ResetPasswordHandler {
+	return &ResetPasswordHandler{
+		resetPasswordUseCase: resetPasswordUC,
+	}
+}
+
+func (h *ResetPasswordHandler) Handle(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[ResetPasswordHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong binding request data", err.Error()))
+		return
+	}
+
+	passwordValidationErrs := validatePassword(req.Password)
+	if len(passwordValidationErrs) > 0 {
+		log.Printf("[ResetPasswordHandler] Password validation error: %s", passwordValidationErrs[0])
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong validating request data", passwordValidationErrs[0]))
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("[ResetPasswordHandler] Failed to hash password: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong hashing password", "failed to hash password"))
+		return
+	}
+
+	err = h.resetPasswordUseCase.Execute(c.Request.Context(), &entity.ResetPasswordInput{
+		Token:        req.Token,
+		PasswordHash: string(hashedPassword),
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidUserToken) {
+			log.Printf("[ResetPasswordHandler] Invalid reset token: %v", err)
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong resetting password", err.Error()))
+			return
+		}
+		log.Printf("[ResetPasswordHandler] Failed to reset password: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong resetting password", "failed to reset password"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "password updated successfully",
+	})
+}
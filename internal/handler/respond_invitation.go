@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type RespondInvitationHandler struct {
+	respondInvitationUseCase usecase.RespondInvitationUseCase
+}
+
+func NewRespondInvitationHandler(respondInvitationUC usecase.RespondInvitationUseCase) *RespondInvitationHandler {
+	return &RespondInvitationHandler{
+		respondInvitationUseCase: respondInvitationUC,
+	}
+}
+
+func (h *RespondInvitationHandler) HandleAccept(c *gin.Context) {
+	h.respond(c, true)
+}
+
+func (h *RespondInvitationHandler) HandleDecline(c *gin.Context) {
+	h.respond(c, false)
+}
+
+func (h *RespondInvitationHandler) respond(c *gin.Context, accept bool) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[RespondInvitationHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	link, err := h.respondInvitationUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), accept)
+	if err != nil {
+		log.Printf("[RespondInvitationHandler] Failed to respond invitation: %v", err)
+		status, message := patientLinkErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong responding invitation", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
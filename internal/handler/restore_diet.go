@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// RestoreDietHandler lida com a restauração de dietas removidas
+type RestoreDietHandler struct {
+	restoreDietUseCase usecase.RestoreDietUseCase
+}
+
+func NewRestoreDietHandler(restoreDietUseCase usecase.RestoreDietUseCase) *RestoreDietHandler {
+	return &RestoreDietHandler{
+		restoreDietUseCase: restoreDietUseCase,
+	}
+}
+
+func (h *RestoreDietHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[RestoreDietHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	diet, err := h.restoreDietUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"))
+	if err != nil {
+		log.Printf("[RestoreDietHandler] Failed to restore diet: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong restoring diet", message))
+		return
+	}
+
+	setDietETag(c, diet)
+	c.JSON(http.StatusOK, dto.NewDietResponse(diet))
+}
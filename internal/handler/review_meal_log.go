@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// ReviewMealLogHandler lets the nutritionist review a meal log
+type ReviewMealLogHandler struct {
+	reviewMealLogUseCase usecase.ReviewMealLogUseCase
+}
+
+func NewReviewMealLogHandler(reviewMealLogUseCase usecase.ReviewMealLogUseCase) *ReviewMealLogHandler {
+	return &ReviewMealLogHandler{
+		reviewMealLogUseCase: reviewMealLogUseCase,
+	}
+}
+
+func (h *ReviewMealLogHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ReviewMealLogHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var req dto.ReviewMealLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[ReviewMealLogHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong reviewing meal log", "dados inválidos: "+err.Error()))
+		return
+	}
+
+	mealLog, err := h.reviewMealLogUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), c.Param("logId"), req.Note)
+	if err != nil {
+		log.Printf("[ReviewMealLogHandler] Failed to review meal log: %v", err)
+		status, message := mealLogErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong reviewing meal log", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, mealLog)
+}
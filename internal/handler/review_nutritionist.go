@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type ReviewNutritionistHandler struct {
+	reviewNutritionistUseCase usecase.ReviewNutritionistUseCase
+}
+
+func NewReviewNutritionistHandler(reviewNutritionistUC usecase.ReviewNutritionistUseCase) *ReviewNutritionistHandler {
+	return &ReviewNutritionistHandler{
+		reviewNutritionistUseCase: reviewNutritionistUC,
+	}
+}
+
+func (h *ReviewNutritionistHandler) HandleApprove(c *gin.Context) {
+	h.review(c, true)
+}
+
+func (h *ReviewNutritionistHandler) HandleReject(c *gin.Context) {
+	h.review(c, false)
+}
+
+func (h *ReviewNutritionistHandler) review(c *gin.Context, approve bool) {
+	var req dto.ReviewNutritionistRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			log.Printf("[ReviewNutritionistHandler] Failed to bind JSON: %v", err)
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong binding request data", err.Error()))
+			return
+		}
+	}
+
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[ReviewNutritionistHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	err := h.reviewNutritionistUseCase.Execute(c.Request.Context(), &entity.NutritionistReviewInput{
+		UserID:     c.Param("id"),
+		ReviewerID: claimsValue.(*middleware.Claims).UserID,
+		Approve:    approve,
+		Note:       req.Note,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, dto.NewError("something went wrong reviewing nutritionist", err.Error()))
+		case errors.Is(err, usecase.ErrNoNutritionistRequest):
+			c.JSON(http.StatusConflict, dto.NewError("something went wrong reviewing nutritionist", err.Error()))
+		default:
+			log.Printf("[ReviewNutritionistHandler] Failed to review nutritionist: %v", err)
+			c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong reviewing nutritionist", "failed to review nutritionist"))
+		}
+		return
+	}
+
+	message := "nutritionist rejected"
+	if approve {
+		message = "nutritionist approved"
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: message,
+	})
+}
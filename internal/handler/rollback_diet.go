@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// RollbackDietHandler restaura uma dieta para uma revisão anterior
+type RollbackDietHandler struct {
+	rollbackDietUseCase usecase.RollbackDietUseCase
+}
+
+func NewRollbackDietHandler(rollbackDietUseCase usecase.RollbackDietUseCase) *RollbackDietHandler {
+	return &RollbackDietHandler{
+		rollbackDietUseCase: rollbackDietUseCase,
+	}
+}
+
+func (h *RollbackDietHandler) Handle(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong rolling back diet", "número da revisão inválido"))
+		return
+	}
+
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[RollbackDietHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	diet, err := h.rollbackDietUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), number, ifMatchVersion(c))
+	if err != nil {
+		log.Printf("[RollbackDietHandler] Failed to roll back diet: %v", err)
+		status, message := dietErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong rolling back diet", message))
+		return
+	}
+
+	setDietETag(c, diet)
+	c.JSON(http.StatusOK, dto.NewDietResponse(diet))
+}
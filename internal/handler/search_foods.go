@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// SearchFoodsHandler searches the food catalog
+type SearchFoodsHandler struct {
+	searchFoodsUseCase usecase.SearchFoodsUseCase
+}
+
+func NewSearchFoodsHandler(searchFoodsUseCase usecase.SearchFoodsUseCase) *SearchFoodsHandler {
+	return &SearchFoodsHandler{
+		searchFoodsUseCase: searchFoodsUseCase,
+	}
+}
+
+func (h *SearchFoodsHandler) Handle(c *gin.Context) {
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong searching foods", "limit inválido: "+value))
+			return
+		}
+		limit = parsed
+	}
+
+	foods, err := h.searchFoodsUseCase.Execute(c.Request.Context(), c.Query("q"), c.Query("source"), limit)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidListParams) {
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong searching foods", err.Error()))
+			return
+		}
+		log.Printf("[SearchFoodsHandler] Failed to search foods: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong searching foods", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, foods)
+}
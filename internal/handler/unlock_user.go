@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// UnlockUserHandler lets administrators lift a login lockout
+type UnlockUserHandler struct {
+	unlockUserUseCase usecase.UnlockUserUseCase
+}
+
+func NewUnlockUserHandler(unlockUserUC usecase.UnlockUserUseCase) *UnlockUserHandler {
+	return &UnlockUserHandler{
+		unlockUserUseCase: unlockUserUC,
+	}
+}
+
+func (h *UnlockUserHandler) Handle(c *gin.Context) {
+	userID := c.Param("id")
+
+	if err := h.unlockUserUseCase.Execute(c.Request.Context(), userID); err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			log.Printf("[UnlockUserHandler] User not found: %s", userID)
+			c.JSON(http.StatusNotFound, dto.NewError("something went wrong unlocking user", err.Error()))
+			return
+		}
+		log.Printf("[UnlockUserHandler] Failed to unlock user: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong unlocking user", "failed to unlock user"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "user unlocked successfully",
+	})
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// UpdateAnthropometricsHandler lets a nutritionist inform the anthropometrics of a patient
+type UpdateAnthropometricsHandler struct {
+	updateAnthropometricsUseCase usecase.UpdateAnthropometricsUseCase
+}
+
+func NewUpdateAnthropometricsHandler(updateAnthropometricsUseCase usecase.UpdateAnthropometricsUseCase) *UpdateAnthropometricsHandler {
+	return &UpdateAnthropometricsHandler{
+		updateAnthropometricsUseCase: updateAnthropometricsUseCase,
+	}
+}
+
+func (h *UpdateAnthropometricsHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[UpdateAnthropometricsHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var req dto.AnthropometricsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[UpdateAnthropometricsHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong updating anthropometrics", "dados inválidos: "+err.Error()))
+		return
+	}
+
+	assessment, err := h.updateAnthropometricsUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, c.Param("id"), dto.ConvertToAnthropometrics(&req))
+	if err != nil {
+		log.Printf("[UpdateAnthropometricsHandler] Failed to update anthropometrics: %v", err)
+		status, message := energyErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong updating anthropometrics", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, assessment)
+}
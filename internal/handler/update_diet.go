@@ -39,14 +39,20 @@ func (h *UpdateDietHandler) Handle(c *gin.Context) {
 	}
 
 	// Fazer o bind do JSON para o DTO
-	var req dto.DietRequest
+	var req dto.UpdateDietRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Printf("[UpdateDietHandler] Failed to bind JSON: %v", err)
 		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong updating diet", "dados inválidos: "+err.Error()))
 		return
 	}
 
-	diet, err := dto.ConvertToDiet(claimsValue.(*middleware.Claims).UserID, &req)
+	if err := req.Validate(); err != nil {
+		log.Printf("[UpdateDietHandler] Validation error: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong validating request data", err.Error()))
+		return
+	}
+
+	diet, err := dto.ConvertUpdateToDiet(claimsValue.(*middleware.Claims).UserID, &req)
 	if err != nil {
 		log.Printf("[UpdateDietHandler] Failed to convert to diet: %v", err)
 		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong updating diet", err.Error()))
@@ -54,7 +60,7 @@ func (h *UpdateDietHandler) Handle(c *gin.Context) {
 	}
 
 	// Chamar o caso de uso
-	updatedDiet, err := h.updateDietUseCase.Execute(c.Request.Context(), dietID, diet)
+	updatedDiet, err := h.updateDietUseCase.Execute(c.Request.Context(), dietID, diet, ifMatchVersion(c))
 	if err != nil {
 		status := http.StatusInternalServerError
 		errMsg := "failed to update diet: " + err.Error()
@@ -63,6 +69,34 @@ func (h *UpdateDietHandler) Handle(c *gin.Context) {
 			status = http.StatusForbidden
 			errMsg = "you do not have permission to update this diet"
 			log.Printf("[UpdateDietHandler] Unauthorized update attempt: %v", err)
+		} else if errors.Is(err, usecase.ErrDietNotFound) {
+			status = http.StatusNotFound
+			errMsg = err.Error()
+			log.Printf("[UpdateDietHandler] Diet not found: %v", err)
+		} else if errors.Is(err, usecase.ErrPreconditionFailed) {
+			status = http.StatusPreconditionFailed
+			errMsg = err.Error()
+			log.Printf("[UpdateDietHandler] If-Match does not match: %v", err)
+		} else if errors.Is(err, usecase.ErrVersionConflict) {
+			status = http.StatusConflict
+			errMsg = err.Error()
+			log.Printf("[UpdateDietHandler] Concurrent update: %v", err)
+		} else if errors.Is(err, usecase.ErrCalendarBusy) {
+			status = http.StatusServiceUnavailable
+			errMsg = err.Error()
+			log.Printf("[UpdateDietHandler] Patient calendar busy: %v", err)
+		} else if errors.Is(err, usecase.ErrFoodNotFound) {
+			status = http.StatusBadRequest
+			errMsg = err.Error()
+			log.Printf("[UpdateDietHandler] Unknown food: %v", err)
+		} else if errors.Is(err, usecase.ErrPatientNotLinked) {
+			status = http.StatusForbidden
+			errMsg = err.Error()
+			log.Printf("[UpdateDietHandler] Patient not linked: %v", err)
+		} else if errors.Is(err, usecase.ErrDietArchived) || errors.Is(err, usecase.ErrActiveDietConflict) {
+			status = http.StatusConflict
+			errMsg = err.Error()
+			log.Printf("[UpdateDietHandler] Diet lifecycle conflict: %v", err)
 		} else {
 			log.Printf("[UpdateDietHandler] Failed to update diet: %v", err)
 		}
@@ -71,5 +105,6 @@ func (h *UpdateDietHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, updatedDiet)
+	setDietETag(c, updatedDiet)
+	c.JSON(http.StatusOK, dto.NewDietResponse(updatedDiet))
 }
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// UpdateDietTemplateHandler edits a template of the nutritionist
+type UpdateDietTemplateHandler struct {
+	updateDietTemplateUseCase usecase.UpdateDietTemplateUseCase
+}
+
+func NewUpdateDietTemplateHandler(updateDietTemplateUseCase usecase.UpdateDietTemplateUseCase) *UpdateDietTemplateHandler {
+	return &UpdateDietTemplateHandler{
+		updateDietTemplateUseCase: updateDietTemplateUseCase,
+	}
+}
+
+func (h *UpdateDietTemplateHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[UpdateDietTemplateHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var req dto.DietTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[UpdateDietTemplateHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong binding request data", err.Error()))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Printf("[UpdateDietTemplateHandler] Validation error: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong validating request data", err.Error()))
+		return
+	}
+
+	userID := claimsValue.(*middleware.Claims).UserID
+	template, err := dto.ConvertToDietTemplate(userID, &req)
+	if err != nil {
+		log.Printf("[UpdateDietTemplateHandler] Failed to convert to diet template: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong updating diet template", "invalid ingredients: "+err.Error()))
+		return
+	}
+
+	template, err = h.updateDietTemplateUseCase.Execute(c.Request.Context(), userID, c.Param("id"), template)
+	if err != nil {
+		log.Printf("[UpdateDietTemplateHandler] Failed to update diet template: %v", err)
+		status, message := dietTemplateErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong updating diet template", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDietTemplateResponse(template))
+}
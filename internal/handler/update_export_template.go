@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// UpdateExportTemplateHandler replaces the export template of the logged nutritionist
+type UpdateExportTemplateHandler struct {
+	updateExportTemplateUseCase usecase.UpdateExportTemplateUseCase
+}
+
+func NewUpdateExportTemplateHandler(updateExportTemplateUseCase usecase.UpdateExportTemplateUseCase) *UpdateExportTemplateHandler {
+	return &UpdateExportTemplateHandler{
+		updateExportTemplateUseCase: updateExportTemplateUseCase,
+	}
+}
+
+func (h *UpdateExportTemplateHandler) Handle(c *gin.Context) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[UpdateExportTemplateHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var req dto.ExportTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[UpdateExportTemplateHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong updating export template", "dados inválidos: "+err.Error()))
+		return
+	}
+
+	template, err := dto.ConvertToExportTemplate(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong updating export template", err.Error()))
+		return
+	}
+
+	template, err = h.updateExportTemplateUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, template)
+	if err != nil {
+		log.Printf("[UpdateExportTemplateHandler] Failed to update export template: %v", err)
+		status, message := exportTemplateErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong updating export template", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewExportTemplateResponse(template))
+}
+
+func exportTemplateErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidExportTemplate):
+		return http.StatusBadRequest, err.Error()
+	default:
+		return http.StatusInternalServerError, "failed to process export template: " + err.Error()
+	}
+}
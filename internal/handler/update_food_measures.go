@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// UpdateFoodMeasuresHandler replaces the density and gram weights of a food
+type UpdateFoodMeasuresHandler struct {
+	updateFoodMeasuresUseCase usecase.UpdateFoodMeasuresUseCase
+}
+
+func NewUpdateFoodMeasuresHandler(updateFoodMeasuresUseCase usecase.UpdateFoodMeasuresUseCase) *UpdateFoodMeasuresHandler {
+	return &UpdateFoodMeasuresHandler{
+		updateFoodMeasuresUseCase: updateFoodMeasuresUseCase,
+	}
+}
+
+func (h *UpdateFoodMeasuresHandler) Handle(c *gin.Context) {
+	var req dto.FoodMeasuresRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[UpdateFoodMeasuresHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong updating food measures", "dados inválidos: "+err.Error()))
+		return
+	}
+
+	food, err := h.updateFoodMeasuresUseCase.Execute(c.Request.Context(), c.Param("id"), req.DensityGPerMl, req.GramWeights)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrFoodNotFound):
+			c.JSON(http.StatusNotFound, dto.NewError("something went wrong updating food measures", err.Error()))
+		case errors.Is(err, usecase.ErrInvalidFoodMeasures):
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong updating food measures", err.Error()))
+		default:
+			log.Printf("[UpdateFoodMeasuresHandler] Failed to update food measures: %v", err)
+			c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong updating food measures", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, food)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+// UpdateMeasurementHandler edits a measurement
+type UpdateMeasurementHandler struct {
+	updateMeasurementUseCase usecase.UpdateMeasurementUseCase
+}
+
+func NewUpdateMeasurementHandler(updateMeasurementUseCase usecase.UpdateMeasurementUseCase) *UpdateMeasurementHandler {
+	return &UpdateMeasurementHandler{
+		updateMeasurementUseCase: updateMeasurementUseCase,
+	}
+}
+
+// Handle edits a measurement of a patient linked to the nutritionist
+func (h *UpdateMeasurementHandler) Handle(c *gin.Context) {
+	h.handle(c, c.Param("id"))
+}
+
+// HandleMe edits a measurement of the logged user
+func (h *UpdateMeasurementHandler) HandleMe(c *gin.Context) {
+	h.handle(c, "")
+}
+
+func (h *UpdateMeasurementHandler) handle(c *gin.Context, linkID string) {
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[UpdateMeasurementHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	var req dto.MeasurementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[UpdateMeasurementHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong updating measurement", "dados inválidos: "+err.Error()))
+		return
+	}
+
+	measurement, err := h.updateMeasurementUseCase.Execute(c.Request.Context(), claimsValue.(*middleware.Claims).UserID, linkID, c.Param("measurementId"), dto.ConvertToMeasurement(&req))
+	if err != nil {
+		log.Printf("[UpdateMeasurementHandler] Failed to update measurement: %v", err)
+		status, message := measurementErrorStatus(err)
+		c.JSON(status, dto.NewError("something went wrong updating measurement", message))
+		return
+	}
+
+	c.JSON(http.StatusOK, measurement)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/middleware"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type UpdateRoleHandler struct {
+	updateRoleUseCase usecase.UpdateRoleUseCase
+}
+
+func NewUpdateRoleHandler(updateRoleUC usecase.UpdateRoleUseCase) *UpdateRoleHandler {
+	return &UpdateRoleHandler{
+		updateRoleUseCase: updateRoleUC,
+	}
+}
+
+func (h *UpdateRoleHandler) Handle(c *gin.Context) {
+	var req dto.RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[UpdateRoleHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong binding request data", err.Error()))
+		return
+	}
+
+	claimsValue, exists := c.Get(string(middleware.TokenContextKey))
+	if !exists {
+		log.Printf("[UpdateRoleHandler] Missing user claims in context")
+		c.JSON(http.StatusUnauthorized, dto.NewError("something went wrong getting user claims", "usuário não autenticado"))
+		return
+	}
+
+	role, err := h.updateRoleUseCase.Execute(c.Request.Context(), &entity.Role{
+		Name:        c.Param("name"),
+		Description: req.Description,
+		Permissions: req.Permissions,
+		UpdatedBy:   claimsValue.(*middleware.Claims).UserID,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrUnknownPermission) {
+			log.Printf("[UpdateRoleHandler] Invalid permissions: %v", err)
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong updating role", err.Error()))
+			return
+		}
+		log.Printf("[UpdateRoleHandler] Failed to update role: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong updating role", "failed to update role"))
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
@@ -3,7 +3,9 @@ package handler
 import (
 	"errors"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/victorgiudicissi/your-diet/internal/dto"
@@ -31,8 +33,10 @@ func (h *LoginHandler) HandleLogin(c *gin.Context) {
 	}
 
 	input := &entity.LoginUseCaseInput{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:     req.Email,
+		Password:  req.Password,
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
 	}
 
 	result, err := h.loginUseCase.Execute(c.Request.Context(), input)
@@ -43,13 +47,29 @@ func (h *LoginHandler) HandleLogin(c *gin.Context) {
 			return
 		}
 
+		var lockedErr *usecase.AccountLockedError
+		if errors.As(err, &lockedErr) {
+			log.Printf("[HandleLogin] Account locked: %v", err)
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+			c.JSON(http.StatusLocked, dto.NewError("error", err.Error()))
+			return
+		}
+
+		if errors.Is(err, usecase.ErrEmailNotVerified) {
+			log.Printf("[HandleLogin] Email not verified: %v", err)
+			c.JSON(http.StatusForbidden, dto.NewError("error", err.Error()))
+			return
+		}
+
 		log.Printf("[HandleLogin] Internal error: %v", err)
 		c.JSON(http.StatusInternalServerError, dto.NewError("error", "login failed"))
 		return
 	}
 
 	c.JSON(http.StatusOK, dto.LoginResponse{
-		Token:     result.Token,
-		ExpiresAt: result.ExpiresAt,
+		Token:            result.Token,
+		ExpiresAt:        result.ExpiresAt,
+		RefreshToken:     result.RefreshToken,
+		RefreshExpiresAt: result.RefreshExpiresAt,
 	})
 }
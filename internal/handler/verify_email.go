@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+)
+
+type VerifyEmailHandler struct {
+	verifyEmailUseCase usecase.VerifyEmailUseCase
+}
+
+func NewVerifyEmailHandler(verifyEmailUC usecase.VerifyEmailUseCase) *VerifyEmailHandler {
+	return &VerifyEmailHandler{
+		verifyEmailUseCase: verifyEmailUC,
+	}
+}
+
+func (h *VerifyEmailHandler) Handle(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[VerifyEmailHandler] Failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, dto.NewError("something went wrong binding request data", err.Error()))
+		return
+	}
+
+	if err := h.verifyEmailUseCase.Execute(c.Request.Context(), req.Token); err != nil {
+		if errors.Is(err, usecase.ErrInvalidUserToken) {
+			log.Printf("[VerifyEmailHandler] Invalid verification token: %v", err)
+			c.JSON(http.StatusBadRequest, dto.NewError("something went wrong verifying email", err.Error()))
+			return
+		}
+		log.Printf("[VerifyEmailHandler] Failed to verify email: %v", err)
+		c.JSON(http.StatusInternalServerError, dto.NewError("something went wrong verifying email", "failed to verify email"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "email verified successfully",
+	})
+}
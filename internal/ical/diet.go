@@ -0,0 +1,198 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/mealtime"
+)
+
+// defaultMealDuration is the length of the event of a meal without a window
+const defaultMealDuration = 30 * time.Minute
+
+var byDay = map[entity.Weekday]string{
+	entity.Monday:    "MO",
+	entity.Tuesday:   "TU",
+	entity.Wednesday: "WE",
+	entity.Thursday:  "TH",
+	entity.Friday:    "FR",
+	entity.Saturday:  "SA",
+	entity.Sunday:    "SU",
+}
+
+// weekOrder lists the days in the order of the BYDAY values
+var weekOrder = []entity.Weekday{
+	entity.Monday, entity.Tuesday, entity.Wednesday, entity.Thursday, entity.Friday, entity.Saturday, entity.Sunday,
+}
+
+// DietCalendar turns each meal of the diet into an event repeated on the days
+// that prescribe it, from start (the first day of the diet) until the end of
+// its duration. Meals follow the day templates of the diet: a weekly rule per
+// template of the WEEKDAY rule and one rule per position of the ROTATION
+// cycle. Meals whose time is still free text are left out. The sequence of
+// the events is the version of the diet, so calendar apps replace them when
+// the diet is updated.
+func DietCalendar(diet *entity.Diet, start, now time.Time) *Calendar {
+	calendar := &Calendar{Name: "Your Diet: " + diet.DietName, Events: []Event{}}
+	if diet.DurationInDays == 0 {
+		return calendar
+	}
+
+	b := &dietEvents{
+		diet:  diet,
+		start: start,
+		last:  start.AddDate(0, 0, int(diet.DurationInDays)-1),
+		now:   now,
+	}
+
+	switch {
+	case len(diet.Days) > 0 && diet.DayRule != nil && diet.DayRule.Type == entity.DayRuleWeekday:
+		assigned := map[entity.Weekday]bool{}
+		for i, day := range diet.Days {
+			for _, weekday := range day.Weekdays {
+				assigned[weekday] = true
+			}
+			b.weekly(fmt.Sprintf("day%d", i), day.Meals, day.Weekdays)
+		}
+
+		var free []entity.Weekday
+		for _, weekday := range weekOrder {
+			if !assigned[weekday] {
+				free = append(free, weekday)
+			}
+		}
+		b.weekly("base", diet.Meals, free)
+	case len(diet.Days) > 0 && diet.DayRule != nil && diet.DayRule.Type == entity.DayRuleRotation && len(diet.DayRule.Rotation) > 0:
+		size := len(diet.DayRule.Rotation)
+		for position := 0; position < size; position++ {
+			date := start.AddDate(0, 0, position)
+			b.daily(fmt.Sprintf("rotation%d", position), dayplan.MealsOn(diet, start, date), date, size)
+		}
+	default:
+		b.daily("base", diet.Meals, start, 1)
+	}
+
+	calendar.Events = b.events
+	return calendar
+}
+
+type dietEvents struct {
+	diet   *entity.Diet
+	start  time.Time
+	last   time.Time
+	now    time.Time
+	events []Event
+}
+
+// daily repeats the meals every interval days from first
+func (b *dietEvents) daily(key string, meals []entity.Meal, first time.Time, interval int) {
+	if first.After(b.last) {
+		return
+	}
+
+	rule := "FREQ=DAILY"
+	if interval > 1 {
+		rule += ";INTERVAL=" + strconv.Itoa(interval)
+	}
+	b.add(key, meals, first, rule)
+}
+
+// weekly repeats the meals on the weekdays, starting on the first of them
+// within the diet
+func (b *dietEvents) weekly(key string, meals []entity.Meal, weekdays []entity.Weekday) {
+	if len(weekdays) == 0 {
+		return
+	}
+
+	days := make([]string, 0, len(weekdays))
+	matches := map[entity.Weekday]bool{}
+	for _, weekday := range weekdays {
+		days = append(days, byDay[weekday])
+		matches[weekday] = true
+	}
+
+	// DTSTART precisa ser uma das ocorrências da regra
+	first := b.start
+	for !matches[dayplan.WeekdayOf(first)] {
+		first = first.AddDate(0, 0, 1)
+		if first.After(b.last) {
+			return
+		}
+	}
+
+	b.add(key, meals, first, "FREQ=WEEKLY;BYDAY="+strings.Join(days, ","))
+}
+
+func (b *dietEvents) add(key string, meals []entity.Meal, first time.Time, rule string) {
+	for i, meal := range meals {
+		start, end, ok := mealTimes(meal, first)
+		if !ok {
+			continue
+		}
+
+		until := time.Date(b.last.Year(), b.last.Month(), b.last.Day(), start.Hour(), start.Minute(), 0, 0, start.Location())
+
+		b.events = append(b.events, Event{
+			UID:         fmt.Sprintf("%s-%s-%d@your-diet", b.diet.ID, key, i),
+			Sequence:    b.diet.Version,
+			Stamp:       b.now,
+			Modified:    b.diet.UpdatedAt,
+			Start:       start,
+			End:         end,
+			Rule:        rule + ";UNTIL=" + until.Format(floatingLayout),
+			Summary:     meal.Name,
+			Description: mealDescription(meal),
+			Alarm:       true,
+		})
+	}
+}
+
+// mealTimes returns the window of the meal on the date or, without one, the
+// time of the meal and the default duration
+func mealTimes(meal entity.Meal, date time.Time) (time.Time, time.Time, bool) {
+	at := func(clock string) (time.Time, bool) {
+		minutes, err := mealtime.ParseClock(clock)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Date(date.Year(), date.Month(), date.Day(), minutes/60, minutes%60, 0, 0, date.Location()), true
+	}
+
+	if meal.WindowStart != "" && meal.WindowEnd != "" {
+		start, okStart := at(meal.WindowStart)
+		end, okEnd := at(meal.WindowEnd)
+		if okStart && okEnd && end.After(start) {
+			return start, end, true
+		}
+	}
+
+	start, ok := at(meal.TimeOfDay)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, start.Add(defaultMealDuration), true
+}
+
+// mealDescription lists the ingredients with their quantities and substitutes
+func mealDescription(meal entity.Meal) string {
+	var lines []string
+	if meal.Description != "" {
+		lines = append(lines, meal.Description, "")
+	}
+	for _, ingredient := range meal.Ingredients {
+		lines = append(lines, "- "+ingredientText(ingredient))
+		for _, substitute := range ingredient.Substitutes {
+			lines = append(lines, "  ou "+ingredientText(substitute))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func ingredientText(ingredient entity.Ingredient) string {
+	quantity := strings.Replace(strconv.FormatFloat(ingredient.Quantity, 'f', -1, 64), ".", ",", 1)
+	return fmt.Sprintf("%s: %s %s", ingredient.Description, quantity, ingredient.Unit)
+}
@@ -0,0 +1,126 @@
+// Package ical writes iCalendar (RFC 5545) feeds that calendar apps can
+// subscribe to.
+package ical
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// floatingLayout is a local time without time zone: the event happens at
+	// the same wall-clock time wherever the phone is
+	floatingLayout = "20060102T150405"
+	utcLayout      = "20060102T150405Z"
+	// maxLineOctets is the longest content line before folding
+	maxLineOctets = 75
+)
+
+// Calendar is a feed of events
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Event is a VEVENT with floating start and end times, repeated by Rule
+type Event struct {
+	UID      string
+	Sequence int64
+	Stamp    time.Time
+	Modified time.Time
+	Start    time.Time
+	End      time.Time
+	// Rule is the RRULE value, such as "FREQ=DAILY;UNTIL=20260330T073000"
+	Rule        string
+	Summary     string
+	Description string
+	// Alarm adds a reminder at the start of the event
+	Alarm bool
+}
+
+// Bytes serializes the calendar with CRLF line endings and folded lines
+func (c *Calendar) Bytes() []byte {
+	w := &writer{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//Your Diet//Diet Calendar//PT")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME", escape(c.Name))
+	}
+	// Pede aos aplicativos que atualizem o feed a cada hora
+	w.line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	w.line("X-PUBLISHED-TTL", "PT1H")
+
+	for _, event := range c.Events {
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", event.UID)
+		w.line("SEQUENCE", strconv.FormatInt(event.Sequence, 10))
+		w.line("DTSTAMP", event.Stamp.UTC().Format(utcLayout))
+		if !event.Modified.IsZero() {
+			w.line("LAST-MODIFIED", event.Modified.UTC().Format(utcLayout))
+		}
+		w.line("DTSTART", event.Start.Format(floatingLayout))
+		w.line("DTEND", event.End.Format(floatingLayout))
+		if event.Rule != "" {
+			w.line("RRULE", event.Rule)
+		}
+		w.line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			w.line("DESCRIPTION", escape(event.Description))
+		}
+		w.line("TRANSP", "TRANSPARENT")
+		if event.Alarm {
+			w.line("BEGIN", "VALARM")
+			w.line("ACTION", "DISPLAY")
+			w.line("DESCRIPTION", escape(event.Summary))
+			w.line("TRIGGER", "PT0M")
+			w.line("END", "VALARM")
+		}
+		w.line("END", "VEVENT")
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+type writer struct {
+	buf bytes.Buffer
+}
+
+// line writes "NAME:value", folding it at 75 octets without splitting a
+// UTF-8 character
+func (w *writer) line(name, value string) {
+	content := name + ":" + value
+	width := 0
+	for len(content) > 0 {
+		r, size := utf8.DecodeRuneInString(content)
+		if r == utf8.RuneError && size <= 1 {
+			size = 1
+		}
+		if width+size > maxLineOctets {
+			w.buf.WriteString("\r\n ")
+			// O espaço da continuação conta no limite da linha
+			width = 1
+		}
+		w.buf.WriteString(content[:size])
+		width += size
+		content = content[size:]
+	}
+	w.buf.WriteString("\r\n")
+}
+
+// escape protects the characters with meaning in TEXT values
+func escape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
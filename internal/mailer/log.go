@@ -0,0 +1,22 @@
+package mailer
+
+import (
+	"context"
+	"log"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// LogMailer only writes emails to the application log. It is used when no
+// SMTP server is configured.
+type LogMailer struct{}
+
+// NewLogMailer creates a new LogMailer.
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(_ context.Context, message *entity.EmailMessage) error {
+	log.Printf("[LogMailer] To: %s | Subject: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
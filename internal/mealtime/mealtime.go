@@ -0,0 +1,149 @@
+// Package mealtime parses and orders the time of the meals of a diet.
+package mealtime
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+var ErrInvalidClock = errors.New("time must be in the HH:MM format")
+
+// Slots lists the canonical slots in the order of the day
+var Slots = []entity.MealSlot{
+	entity.SlotBreakfast,
+	entity.SlotMorningSnack,
+	entity.SlotLunch,
+	entity.SlotAfternoonSnack,
+	entity.SlotDinner,
+	entity.SlotSupper,
+	entity.SlotPreWorkout,
+	entity.SlotPostWorkout,
+	entity.SlotOther,
+}
+
+// IsSlot reports whether the value is a canonical slot
+func IsSlot(value string) bool {
+	for _, slot := range Slots {
+		if string(slot) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClock parses a strict HH:MM time, returning the minutes since midnight
+func ParseClock(value string) (int, error) {
+	if len(value) != 5 || value[2] != ':' {
+		return 0, ErrInvalidClock
+	}
+
+	hours, err := strconv.Atoi(value[:2])
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, ErrInvalidClock
+	}
+
+	minutes, err := strconv.Atoi(value[3:])
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, ErrInvalidClock
+	}
+
+	return hours*60 + minutes, nil
+}
+
+// FormatClock formats minutes since midnight as HH:MM
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// InferSlot returns the usual slot for a time of the day. Pre and post
+// workout meals are never inferred.
+func InferSlot(minutes int) entity.MealSlot {
+	switch {
+	case minutes >= 5*60 && minutes < 10*60:
+		return entity.SlotBreakfast
+	case minutes >= 10*60 && minutes < 11*60+30:
+		return entity.SlotMorningSnack
+	case minutes >= 11*60+30 && minutes < 14*60+30:
+		return entity.SlotLunch
+	case minutes >= 14*60+30 && minutes < 18*60:
+		return entity.SlotAfternoonSnack
+	case minutes >= 18*60 && minutes < 21*60:
+		return entity.SlotDinner
+	default:
+		return entity.SlotSupper
+	}
+}
+
+// ValidateWindow checks that the window, when informed, is complete and
+// contains the time of the meal. Windows may cross midnight.
+func ValidateWindow(timeOfDay, windowStart, windowEnd string) error {
+	if windowStart == "" && windowEnd == "" {
+		return nil
+	}
+	if windowStart == "" || windowEnd == "" {
+		return errors.New("window_start and window_end must be informed together")
+	}
+
+	at, err := ParseClock(timeOfDay)
+	if err != nil {
+		return err
+	}
+	start, err := ParseClock(windowStart)
+	if err != nil {
+		return err
+	}
+	end, err := ParseClock(windowEnd)
+	if err != nil {
+		return err
+	}
+
+	inside := start <= at && at <= end
+	if start > end {
+		inside = at >= start || at <= end
+	}
+	if !inside {
+		return fmt.Errorf("time_of_day %s must be inside the window %s-%s", timeOfDay, windowStart, windowEnd)
+	}
+	return nil
+}
+
+// Sort orders the meals chronologically. Meals whose time cannot be parsed
+// (free text of old diets) keep their relative order at the end.
+func Sort(meals []entity.Meal) {
+	sort.SliceStable(meals, func(i, j int) bool {
+		a, errA := ParseClock(meals[i].TimeOfDay)
+		b, errB := ParseClock(meals[j].TimeOfDay)
+		switch {
+		case errA != nil:
+			return false
+		case errB != nil:
+			return true
+		default:
+			return a < b
+		}
+	})
+}
+
+// Normalize fills the slot from the time when it was not informed and sorts
+// the meals
+func Normalize(meals []entity.Meal) {
+	for i := range meals {
+		if meals[i].Slot != "" {
+			continue
+		}
+		if minutes, err := ParseClock(meals[i].TimeOfDay); err == nil {
+			meals[i].Slot = InferSlot(minutes)
+		}
+	}
+	Sort(meals)
+}
+
+// IsStructured reports whether the meal already has a valid time and slot
+func IsStructured(meal entity.Meal) bool {
+	_, err := ParseClock(meal.TimeOfDay)
+	return err == nil && IsSlot(string(meal.Slot))
+}
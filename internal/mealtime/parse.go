@@ -0,0 +1,137 @@
+package mealtime
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+// Parsed is the structured time recovered from a free-text description
+type Parsed struct {
+	TimeOfDay   string
+	WindowStart string
+	WindowEnd   string
+	Slot        entity.MealSlot
+	// ExplicitSlot is true when the slot came from a word of the text and not
+	// from the time of the day
+	ExplicitSlot bool
+}
+
+// clockPattern finds times such as "07:30", "7h30", "7h", "19 horas", "7.30" and "7 am"
+var clockPattern = regexp.MustCompile(`(\d{1,2})\s*(?:(?:horas?|hrs|hs|h|:|\.)\s*(\d+)?|(am|pm))\s*(am|pm)?`)
+
+// slotKeywords are checked in order, so the specific expressions come before
+// the generic ones ("lanche da manha" before "manha")
+var slotKeywords = []struct {
+	words []string
+	slot  entity.MealSlot
+}{
+	{[]string{"pre treino", "pretreino", "pre workout"}, entity.SlotPreWorkout},
+	{[]string{"pos treino", "postreino", "post workout"}, entity.SlotPostWorkout},
+	{[]string{"lanche da manha", "colacao", "morning snack"}, entity.SlotMorningSnack},
+	{[]string{"lanche da tarde", "cafe da tarde", "merenda", "afternoon snack"}, entity.SlotAfternoonSnack},
+	{[]string{"cafe da manha", "desjejum", "breakfast"}, entity.SlotBreakfast},
+	{[]string{"almoco", "lunch", "meio dia"}, entity.SlotLunch},
+	{[]string{"jantar", "janta", "dinner"}, entity.SlotDinner},
+	{[]string{"ceia", "supper"}, entity.SlotSupper},
+	{[]string{"manha", "morning"}, entity.SlotBreakfast},
+	{[]string{"lanche", "snack", "tarde", "afternoon"}, entity.SlotAfternoonSnack},
+	{[]string{"noite", "night", "evening"}, entity.SlotDinner},
+}
+
+// defaultTimes are used when the text names the meal but has no time
+var defaultTimes = map[entity.MealSlot]string{
+	entity.SlotBreakfast:      "07:00",
+	entity.SlotMorningSnack:   "10:00",
+	entity.SlotLunch:          "12:00",
+	entity.SlotAfternoonSnack: "16:00",
+	entity.SlotDinner:         "19:30",
+	entity.SlotSupper:         "22:00",
+}
+
+// ParseFreeText recovers a time and a slot from texts like "07:30", "7h às
+// 8h", "café da manhã" or "jantar 20h". A second time is taken as the end of
+// the window. It returns false when no time can be determined.
+func ParseFreeText(text string) (Parsed, bool) {
+	var parsed Parsed
+	parsed.Slot, parsed.ExplicitSlot = SlotFromText(text)
+
+	var clocks []int
+	for _, match := range clockPattern.FindAllStringSubmatch(strings.ToLower(text), -1) {
+		if minutes, ok := clockMinutes(match); ok {
+			clocks = append(clocks, minutes)
+		}
+	}
+
+	switch {
+	case len(clocks) > 0:
+		parsed.TimeOfDay = FormatClock(clocks[0])
+		if len(clocks) > 1 && clocks[1] != clocks[0] {
+			parsed.WindowStart = FormatClock(clocks[0])
+			parsed.WindowEnd = FormatClock(clocks[1])
+		}
+		if !parsed.ExplicitSlot {
+			parsed.Slot = InferSlot(clocks[0])
+		}
+	case parsed.ExplicitSlot && defaultTimes[parsed.Slot] != "":
+		parsed.TimeOfDay = defaultTimes[parsed.Slot]
+	default:
+		return Parsed{}, false
+	}
+
+	return parsed, true
+}
+
+// SlotFromText finds the slot named by a text such as "Café da manhã" or
+// "Lanche pré-treino"
+func SlotFromText(text string) (entity.MealSlot, bool) {
+	normalized := " " + utils.NormalizeText(text) + " "
+	for _, keyword := range slotKeywords {
+		for _, word := range keyword.words {
+			if strings.Contains(normalized, " "+word+" ") {
+				return keyword.slot, true
+			}
+		}
+	}
+	return "", false
+}
+
+// clockMinutes converts a match of clockPattern into minutes since midnight
+func clockMinutes(match []string) (int, bool) {
+	hours, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+
+	// Minutes must have two digits: "7:5" could be 07:05 or 07:50
+	minutes := 0
+	if match[2] != "" {
+		if len(match[2]) != 2 {
+			return 0, false
+		}
+		minutes, _ = strconv.Atoi(match[2])
+	}
+
+	meridiem := match[3]
+	if meridiem == "" {
+		meridiem = match[4]
+	}
+	switch meridiem {
+	case "am":
+		if hours == 12 {
+			hours = 0
+		}
+	case "pm":
+		if hours < 12 {
+			hours += 12
+		}
+	}
+
+	if hours > 23 || minutes > 59 {
+		return 0, false
+	}
+	return hours*60 + minutes, true
+}
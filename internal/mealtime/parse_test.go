@@ -0,0 +1,80 @@
+package mealtime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+func TestParseFreeText(t *testing.T) {
+	tests := []struct {
+		text string
+		want Parsed
+		ok   bool
+	}{
+		{"07:30", Parsed{TimeOfDay: "07:30", Slot: entity.SlotBreakfast}, true},
+		{"7h30", Parsed{TimeOfDay: "07:30", Slot: entity.SlotBreakfast}, true},
+		{"7h", Parsed{TimeOfDay: "07:00", Slot: entity.SlotBreakfast}, true},
+		{"7.30", Parsed{TimeOfDay: "07:30", Slot: entity.SlotBreakfast}, true},
+		{"19 horas", Parsed{TimeOfDay: "19:00", Slot: entity.SlotDinner}, true},
+		{"7 pm", Parsed{TimeOfDay: "19:00", Slot: entity.SlotDinner}, true},
+		{"12 am", Parsed{TimeOfDay: "00:00", Slot: entity.SlotSupper}, true},
+		{"7h às 8h", Parsed{TimeOfDay: "07:00", WindowStart: "07:00", WindowEnd: "08:00", Slot: entity.SlotBreakfast}, true},
+		{"7h às 7h", Parsed{TimeOfDay: "07:00", Slot: entity.SlotBreakfast}, true},
+		{"jantar 20h", Parsed{TimeOfDay: "20:00", Slot: entity.SlotDinner, ExplicitSlot: true}, true},
+		{"Ceia 21h", Parsed{TimeOfDay: "21:00", Slot: entity.SlotSupper, ExplicitSlot: true}, true},
+		{"café da manhã", Parsed{TimeOfDay: "07:00", Slot: entity.SlotBreakfast, ExplicitSlot: true}, true},
+		{"Lanche da tarde", Parsed{TimeOfDay: "16:00", Slot: entity.SlotAfternoonSnack, ExplicitSlot: true}, true},
+		{"7:5", Parsed{}, false},
+		{"7h5", Parsed{}, false},
+		{"25h", Parsed{}, false},
+		{"19:60", Parsed{}, false},
+		{"pré-treino", Parsed{}, false},
+		{"quando acordar", Parsed{}, false},
+		{"", Parsed{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParseFreeText(tt.text)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("ParseFreeText(%q) = %+v, %v; want %+v, %v", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestClockMinutes(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+		ok   bool
+	}{
+		{"07:30", 7*60 + 30, true},
+		{"7h", 7 * 60, true},
+		{"7 h 05", 7*60 + 5, true},
+		{"23:59", 23*60 + 59, true},
+		{"00:00", 0, true},
+		{"12 pm", 12 * 60, true},
+		{"12 am", 0, true},
+		{"1:15 pm", 13*60 + 15, true},
+		{"7:5", 0, false},
+		{"7:505", 0, false},
+		{"24:00", 0, false},
+		{"9:75", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			match := clockPattern.FindStringSubmatch(strings.ToLower(tt.text))
+			if match == nil {
+				t.Fatalf("clockPattern did not match %q", tt.text)
+			}
+			got, ok := clockMinutes(match)
+			if ok != tt.ok || (ok && got != tt.want) {
+				t.Errorf("clockMinutes(%q) = %d, %v; want %d, %v", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
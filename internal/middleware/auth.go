@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -18,17 +19,31 @@ const (
 	UserIDContextKey ContextKey = "user_id"
 	// PermissionsContextKey is the key used to store the user permissions in the context
 	PermissionsContextKey ContextKey = "permissions"
+	// SessionIDContextKey is the key used to store the session ID in the context
+	SessionIDContextKey ContextKey = "session_id"
 )
 
+// SessionValidator checks that the session a token was issued for is still active
+type SessionValidator interface {
+	Execute(ctx context.Context, sessionID string) error
+}
+
+// KeyProvider resolves the keys used to verify token signatures
+type KeyProvider interface {
+	Keyfunc(token *jwt.Token) (interface{}, error)
+	Algorithms() []string
+}
+
 // Claims defines the JWT claims structure
 type Claims struct {
 	UserID      string   `json:"user_id"`
+	SessionID   string   `json:"session_id"`
 	Permissions []string `json:"permissions"`
 	jwt.RegisteredClaims
 }
 
 // AuthMiddleware creates a new authentication middleware
-func AuthMiddleware(jwtSecretKey []byte) gin.HandlerFunc {
+func AuthMiddleware(keys KeyProvider, sessionValidator SessionValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get the Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -46,14 +61,8 @@ func AuthMiddleware(jwtSecretKey []byte) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		// Parse and validate the token
-		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			// Validate the signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return jwtSecretKey, nil
-		})
+		// Parse and validate the token, only accepting the configured algorithms
+		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keys.Keyfunc, jwt.WithValidMethods(keys.Algorithms()))
 
 		if err != nil || !token.Valid {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
@@ -67,9 +76,16 @@ func AuthMiddleware(jwtSecretKey []byte) gin.HandlerFunc {
 			return
 		}
 
-		// Add claims, user ID and permissions to context
+		// Reject tokens whose session was revoked (logout or refresh token reuse)
+		if err := sessionValidator.Execute(c.Request.Context(), claims.SessionID); err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session is no longer active"})
+			return
+		}
+
+		// Add claims, user ID, session ID and permissions to context
 		c.Set(string(TokenContextKey), claims)
 		c.Set(string(UserIDContextKey), claims.UserID)
+		c.Set(string(SessionIDContextKey), claims.SessionID)
 		c.Set(string(PermissionsContextKey), claims.Permissions)
 
 		// Continue to the next handler
@@ -79,6 +95,34 @@ func AuthMiddleware(jwtSecretKey []byte) gin.HandlerFunc {
 
 // HasPermission checks if the user has the required permission
 func HasPermission(requiredPermission string) gin.HandlerFunc {
+	return HasAllPermissions(requiredPermission)
+}
+
+// HasAllPermissions checks if the user has every one of the required permissions
+func HasAllPermissions(requiredPermissions ...string) gin.HandlerFunc {
+	return requirePermissions(func(granted map[string]bool) bool {
+		for _, p := range requiredPermissions {
+			if !granted[p] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// HasAnyPermission checks if the user has at least one of the required permissions
+func HasAnyPermission(requiredPermissions ...string) gin.HandlerFunc {
+	return requirePermissions(func(granted map[string]bool) bool {
+		for _, p := range requiredPermissions {
+			if granted[p] {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func requirePermissions(allowed func(granted map[string]bool) bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		permissions, ok := c.Get(string(PermissionsContextKey))
 		if !ok {
@@ -86,15 +130,12 @@ func HasPermission(requiredPermission string) gin.HandlerFunc {
 			return
 		}
 
-		hasPermission := false
+		granted := map[string]bool{}
 		for _, p := range permissions.([]string) {
-			if p == requiredPermission {
-				hasPermission = true
-				break
-			}
+			granted[p] = true
 		}
 
-		if !hasPermission {
+		if !allowed(granted) {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
 			return
 		}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const rateLimiterSweepInterval = time.Minute
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// RateLimiter is an in-memory token bucket limiter keyed by an arbitrary
+// string (usually the client IP). Buckets refill continuously at the
+// configured rate up to the burst size.
+type RateLimiter struct {
+	ratePerSecond float64
+	burst         float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewRateLimiter creates a limiter allowing requestsPerMinute on average with
+// bursts of up to burst requests.
+func NewRateLimiter(requestsPerMinute, burst int) *RateLimiter {
+	return &RateLimiter{
+		ratePerSecond: float64(requestsPerMinute) / 60,
+		burst:         float64(burst),
+		buckets:       map[string]*bucket{},
+		lastSweep:     time.Now(),
+	}
+}
+
+// Allow takes a token from the key's bucket. When the bucket is empty it
+// reports how long until the next token is available.
+func (l *RateLimiter) Allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.lastSeen).Seconds()*l.ratePerSecond)
+	b.lastSeen = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / l.ratePerSecond * float64(time.Second))
+	return false, wait
+}
+
+// sweep drops buckets that have refilled completely, as they are
+// indistinguishable from new ones. Must be called with the lock held.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimiterSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	fullAfter := time.Duration(l.burst / l.ratePerSecond * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) >= fullAfter {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimitByIP throttles requests per client IP, answering 429 with a
+// Retry-After header once the client runs out of tokens.
+func RateLimitByIP(limiter *RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed, retryAfter := limiter.Allow(c.ClientIP(), time.Now())
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests, try again later"})
+			return
+		}
+
+		c.Next()
+	}
+}
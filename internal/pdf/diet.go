@@ -0,0 +1,317 @@
+package pdf
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/mealtime"
+)
+
+const (
+	margin       = 50.0
+	contentWidth = PageWidth - 2*margin
+	// footerTop is where the content of a page ends
+	footerTop  = PageHeight - 60
+	logoHeight = 48.0
+)
+
+// DietOptions customizes the printed diet with the branding of the nutritionist
+type DietOptions struct {
+	ClinicName string
+	// HeaderLines are printed below the clinic name (address, phone, site)
+	HeaderLines      []string
+	Logo             image.Image
+	Accent           Color
+	ProfessionalName string
+	// ProfessionalRegistration is the CRN of the nutritionist
+	ProfessionalRegistration string
+	Footer                   string
+	// ShowNutrition adds the daily energy and macronutrients, when the diet
+	// has its nutrition calculated
+	ShowNutrition bool
+}
+
+var weekdayNames = map[entity.Weekday]string{
+	entity.Monday:    "segunda",
+	entity.Tuesday:   "terça",
+	entity.Wednesday: "quarta",
+	entity.Thursday:  "quinta",
+	entity.Friday:    "sexta",
+	entity.Saturday:  "sábado",
+	entity.Sunday:    "domingo",
+}
+
+// RenderDiet prints the diet for the patient: header of the clinic, name and
+// period of the diet, observations and the meals of each day in time order,
+// with the quantities and substitutes of the ingredients
+func RenderDiet(diet *entity.Diet, options DietOptions) ([]byte, error) {
+	r := &dietRenderer{doc: NewDocument(), options: options}
+	r.newPage()
+
+	r.title(diet)
+
+	if strings.TrimSpace(diet.Observations) != "" {
+		r.heading("Observações")
+		r.paragraph(diet.Observations, Helvetica, 10, Black, 0)
+	}
+
+	if len(diet.Meals) > 0 {
+		heading := "Refeições"
+		if len(diet.Days) > 0 {
+			heading = "Refeições dos demais dias"
+		}
+		r.heading(heading)
+		r.meals(diet.Meals)
+	}
+
+	for _, day := range diet.Days {
+		r.heading(dayHeading(day, diet.DayRule))
+		r.meals(day.Meals)
+	}
+
+	if options.ShowNutrition && diet.Nutrition != nil {
+		r.nutrition(diet.Nutrition)
+	}
+
+	r.footers()
+	return r.doc.Bytes()
+}
+
+// dietRenderer writes the diet top to bottom, breaking pages when the next
+// block does not fit
+type dietRenderer struct {
+	doc     *Document
+	options DietOptions
+	y       float64
+}
+
+func (r *dietRenderer) newPage() {
+	r.doc.AddPage()
+	r.y = margin
+	r.header()
+}
+
+// ensure breaks the page when height does not fit in the current one
+func (r *dietRenderer) ensure(height float64) {
+	if r.y+height > footerTop {
+		r.newPage()
+	}
+}
+
+// header prints the logo, the clinic name and its lines, repeated on every page
+func (r *dietRenderer) header() {
+	x := margin
+	height := 0.0
+
+	if r.options.Logo != nil {
+		bounds := r.options.Logo.Bounds()
+		if bounds.Dx() > 0 && bounds.Dy() > 0 {
+			width := logoHeight * float64(bounds.Dx()) / float64(bounds.Dy())
+			r.doc.Image(r.options.Logo, margin, r.y, width, logoHeight)
+			x += width + 12
+			height = logoHeight
+		}
+	}
+
+	textY := r.y
+	if r.options.ClinicName != "" {
+		textY += 16
+		r.doc.Text(x, textY, r.options.ClinicName, HelveticaBold, 14, r.options.Accent)
+	}
+	for _, line := range r.options.HeaderLines {
+		textY += 12
+		r.doc.Text(x, textY, line, Helvetica, 9, Gray)
+	}
+	if textY-r.y > height {
+		height = textY - r.y
+	}
+
+	if height > 0 {
+		r.y += height + 10
+		r.doc.Line(margin, r.y, PageWidth-margin, r.y, 1.5, r.options.Accent)
+		r.y += 20
+	}
+}
+
+func (r *dietRenderer) title(diet *entity.Diet) {
+	for _, line := range HelveticaBold.Wrap(diet.DietName, 20, contentWidth) {
+		r.ensure(24)
+		r.y += 20
+		r.doc.Text(margin, r.y, line, HelveticaBold, 20, Black)
+		r.y += 4
+	}
+	r.y += 6
+
+	details := []string{"Paciente: " + diet.UserEmail}
+	if professional := r.professional(); professional != "" {
+		details = append(details, "Nutricionista: "+professional)
+	}
+	if period := dietPeriod(diet); period != "" {
+		details = append(details, period)
+	}
+	for _, detail := range details {
+		r.paragraph(detail, Helvetica, 10, Gray, 0)
+	}
+	r.y += 6
+}
+
+func (r *dietRenderer) professional() string {
+	name := r.options.ProfessionalName
+	if r.options.ProfessionalRegistration != "" {
+		if name != "" {
+			name += " - "
+		}
+		name += "CRN " + r.options.ProfessionalRegistration
+	}
+	return name
+}
+
+func (r *dietRenderer) heading(text string) {
+	// O título não fica sozinho no fim da página
+	r.ensure(60)
+	r.y += 22
+	r.doc.Text(margin, r.y, text, HelveticaBold, 13, r.options.Accent)
+	r.y += 5
+	r.doc.Line(margin, r.y, PageWidth-margin, r.y, 0.5, Gray)
+	r.y += 6
+}
+
+// paragraph wraps the text in the content width, starting at indent
+func (r *dietRenderer) paragraph(text string, font *Font, size float64, color Color, indent float64) {
+	lineHeight := size * 1.35
+	for _, line := range font.Wrap(text, size, contentWidth-indent) {
+		r.ensure(lineHeight)
+		r.y += lineHeight
+		r.doc.Text(margin+indent, r.y, line, font, size, color)
+	}
+}
+
+func (r *dietRenderer) meals(meals []entity.Meal) {
+	sorted := append([]entity.Meal(nil), meals...)
+	mealtime.Sort(sorted)
+
+	for _, meal := range sorted {
+		r.ensure(40)
+		r.y += 8
+		r.paragraph(mealTitle(meal), HelveticaBold, 11, Black, 0)
+		if meal.Description != "" {
+			r.paragraph(meal.Description, Helvetica, 9, Gray, 0)
+		}
+
+		for _, ingredient := range meal.Ingredients {
+			r.paragraph("• "+ingredientLine(ingredient), Helvetica, 10, Black, 10)
+			for _, substitute := range ingredient.Substitutes {
+				r.paragraph("ou "+ingredientLine(substitute), Helvetica, 9, Gray, 22)
+			}
+		}
+	}
+}
+
+func (r *dietRenderer) nutrition(nutrition *entity.DietNutrition) {
+	r.heading("Valores nutricionais por dia")
+	perDay := nutrition.PerDay
+	r.paragraph(fmt.Sprintf("Energia: %s kcal", formatNumber(perDay.EnergyKcal, 0)), Helvetica, 10, Black, 0)
+	r.paragraph(fmt.Sprintf("Proteínas: %s g (%s%%)  Carboidratos: %s g (%s%%)  Gorduras: %s g (%s%%)",
+		formatNumber(perDay.ProteinG, 1), formatNumber(nutrition.MacroSplit.ProteinPct, 0),
+		formatNumber(perDay.CarbohydrateG, 1), formatNumber(nutrition.MacroSplit.CarbohydratePct, 0),
+		formatNumber(perDay.FatG, 1), formatNumber(nutrition.MacroSplit.FatPct, 0)), Helvetica, 10, Black, 0)
+	if len(nutrition.Unresolved) > 0 {
+		r.paragraph("Valores aproximados: alguns ingredientes não têm composição conhecida.", Helvetica, 8, Gray, 0)
+	}
+}
+
+// footers writes the footer text and the page numbers once every page exists
+func (r *dietRenderer) footers() {
+	total := r.doc.PageCount()
+	for page := 1; page <= total; page++ {
+		r.doc.SetPage(page)
+		r.doc.Line(margin, footerTop+15, PageWidth-margin, footerTop+15, 0.5, Gray)
+
+		if r.options.Footer != "" {
+			r.doc.Text(margin, footerTop+30, r.options.Footer, Helvetica, 8, Gray)
+		}
+		number := fmt.Sprintf("Página %d de %d", page, total)
+		r.doc.Text(PageWidth-margin-Helvetica.Width(number, 8), footerTop+30, number, Helvetica, 8, Gray)
+	}
+}
+
+// mealTitle writes "07:30 · Café da manhã (07:00 às 08:00)"
+func mealTitle(meal entity.Meal) string {
+	title := meal.Name
+	if meal.TimeOfDay != "" {
+		title = meal.TimeOfDay + " · " + title
+	}
+	if meal.WindowStart != "" && meal.WindowEnd != "" {
+		title += fmt.Sprintf(" (%s às %s)", meal.WindowStart, meal.WindowEnd)
+	}
+	return title
+}
+
+// ingredientLine writes "Arroz integral: 4 colher de sopa"
+func ingredientLine(ingredient entity.Ingredient) string {
+	if ingredient.Quantity == 0 {
+		return ingredient.Description
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s: %s %s", ingredient.Description, formatNumber(ingredient.Quantity, 2), ingredient.Unit))
+}
+
+// dayHeading names the day template with the days it applies to
+func dayHeading(day entity.DayTemplate, rule *entity.DayRule) string {
+	if rule == nil {
+		return day.Name
+	}
+
+	switch rule.Type {
+	case entity.DayRuleWeekday:
+		names := make([]string, 0, len(day.Weekdays))
+		for _, weekday := range day.Weekdays {
+			names = append(names, weekdayNames[weekday])
+		}
+		if len(names) > 0 {
+			return fmt.Sprintf("%s (%s)", day.Name, strings.Join(names, ", "))
+		}
+	case entity.DayRuleRotation:
+		var positions []string
+		for i, name := range rule.Rotation {
+			if name == day.Name {
+				positions = append(positions, strconv.Itoa(i+1))
+			}
+		}
+		if len(positions) > 0 {
+			return fmt.Sprintf("%s (dia %s do ciclo de %d)", day.Name, strings.Join(positions, ", "), len(rule.Rotation))
+		}
+	}
+	return day.Name
+}
+
+// dietPeriod writes "Período: 01/03/2025 a 30/03/2025 (30 dias)"
+func dietPeriod(diet *entity.Diet) string {
+	if diet.StartsAt == nil {
+		if diet.DurationInDays == 0 {
+			return ""
+		}
+		return fmt.Sprintf("Duração: %d dias", diet.DurationInDays)
+	}
+
+	period := "Período: " + diet.StartsAt.Format("02/01/2006")
+	if diet.EndsAt != nil {
+		// EndsAt é o instante em que a dieta termina, o último dia é o anterior
+		period += " a " + diet.EndsAt.AddDate(0, 0, -1).Format("02/01/2006")
+	}
+	if diet.DurationInDays > 0 {
+		period += fmt.Sprintf(" (%d dias)", diet.DurationInDays)
+	}
+	return period
+}
+
+// formatNumber uses the decimal comma and drops trailing zeros
+func formatNumber(value float64, decimals int) string {
+	formatted := strconv.FormatFloat(value, 'f', decimals, 64)
+	if strings.Contains(formatted, ".") {
+		formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+	}
+	return strings.Replace(formatted, ".", ",", 1)
+}
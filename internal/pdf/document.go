@@ -0,0 +1,242 @@
+// Package pdf writes simple PDF documents (text, lines, rectangles and
+// images) using only the standard library. Text uses the standard Helvetica
+// fonts, which every PDF reader provides, with the WinAnsi encoding.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Color is an RGB color with components from 0 to 255
+type Color struct {
+	R, G, B uint8
+}
+
+var (
+	Black = Color{0, 0, 0}
+	Gray  = Color{110, 110, 110}
+)
+
+// ParseColor parses a #RRGGBB color
+func ParseColor(hex string) (Color, error) {
+	var c Color
+	if _, err := fmt.Sscanf(strings.TrimPrefix(hex, "#"), "%02x%02x%02x", &c.R, &c.G, &c.B); err != nil || len(strings.TrimPrefix(hex, "#")) != 6 {
+		return Color{}, fmt.Errorf("cor inválida %q, use #RRGGBB", hex)
+	}
+	return c, nil
+}
+
+func (c Color) operands() string {
+	return fmt.Sprintf("%.3f %.3f %.3f", float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+}
+
+// Document is a PDF being written. Coordinates are in points with the origin
+// at the top-left corner of the page.
+type Document struct {
+	pages   []*bytes.Buffer
+	current int
+	images  []image.Image
+}
+
+// NewDocument creates an empty document
+func NewDocument() *Document {
+	return &Document{}
+}
+
+// AddPage starts a new A4 page, which receives the following drawing calls
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.current = len(d.pages) - 1
+}
+
+// PageCount returns the number of pages
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// SetPage makes a previous page (starting at 1) receive the drawing calls,
+// used to write footers once the number of pages is known
+func (d *Document) SetPage(number int) {
+	d.current = number - 1
+}
+
+func (d *Document) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	return d.pages[d.current]
+}
+
+// Text writes a line of text with its baseline at y
+func (d *Document) Text(x, y float64, text string, font *Font, size float64, color Color) {
+	fmt.Fprintf(d.page(), "BT /%s %.2f Tf %s rg %.2f %.2f Td (%s) Tj ET\n",
+		font.resource(), size, color.operands(), x, PageHeight-y, escape(encode(text)))
+}
+
+// Line draws a line
+func (d *Document) Line(x1, y1, x2, y2, width float64, color Color) {
+	fmt.Fprintf(d.page(), "%.2f w %s RG %.2f %.2f m %.2f %.2f l S\n",
+		width, color.operands(), x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// Rect fills a rectangle whose top-left corner is at x, y
+func (d *Document) Rect(x, y, width, height float64, color Color) {
+	fmt.Fprintf(d.page(), "%s rg %.2f %.2f %.2f %.2f re f\n",
+		color.operands(), x, PageHeight-y-height, width, height)
+}
+
+// Image draws the image with its top-left corner at x, y
+func (d *Document) Image(img image.Image, x, y, width, height float64) {
+	d.images = append(d.images, img)
+	fmt.Fprintf(d.page(), "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n",
+		width, height, x, PageHeight-y-height, len(d.images))
+}
+
+// Bytes serializes the document
+func (d *Document) Bytes() ([]byte, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	w := &writer{}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: catálogo, 2: árvore de páginas, 3 e 4: fontes, depois imagens e páginas
+	const fontsStart = 3
+	imagesStart := fontsStart + len(fonts)
+	pagesStart := imagesStart + len(d.images)
+
+	w.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, 0, len(d.pages))
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", pagesStart+i*2))
+	}
+	w.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	var resources strings.Builder
+	resources.WriteString("<< /Font <<")
+	for i, font := range fonts {
+		fmt.Fprintf(&resources, " /%s %d 0 R", font.resource(), fontsStart+i)
+		w.object(fontsStart+i, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font.name))
+	}
+	resources.WriteString(" >>")
+	if len(d.images) > 0 {
+		resources.WriteString(" /XObject <<")
+		for i := range d.images {
+			fmt.Fprintf(&resources, " /Im%d %d 0 R", i+1, imagesStart+i)
+		}
+		resources.WriteString(" >>")
+	}
+	resources.WriteString(" >>")
+
+	for i, img := range d.images {
+		data, err := deflate(rgb(img))
+		if err != nil {
+			return nil, err
+		}
+		bounds := img.Bounds()
+		w.stream(imagesStart+i, fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode",
+			bounds.Dx(), bounds.Dy()), data)
+	}
+
+	for i, content := range d.pages {
+		pageID := pagesStart + i*2
+		w.object(pageID, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources %s /Contents %d 0 R >>",
+			PageWidth, PageHeight, resources.String(), pageID+1))
+
+		data, err := deflate(content.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		w.stream(pageID+1, "/Filter /FlateDecode", data)
+	}
+
+	w.trailer(pagesStart + len(d.pages)*2)
+	return w.buf.Bytes(), nil
+}
+
+// writer keeps the offsets of the objects for the cross-reference table
+type writer struct {
+	buf     bytes.Buffer
+	offsets map[int]int
+}
+
+func (w *writer) object(id int, body string) {
+	w.begin(id)
+	w.buf.WriteString(body)
+	w.buf.WriteString("\nendobj\n")
+}
+
+func (w *writer) stream(id int, dict string, data []byte) {
+	w.begin(id)
+	fmt.Fprintf(&w.buf, "<< %s /Length %d >>\nstream\n", dict, len(data))
+	w.buf.Write(data)
+	w.buf.WriteString("\nendstream\nendobj\n")
+}
+
+func (w *writer) begin(id int) {
+	if w.offsets == nil {
+		w.offsets = map[int]int{}
+	}
+	w.offsets[id] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n", id)
+}
+
+func (w *writer) trailer(size int) {
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", size)
+	for id := 1; id < size; id++ {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", w.offsets[id])
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, xref)
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var out bytes.Buffer
+	zw := zlib.NewWriter(&out)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// rgb flattens the image to 8-bit RGB, compositing transparency over white
+func rgb(img image.Image) []byte {
+	bounds := img.Bounds()
+	data := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			white := 0xffff - a
+			data = append(data, byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8))
+		}
+	}
+	return data
+}
+
+// escape protects the delimiters of a PDF string
+func escape(text []byte) string {
+	var b strings.Builder
+	for _, c := range text {
+		switch c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
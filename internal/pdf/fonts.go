@@ -0,0 +1,119 @@
+package pdf
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+// Font is one of the standard fonts available in every PDF reader
+type Font struct {
+	name string
+	// widths of the printable ASCII characters (32 to 126) in thousandths of
+	// the font size, from the Adobe font metrics
+	widths [95]int
+}
+
+var (
+	Helvetica = &Font{name: "Helvetica", widths: [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}}
+	HelveticaBold = &Font{name: "Helvetica-Bold", widths: [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}}
+)
+
+// fonts are the fonts declared in every page, in resource order
+var fonts = []*Font{Helvetica, HelveticaBold}
+
+func (f *Font) resource() string {
+	for i, font := range fonts {
+		if font == f {
+			return "F" + string(rune('1'+i))
+		}
+	}
+	return "F1"
+}
+
+// Width returns the width of the text in points
+func (f *Font) Width(text string, size float64) float64 {
+	total := 0
+	for _, r := range text {
+		total += f.runeWidth(r)
+	}
+	return float64(total) * size / 1000
+}
+
+// runeWidth measures accented letters by their base letter
+func (f *Font) runeWidth(r rune) int {
+	if r < 32 || r > 126 {
+		base := []rune(utils.NormalizeText(string(r)))
+		if len(base) != 1 || base[0] < 32 || base[0] > 126 {
+			return 556
+		}
+		if unicode.IsUpper(r) {
+			base[0] = unicode.ToUpper(base[0])
+		}
+		r = base[0]
+	}
+	return f.widths[r-32]
+}
+
+// Wrap breaks the text into lines that fit the width, keeping explicit line
+// breaks. Words longer than the width are left on their own line.
+func (f *Font) Wrap(text string, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.FieldsFunc(paragraph, unicode.IsSpace)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		line := words[0]
+		for _, word := range words[1:] {
+			if f.Width(line+" "+word, size) > width {
+				lines = append(lines, line)
+				line = word
+				continue
+			}
+			line += " " + word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// winAnsi maps the characters outside Latin-1 that WinAnsiEncoding supports
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '•': 0x95, '–': 0x96, '—': 0x97,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '™': 0x99, 'œ': 0x9c, 'Œ': 0x8c,
+}
+
+// encode converts the text to WinAnsiEncoding, replacing unsupported
+// characters with "?"
+func encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r >= 32 && r <= 126, r >= 0xa0 && r <= 0xff:
+			encoded = append(encoded, byte(r))
+		case winAnsi[r] != 0:
+			encoded = append(encoded, winAnsi[r])
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return encoded
+}
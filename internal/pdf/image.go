@@ -0,0 +1,50 @@
+package pdf
+
+import (
+	"image"
+	"image/color"
+)
+
+// Downscale shrinks the image to fit maxWidth x maxHeight, keeping the aspect
+// ratio. Each pixel is the average of the pixels it covers, so thin lines of a
+// logo fade instead of vanishing. Images that already fit are returned as is.
+func Downscale(img image.Image, maxWidth, maxHeight int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxWidth && height <= maxHeight {
+		return img
+	}
+
+	scale := min(float64(maxWidth)/float64(width), float64(maxHeight)/float64(height))
+	newWidth := max(1, int(float64(width)*scale))
+	newHeight := max(1, int(float64(height)*scale))
+
+	scaled := image.NewNRGBA(image.Rect(0, 0, newWidth, newHeight))
+	for y := 0; y < newHeight; y++ {
+		top := bounds.Min.Y + y*height/newHeight
+		bottom := max(top+1, bounds.Min.Y+(y+1)*height/newHeight)
+		for x := 0; x < newWidth; x++ {
+			left := bounds.Min.X + x*width/newWidth
+			right := max(left+1, bounds.Min.X+(x+1)*width/newWidth)
+
+			var r, g, b, a, count uint64
+			for sy := top; sy < bottom; sy++ {
+				for sx := left; sx < right; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					count++
+				}
+			}
+
+			// RGBA devolve cores pré-multiplicadas pelo alfa
+			pixel := color.RGBA64{
+				R: uint16(r / count),
+				G: uint16(g / count),
+				B: uint16(b / count),
+				A: uint16(a / count),
+			}
+			scaled.Set(x, y, pixel)
+		}
+	}
+	return scaled
+}
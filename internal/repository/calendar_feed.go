@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	calendarFeedCollectionName = "calendar_feeds"
+)
+
+// CalendarFeedRepository implements the usecase.CalendarFeedRepository interface using MongoDB.
+type CalendarFeedRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+// NewCalendarFeedRepository creates a new CalendarFeedRepository.
+func NewCalendarFeedRepository(cfg *utils.EnvConfig) (*CalendarFeedRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CalendarFeedRepository{
+		client:     client,
+		database:   cfg.DBName,
+		collection: calendarFeedCollectionName,
+	}, nil
+}
+
+// Save cria ou substitui o feed do usuário, invalidando o token anterior
+func (r *CalendarFeedRepository) Save(ctx context.Context, feed *entity.CalendarFeed) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.ReplaceOne(ctx, bson.M{"_id": feed.UserID}, feed, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *CalendarFeedRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.CalendarFeed, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	var feed entity.CalendarFeed
+	err := collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&feed)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (r *CalendarFeedRepository) Delete(ctx context.Context, userID string) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": userID})
+	return err
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"log"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -16,7 +18,14 @@ import (
 )
 
 const (
-	dietCollectionName = "diets"
+	dietCollectionName             = "diets"
+	dietCalendarLockCollectionName = "diet_calendar_locks"
+
+	// calendarLockLease libera o calendário de quem travou e caiu antes de soltar
+	calendarLockLease = 30 * time.Second
+	// calendarLockWait é o tempo máximo de espera por outra requisição
+	calendarLockWait  = 5 * time.Second
+	calendarLockRetry = 50 * time.Millisecond
 )
 
 type DietRepository struct {
@@ -57,11 +66,15 @@ func (r *DietRepository) CreateDiet(ctx context.Context, diet *entity.Diet) erro
 	diet.CreatedAt = time.Now()
 	diet.UpdatedAt = time.Now()
 
-	_, err := collection.InsertOne(ctx, diet)
+	result, err := collection.InsertOne(ctx, diet)
 	if err != nil {
 		return err
 	}
 
+	if objID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		diet.ID = objID.Hex()
+	}
+
 	return nil
 }
 
@@ -69,12 +82,16 @@ func (r *DietRepository) GetDietByID(ctx context.Context, id string) (*entity.Di
 	collection := r.client.Database(r.database).Collection(r.collection)
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, err
+		// IDs malformados nunca correspondem a uma dieta
+		return nil, nil
 	}
 
 	var diet entity.Diet
 	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&diet)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -92,11 +109,66 @@ func (r *DietRepository) FindDiets(ctx context.Context, filter *usecase.DietFilt
 		mongoFilter["user_email"] = *filter.UserEmail
 	}
 
+	if filter.UserEmails != nil {
+		mongoFilter["user_email"] = bson.M{"$in": filter.UserEmails}
+	}
+
 	if filter.CreatedBy != nil {
 		mongoFilter["created_by"] = *filter.CreatedBy
 	}
 
-	cursor, err := collection.Find(ctx, mongoFilter)
+	// Dietas removidas ficam fora da listagem, a menos que pedido explicitamente
+	if !filter.IncludeDeleted {
+		mongoFilter["deleted_at"] = nil
+	}
+
+	if filter.Status != nil {
+		mongoFilter["status"] = *filter.Status
+	}
+
+	if filter.Statuses != nil {
+		mongoFilter["status"] = bson.M{"$in": filter.Statuses}
+	}
+
+	if filter.ExcludeStatuses != nil {
+		mongoFilter["$nor"] = bson.A{bson.M{"status": bson.M{"$in": filter.ExcludeStatuses}}}
+	}
+
+	if filter.NameContains != nil {
+		mongoFilter["name"] = bson.M{"$regex": regexp.QuoteMeta(*filter.NameContains), "$options": "i"}
+	}
+
+	if dateRange := rangeFilter(filter.CreatedFrom, filter.CreatedTo); dateRange != nil {
+		mongoFilter["created_at"] = dateRange
+	}
+
+	if dateRange := rangeFilter(filter.UpdatedFrom, filter.UpdatedTo); dateRange != nil {
+		mongoFilter["updated_at"] = dateRange
+	}
+
+	findOptions := options.Find()
+	if filter.SortBy != "" {
+		direction := 1
+		if filter.SortDesc {
+			direction = -1
+		}
+		// _id desempata dietas com o mesmo valor no campo de ordenação
+		findOptions.SetSort(bson.D{{Key: filter.SortBy, Value: direction}, {Key: "_id", Value: direction}})
+	}
+
+	if filter.Limit > 0 {
+		findOptions.SetLimit(filter.Limit)
+	}
+
+	if filter.After != nil {
+		after, err := afterCursorFilter(filter.After)
+		if err != nil {
+			return nil, err
+		}
+		mongoFilter = bson.M{"$and": bson.A{mongoFilter, after}}
+	}
+
+	cursor, err := collection.Find(ctx, mongoFilter, findOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -110,20 +182,69 @@ func (r *DietRepository) FindDiets(ctx context.Context, filter *usecase.DietFilt
 	return diets, nil
 }
 
-// UpdateDiet atualiza uma dieta existente
-func (r *DietRepository) UpdateDiet(ctx context.Context, diet *entity.Diet) error {
+// rangeFilter monta o intervalo de datas, com início e fim inclusivos
+func rangeFilter(from, to *time.Time) bson.M {
+	if from == nil && to == nil {
+		return nil
+	}
+
+	dateRange := bson.M{}
+	if from != nil {
+		dateRange["$gte"] = *from
+	}
+	if to != nil {
+		dateRange["$lte"] = *to
+	}
+	return dateRange
+}
+
+// afterCursorFilter seleciona as dietas posteriores ao cursor na ordem
+// (campo de ordenação, _id)
+func afterCursorFilter(cursor *usecase.DietCursor) (bson.M, error) {
+	lastID, err := primitive.ObjectIDFromHex(cursor.LastID)
+	if err != nil {
+		return nil, usecase.ErrInvalidCursor
+	}
+
+	var lastValue interface{} = cursor.LastTime
+	if cursor.SortBy == usecase.DietSortName {
+		lastValue = cursor.LastName
+	}
+
+	operator := "$gt"
+	if cursor.Desc {
+		operator = "$lt"
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{cursor.SortBy: bson.M{operator: lastValue}},
+		bson.M{cursor.SortBy: lastValue, "_id": bson.M{operator: lastID}},
+	}}, nil
+}
+
+// UpdateDiet atualiza uma dieta existente somente se ela ainda estiver na
+// versão esperada, incrementando a versão. Retorna usecase.ErrVersionConflict
+// quando outra requisição alterou a dieta antes.
+func (r *DietRepository) UpdateDiet(ctx context.Context, diet *entity.Diet, expectedVersion int64) error {
 	collection := r.client.Database(r.database).Collection(r.collection)
 
 	diet.UpdatedAt = time.Now()
+	diet.Version = expectedVersion + 1
 
 	update := bson.M{
 		"$set": bson.M{
 			"name":             diet.DietName,
 			"duration_in_days": diet.DurationInDays,
 			"status":           diet.Status,
+			"starts_at":        diet.StartsAt,
+			"ends_at":          diet.EndsAt,
 			"meals":            diet.Meals,
+			"days":             diet.Days,
+			"day_rule":         diet.DayRule,
 			"observations":     diet.Observations,
+			"texture":          diet.Texture,
 			"updated_at":       diet.UpdatedAt,
+			"version":          diet.Version,
 		},
 	}
 
@@ -132,11 +253,129 @@ func (r *DietRepository) UpdateDiet(ctx context.Context, diet *entity.Diet) erro
 		return err
 	}
 
-	_, err = collection.UpdateOne(
-		ctx,
-		bson.M{"_id": objID, "user_email": diet.UserEmail}, // Garante que só o dono pode atualizar
-		update,
+	filter := bson.M{
+		"_id":        objID,
+		"user_email": diet.UserEmail, // Garante que só o dono pode atualizar
+		"deleted_at": nil,
+		"version":    expectedVersion,
+	}
+	if expectedVersion == 0 {
+		// Dietas criadas antes do controle de versão não têm o campo
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return usecase.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// SoftDeleteDiet marca a dieta como removida sem apagar o documento
+func (r *DietRepository) SoftDeleteDiet(ctx context.Context, id, deletedBy string, deletedAt time.Time) error {
+	return r.updateByID(ctx, id, bson.M{
+		"$set": bson.M{
+			"deleted_at": deletedAt,
+			"deleted_by": deletedBy,
+			"updated_at": deletedAt,
+		},
+		"$inc": bson.M{"version": 1},
+	})
+}
+
+// RestoreDiet desfaz a remoção de uma dieta
+func (r *DietRepository) RestoreDiet(ctx context.Context, id string) error {
+	return r.updateByID(ctx, id, bson.M{
+		"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$inc":   bson.M{"version": 1},
+	})
+}
+
+func (r *DietRepository) updateByID(ctx context.Context, id string, update bson.M) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return usecase.ErrDietNotFound
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return usecase.ErrDietNotFound
+	}
+
+	return nil
+}
+
+// LockCalendar trava o calendário do paciente, esperando enquanto outra
+// requisição o mantém. O documento de trava só é criado quando não existe ou
+// a trava anterior expirou; nos demais casos o upsert esbarra no _id.
+func (r *DietRepository) LockCalendar(ctx context.Context, userEmail string) (func(), error) {
+	collection := r.client.Database(r.database).Collection(dietCalendarLockCollectionName)
+	owner := primitive.NewObjectID()
+	deadline := time.Now().Add(calendarLockWait)
+
+	for {
+		now := time.Now()
+		_, err := collection.UpdateOne(ctx,
+			bson.M{"_id": userEmail, "locked_until": bson.M{"$lte": now}},
+			bson.M{"$set": bson.M{"owner": owner, "locked_until": now.Add(calendarLockLease)}},
+			options.Update().SetUpsert(true),
+		)
+		if err == nil {
+			break
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+		if now.After(deadline) {
+			return nil, usecase.ErrCalendarBusy
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(calendarLockRetry):
+		}
+	}
+
+	unlock := func() {
+		// A trava é solta mesmo quando a requisição já foi cancelada
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := collection.DeleteOne(ctx, bson.M{"_id": userEmail, "owner": owner}); err != nil {
+			log.Printf("[DietRepository] Failed to unlock calendar of %s: %v", userEmail, err)
+		}
+	}
+	return unlock, nil
+}
+
+// NormalizeUserEmails grava o email do paciente das dietas em minúsculas e
+// sem espaços nas pontas, como os casos de uso o buscam
+func (r *DietRepository) NormalizeUserEmails(ctx context.Context) (int, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	normalized := bson.M{"$toLower": bson.M{"$trim": bson.M{"input": "$user_email"}}}
+	result, err := collection.UpdateMany(ctx,
+		bson.M{
+			"user_email": bson.M{"$type": "string"},
+			"$expr":      bson.M{"$ne": bson.A{"$user_email", normalized}},
+		},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"user_email": normalized}}}},
 	)
+	if err != nil {
+		return 0, err
+	}
 
-	return err
+	return int(result.ModifiedCount), nil
 }
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	dietRevisionCollectionName = "diet_revisions"
+)
+
+// DietRevisionRepository implements the usecase.DietRevisionRepository interface using MongoDB.
+// Revisions are append-only: there is no update or delete.
+type DietRevisionRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+// NewDietRevisionRepository creates a new DietRevisionRepository.
+func NewDietRevisionRepository(cfg *utils.EnvConfig) (*DietRevisionRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The number identifies the revision within the diet, so concurrent writes
+	// cannot share it
+	_, err = client.Database(cfg.DBName).Collection(dietRevisionCollectionName).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "diet_id", Value: 1}, {Key: "number", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating the diet revision index: %w", err)
+	}
+
+	return &DietRevisionRepository{
+		client:     client,
+		database:   cfg.DBName,
+		collection: dietRevisionCollectionName,
+	}, nil
+}
+
+func (r *DietRevisionRepository) Create(ctx context.Context, revision *entity.DietRevision) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.InsertOne(ctx, revision)
+	if mongo.IsDuplicateKeyError(err) {
+		return usecase.ErrRevisionExists
+	}
+	return err
+}
+
+func (r *DietRevisionRepository) FindByDiet(ctx context.Context, dietID string) ([]*entity.DietRevision, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	cursor, err := collection.Find(ctx, bson.M{"diet_id": dietID}, options.Find().SetSort(bson.M{"number": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []*entity.DietRevision{}
+	if err = cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (r *DietRevisionRepository) FindByNumber(ctx context.Context, dietID string, number int) (*entity.DietRevision, error) {
+	return r.findOne(ctx, bson.M{"diet_id": dietID, "number": number}, options.FindOne())
+}
+
+func (r *DietRevisionRepository) FindLatest(ctx context.Context, dietID string) (*entity.DietRevision, error) {
+	return r.findOne(ctx, bson.M{"diet_id": dietID}, options.FindOne().SetSort(bson.M{"number": -1}))
+}
+
+func (r *DietRevisionRepository) findOne(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*entity.DietRevision, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	var revision entity.DietRevision
+	err := collection.FindOne(ctx, filter, opts).Decode(&revision)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
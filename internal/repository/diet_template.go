@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	dietTemplateCollectionName = "diet_templates"
+)
+
+// DietTemplateRepository implements the usecase.DietTemplateRepository interface using MongoDB.
+type DietTemplateRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+// NewDietTemplateRepository creates a new DietTemplateRepository.
+func NewDietTemplateRepository(cfg *utils.EnvConfig) (*DietTemplateRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DietTemplateRepository{
+		client:     client,
+		database:   cfg.DBName,
+		collection: dietTemplateCollectionName,
+	}, nil
+}
+
+func (r *DietTemplateRepository) Create(ctx context.Context, template *entity.DietTemplate) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.InsertOne(ctx, template)
+	return err
+}
+
+func (r *DietTemplateRepository) FindByID(ctx context.Context, id string) (*entity.DietTemplate, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	var template entity.DietTemplate
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&template)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *DietTemplateRepository) FindByOwner(ctx context.Context, ownerID string) ([]*entity.DietTemplate, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	cursor, err := collection.Find(ctx, bson.M{"owner_id": ownerID}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	templates := []*entity.DietTemplate{}
+	if err = cursor.All(ctx, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *DietTemplateRepository) Update(ctx context.Context, template *entity.DietTemplate) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	result, err := collection.ReplaceOne(ctx, bson.M{"_id": template.ID}, template)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return usecase.ErrDietTemplateNotFound
+	}
+	return nil
+}
+
+func (r *DietTemplateRepository) Delete(ctx context.Context, id string) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return usecase.ErrDietTemplateNotFound
+	}
+	return nil
+}
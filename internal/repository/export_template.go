@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	exportTemplateCollectionName = "export_templates"
+)
+
+// ExportTemplateRepository implements the usecase.ExportTemplateRepository interface using MongoDB.
+type ExportTemplateRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+// NewExportTemplateRepository creates a new ExportTemplateRepository.
+func NewExportTemplateRepository(cfg *utils.EnvConfig) (*ExportTemplateRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportTemplateRepository{
+		client:     client,
+		database:   cfg.DBName,
+		collection: exportTemplateCollectionName,
+	}, nil
+}
+
+func (r *ExportTemplateRepository) FindByOwner(ctx context.Context, ownerID string) (*entity.ExportTemplate, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	var template entity.ExportTemplate
+	err := collection.FindOne(ctx, bson.M{"_id": ownerID}).Decode(&template)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Save cria ou substitui o modelo de exportação do nutricionista
+func (r *ExportTemplateRepository) Save(ctx context.Context, template *entity.ExportTemplate) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.ReplaceOne(ctx, bson.M{"_id": template.OwnerID}, template, options.Replace().SetUpsert(true))
+	return err
+}
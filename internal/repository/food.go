@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	foodCollectionName = "foods"
+
+	// foodUpsertBatchSize limita o tamanho de cada bulk write na importação
+	foodUpsertBatchSize = 1000
+)
+
+// FoodRepository implements the usecase.FoodRepository interface using MongoDB.
+type FoodRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+// NewFoodRepository creates a new FoodRepository.
+func NewFoodRepository(cfg *utils.EnvConfig) (*FoodRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FoodRepository{
+		client:     client,
+		database:   cfg.DBName,
+		collection: foodCollectionName,
+	}, nil
+}
+
+func (r *FoodRepository) UpsertMany(ctx context.Context, foods []*entity.Food) (int, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	total := 0
+	for start := 0; start < len(foods); start += foodUpsertBatchSize {
+		end := start + foodUpsertBatchSize
+		if end > len(foods) {
+			end = len(foods)
+		}
+
+		models := make([]mongo.WriteModel, 0, end-start)
+		for _, food := range foods[start:end] {
+			// $set em vez de replace para não apagar as medidas cadastradas
+			// manualmente (densidade e pesos por medida) ao reimportar a tabela
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": food.ID}).
+				SetUpdate(bson.M{"$set": food}).
+				SetUpsert(true))
+		}
+
+		result, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return total, err
+		}
+		total += int(result.UpsertedCount + result.ModifiedCount)
+	}
+
+	return total, nil
+}
+
+func (r *FoodRepository) FindByID(ctx context.Context, id string) (*entity.Food, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	var food entity.Food
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&food)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &food, nil
+}
+
+func (r *FoodRepository) UpdateMeasures(ctx context.Context, id string, densityGPerMl float64, gramWeights map[string]float64) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	update := bson.M{}
+	set := bson.M{}
+	unset := bson.M{}
+
+	if densityGPerMl > 0 {
+		set["density_g_per_ml"] = densityGPerMl
+	} else {
+		unset["density_g_per_ml"] = ""
+	}
+	if len(gramWeights) > 0 {
+		set["gram_weights"] = gramWeights
+	} else {
+		unset["gram_weights"] = ""
+	}
+
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return usecase.ErrFoodNotFound
+	}
+	return nil
+}
+
+func (r *FoodRepository) FindByIDs(ctx context.Context, ids []string) ([]*entity.Food, error) {
+	return r.find(ctx, bson.M{"_id": bson.M{"$in": ids}}, options.Find())
+}
+
+func (r *FoodRepository) Search(ctx context.Context, filter *usecase.FoodFilter) ([]*entity.Food, error) {
+	conditions := bson.A{}
+	for _, term := range filter.Terms {
+		conditions = append(conditions, bson.M{"normalized_name": bson.M{"$regex": regexp.QuoteMeta(term)}})
+	}
+
+	mongoFilter := bson.M{}
+	if len(conditions) > 0 {
+		mongoFilter["$and"] = conditions
+	}
+
+	if filter.Source != nil {
+		mongoFilter["source"] = *filter.Source
+	}
+
+	findOptions := options.Find().SetSort(bson.M{"normalized_name": 1})
+	if filter.Limit > 0 {
+		findOptions.SetLimit(filter.Limit)
+	}
+
+	return r.find(ctx, mongoFilter, findOptions)
+}
+
+func (r *FoodRepository) find(ctx context.Context, filter bson.M, findOptions *options.FindOptions) ([]*entity.Food, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	foods := []*entity.Food{}
+	if err = cursor.All(ctx, &foods); err != nil {
+		return nil, err
+	}
+	return foods, nil
+}
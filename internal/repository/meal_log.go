@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	mealLogCollectionName = "meal_logs"
+)
+
+// MealLogRepository implements the usecase.MealLogRepository interface using MongoDB.
+type MealLogRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+// NewMealLogRepository creates a new MealLogRepository.
+func NewMealLogRepository(cfg *utils.EnvConfig) (*MealLogRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Upsert relies on a single log per meal and day of the diet
+	_, err = client.Database(cfg.DBName).Collection(mealLogCollectionName).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "diet_id", Value: 1}, {Key: "meal_name", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating the meal log index: %w", err)
+	}
+
+	return &MealLogRepository{
+		client:     client,
+		database:   cfg.DBName,
+		collection: mealLogCollectionName,
+	}, nil
+}
+
+// Upsert grava o registro da refeição no dia, substituindo o anterior. Um novo
+// registro descarta a revisão do nutricionista, que se referia ao anterior.
+func (r *MealLogRepository) Upsert(ctx context.Context, log *entity.MealLog) (*entity.MealLog, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	filter := bson.M{"diet_id": log.DietID, "meal_name": log.MealName, "date": log.Date}
+	update := bson.M{
+		"$set": bson.M{
+			"patient_id": log.PatientID,
+			"status":     log.Status,
+			"substitute": log.Substitute,
+			"notes":      log.Notes,
+			"updated_at": log.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"_id":       log.ID,
+			"logged_at": log.LoggedAt,
+		},
+		"$unset": bson.M{
+			"reviewed_by": "",
+			"reviewed_at": "",
+			"review_note": "",
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved entity.MealLog
+	err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&saved)
+	if mongo.IsDuplicateKeyError(err) {
+		// Outro registro simultâneo inseriu primeiro; agora o filtro o encontra
+		err = collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&saved)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *MealLogRepository) FindByID(ctx context.Context, id string) (*entity.MealLog, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	var log entity.MealLog
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&log)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &log, nil
+}
+
+func (r *MealLogRepository) FindByDiet(ctx context.Context, dietID, from, to string) ([]*entity.MealLog, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	filter := bson.M{"diet_id": dietID}
+	date := bson.M{}
+	if from != "" {
+		date["$gte"] = from
+	}
+	if to != "" {
+		date["$lte"] = to
+	}
+	if len(date) > 0 {
+		filter["date"] = date
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "logged_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	logs := []*entity.MealLog{}
+	if err = cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+func (r *MealLogRepository) UpdateReview(ctx context.Context, id, reviewedBy, note string, reviewedAt time.Time) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"reviewed_by": reviewedBy,
+		"reviewed_at": reviewedAt,
+		"review_note": note,
+	}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return usecase.ErrMealLogNotFound
+	}
+	return nil
+}
+
+func (r *MealLogRepository) Delete(ctx context.Context, id string) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return usecase.ErrMealLogNotFound
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	measurementCollectionName = "measurements"
+)
+
+// MeasurementRepository implements the usecase.MeasurementRepository interface using MongoDB.
+type MeasurementRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+// NewMeasurementRepository creates a new MeasurementRepository.
+func NewMeasurementRepository(cfg *utils.EnvConfig) (*MeasurementRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MeasurementRepository{
+		client:     client,
+		database:   cfg.DBName,
+		collection: measurementCollectionName,
+	}, nil
+}
+
+func (r *MeasurementRepository) Create(ctx context.Context, measurement *entity.Measurement) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.InsertOne(ctx, measurement)
+	return err
+}
+
+func (r *MeasurementRepository) FindByID(ctx context.Context, id string) (*entity.Measurement, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	var measurement entity.Measurement
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&measurement)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &measurement, nil
+}
+
+func (r *MeasurementRepository) FindByPatient(ctx context.Context, patientID string, from, to *time.Time) ([]*entity.Measurement, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	filter := bson.M{"patient_id": patientID}
+	measuredAt := bson.M{}
+	if from != nil {
+		measuredAt["$gte"] = *from
+	}
+	if to != nil {
+		measuredAt["$lte"] = *to
+	}
+	if len(measuredAt) > 0 {
+		filter["measured_at"] = measuredAt
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "measured_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	measurements := []*entity.Measurement{}
+	if err = cursor.All(ctx, &measurements); err != nil {
+		return nil, err
+	}
+	return measurements, nil
+}
+
+func (r *MeasurementRepository) Update(ctx context.Context, measurement *entity.Measurement) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	result, err := collection.ReplaceOne(ctx, bson.M{"_id": measurement.ID}, measurement)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return usecase.ErrMeasurementNotFound
+	}
+	return nil
+}
+
+func (r *MeasurementRepository) Delete(ctx context.Context, id string) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return usecase.ErrMeasurementNotFound
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	patientLinkCollectionName = "patient_links"
+)
+
+// PatientLinkRepository implements the usecase.PatientLinkRepository interface using MongoDB.
+type PatientLinkRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+// NewPatientLinkRepository creates a new PatientLinkRepository.
+func NewPatientLinkRepository(cfg *utils.EnvConfig) (*PatientLinkRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// A nutritionist has at most one pending or active link with each patient,
+	// even when two invitations are sent at the same time
+	_, err = client.Database(cfg.DBName).Collection(patientLinkCollectionName).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "nutritionist_id", Value: 1}, {Key: "patient_email", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+			"status": bson.M{"$in": bson.A{entity.PatientLinkPending, entity.PatientLinkActive}},
+		}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating the patient link index: %w", err)
+	}
+
+	return &PatientLinkRepository{
+		client:     client,
+		database:   cfg.DBName,
+		collection: patientLinkCollectionName,
+	}, nil
+}
+
+func (r *PatientLinkRepository) Create(ctx context.Context, link *entity.PatientLink) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.InsertOne(ctx, link)
+	if mongo.IsDuplicateKeyError(err) {
+		return usecase.ErrPatientLinkExists
+	}
+	return err
+}
+
+func (r *PatientLinkRepository) FindByID(ctx context.Context, id string) (*entity.PatientLink, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *PatientLinkRepository) FindOpen(ctx context.Context, nutritionistID, patientEmail string) (*entity.PatientLink, error) {
+	return r.findOne(ctx, bson.M{
+		"nutritionist_id": nutritionistID,
+		"patient_email":   patientEmail,
+		"status":          bson.M{"$in": bson.A{entity.PatientLinkPending, entity.PatientLinkActive}},
+	})
+}
+
+func (r *PatientLinkRepository) FindByNutritionist(ctx context.Context, nutritionistID string, status *entity.PatientLinkStatus) ([]*entity.PatientLink, error) {
+	filter := bson.M{"nutritionist_id": nutritionistID}
+	if status != nil {
+		filter["status"] = *status
+	}
+	return r.find(ctx, filter)
+}
+
+func (r *PatientLinkRepository) FindByPatientEmail(ctx context.Context, patientEmail string, status *entity.PatientLinkStatus) ([]*entity.PatientLink, error) {
+	filter := bson.M{"patient_email": patientEmail}
+	if status != nil {
+		filter["status"] = *status
+	}
+	return r.find(ctx, filter)
+}
+
+func (r *PatientLinkRepository) UpdateStatus(ctx context.Context, link *entity.PatientLink, from entity.PatientLinkStatus) (bool, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	result, err := collection.UpdateOne(
+		ctx,
+		bson.M{"_id": link.ID, "status": from},
+		bson.M{"$set": bson.M{
+			"status":       link.Status,
+			"patient_id":   link.PatientID,
+			"responded_at": link.RespondedAt,
+			"started_at":   link.StartedAt,
+			"ended_at":     link.EndedAt,
+			"ended_by":     link.EndedBy,
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *PatientLinkRepository) findOne(ctx context.Context, filter bson.M) (*entity.PatientLink, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	var link entity.PatientLink
+	err := collection.FindOne(ctx, filter).Decode(&link)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *PatientLinkRepository) find(ctx context.Context, filter bson.M) ([]*entity.PatientLink, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.M{"invited_at": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	links := []*entity.PatientLink{}
+	if err = cursor.All(ctx, &links); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	roleCollectionName = "roles"
+)
+
+// RoleRepository implements the usecase.RoleRepository interface using MongoDB.
+type RoleRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+// NewRoleRepository creates a new RoleRepository.
+func NewRoleRepository(cfg *utils.EnvConfig) (*RoleRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RoleRepository{
+		client:     client,
+		database:   cfg.DBName,
+		collection: roleCollectionName,
+	}, nil
+}
+
+func (r *RoleRepository) FindByName(ctx context.Context, name string) (*entity.Role, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	var role entity.Role
+	err := collection.FindOne(ctx, bson.M{"_id": name}).Decode(&role)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *RoleRepository) List(ctx context.Context) ([]*entity.Role, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var roles []*entity.Role
+	if err = cursor.All(ctx, &roles); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *RoleRepository) Create(ctx context.Context, role *entity.Role) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.InsertOne(ctx, role)
+	return err
+}
+
+func (r *RoleRepository) Upsert(ctx context.Context, role *entity.Role) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.UpdateOne(
+		ctx,
+		bson.M{"_id": role.Name},
+		bson.M{
+			"$set": bson.M{
+				"description": role.Description,
+				"permissions": role.Permissions,
+				"updated_by":  role.UpdatedBy,
+				"updated_at":  role.UpdatedAt,
+			},
+			"$setOnInsert": bson.M{"seeded_permissions": bson.A{}},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *RoleRepository) AddSeededPermissions(ctx context.Context, name string, permissions []string) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.UpdateOne(
+		ctx,
+		bson.M{"_id": name},
+		bson.M{
+			"$addToSet": bson.M{
+				"permissions":        bson.M{"$each": permissions},
+				"seeded_permissions": bson.M{"$each": permissions},
+			},
+			"$set": bson.M{"updated_at": time.Now()},
+		},
+	)
+	return err
+}
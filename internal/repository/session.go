@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	sessionCollectionName = "sessions"
+)
+
+// SessionRepository implements the usecase.SessionRepository interface using MongoDB.
+type SessionRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+// NewSessionRepository creates a new SessionRepository.
+func NewSessionRepository(cfg *utils.EnvConfig) (*SessionRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every refresh looks the session up by the current or a used token hash
+	_, err = client.Database(cfg.DBName).Collection(sessionCollectionName).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "refresh_token_hash", Value: 1}}},
+		{Keys: bson.D{{Key: "used_token_hashes", Value: 1}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating the session indexes: %w", err)
+	}
+
+	return &SessionRepository{
+		client:     client,
+		database:   cfg.DBName,
+		collection: sessionCollectionName,
+	}, nil
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.InsertOne(ctx, session)
+	return err
+}
+
+func (r *SessionRepository) FindByID(ctx context.Context, id string) (*entity.Session, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *SessionRepository) FindByTokenHash(ctx context.Context, hash string) (*entity.Session, error) {
+	return r.findOne(ctx, bson.M{
+		"$or": bson.A{
+			bson.M{"refresh_token_hash": hash},
+			bson.M{"used_token_hashes": hash},
+		},
+	})
+}
+
+func (r *SessionRepository) Rotate(ctx context.Context, id, currentHash, newHash string, refreshedAt, expiresAt time.Time) (bool, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	// Matching on the current hash makes concurrent refreshes with the same
+	// token race for a single winner.
+	result, err := collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "refresh_token_hash": currentHash, "revoked_at": nil},
+		bson.M{
+			"$set": bson.M{
+				"refresh_token_hash": newHash,
+				"refreshed_at":       refreshedAt,
+				"expires_at":         expiresAt,
+			},
+			"$push": bson.M{"used_token_hashes": currentHash},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id, reason string, revokedAt time.Time) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": revokedAt, "revoke_reason": reason}},
+	)
+	return err
+}
+
+func (r *SessionRepository) RevokeAllForUser(ctx context.Context, userID, reason string, revokedAt time.Time) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.UpdateMany(
+		ctx,
+		bson.M{"user_id": userID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": revokedAt, "revoke_reason": reason}},
+	)
+	return err
+}
+
+func (r *SessionRepository) findOne(ctx context.Context, filter bson.M) (*entity.Session, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	var session entity.Session
+	err := collection.FindOne(ctx, filter).Decode(&session)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	userTokenCollectionName = "user_tokens"
+)
+
+// UserTokenRepository implements the usecase.UserTokenRepository interface using MongoDB.
+type UserTokenRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+// NewUserTokenRepository creates a new UserTokenRepository.
+func NewUserTokenRepository(cfg *utils.EnvConfig) (*UserTokenRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURL))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserTokenRepository{
+		client:     client,
+		database:   cfg.DBName,
+		collection: userTokenCollectionName,
+	}, nil
+}
+
+func (r *UserTokenRepository) Create(ctx context.Context, token *entity.UserToken) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.InsertOne(ctx, token)
+	return err
+}
+
+func (r *UserTokenRepository) Consume(ctx context.Context, tokenHash string, purpose entity.UserTokenPurpose, now time.Time) (*entity.UserToken, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	// A single atomic update guarantees a token can only be consumed once.
+	var token entity.UserToken
+	err := collection.FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"token_hash": tokenHash,
+			"purpose":    purpose,
+			"used_at":    nil,
+			"expires_at": bson.M{"$gt": now},
+		},
+		bson.M{"$set": bson.M{"used_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *UserTokenRepository) InvalidateAll(ctx context.Context, userID string, purpose entity.UserTokenPurpose, now time.Time) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	_, err := collection.UpdateMany(
+		ctx,
+		bson.M{"user_id": userID, "purpose": purpose, "used_at": nil},
+		bson.M{"$set": bson.M{"used_at": now}},
+	)
+	return err
+}
@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"errors"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/usecase"
 	"github.com/victorgiudicissi/your-diet/internal/utils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -59,11 +62,13 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.User) (string,
 	return result.InsertedID.(primitive.ObjectID).Hex(), nil
 }
 
+// FindByEmail matches the email in lowercase and without surrounding spaces,
+// as it is stored since registration
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	collection := r.client.Database(r.database).Collection(r.collection)
 
 	var user entity.User
-	err := collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	err := collection.FindOne(ctx, bson.M{"email": strings.ToLower(strings.TrimSpace(email))}).Decode(&user)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, nil
@@ -77,7 +82,8 @@ func (r *UserRepository) FindByID(ctx context.Context, id string) (*entity.User,
 	collection := r.client.Database(r.database).Collection(r.collection)
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, err
+		// A malformed ID can never match a user
+		return nil, nil
 	}
 
 	var user entity.User
@@ -90,3 +96,183 @@ func (r *UserRepository) FindByID(ctx context.Context, id string) (*entity.User,
 	}
 	return &user, nil
 }
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id string, passwordHash string) error {
+	return r.updateByID(ctx, id, bson.M{"password": passwordHash})
+}
+
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, id string, verifiedAt time.Time) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	// Keep the original verification date when the email was already verified
+	_, err = collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "email_verified": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{"email_verified": true, "email_verified_at": verifiedAt}},
+	)
+	return err
+}
+
+// RecordFailedLogin increments the failures in a single update, so parallel
+// attempts never read the same count
+func (r *UserRepository) RecordFailedLogin(ctx context.Context, id string, failedAt, windowStart time.Time) (int, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return 0, err
+	}
+
+	// Failures older than the window no longer count
+	attempts := bson.M{"$cond": bson.A{
+		bson.M{"$gte": bson.A{"$last_failed_login_at", windowStart}},
+		bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$failed_login_attempts", 0}}, 1}},
+		1,
+	}}
+
+	var user entity.User
+	err = collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": objID},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"failed_login_attempts": attempts,
+			"last_failed_login_at":  failedAt,
+		}}}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, usecase.ErrUserNotFound
+		}
+		return 0, err
+	}
+	return user.FailedLoginAttempts, nil
+}
+
+// LockLogin only extends the lockout, so a shorter lockout computed by a
+// parallel attempt cannot replace a longer one
+func (r *UserRepository) LockLogin(ctx context.Context, id string, lockedUntil time.Time) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$max": bson.M{"locked_until": lockedUntil}})
+	return err
+}
+
+func (r *UserRepository) ResetFailedLogins(ctx context.Context, id string) error {
+	return r.updateByID(ctx, id, bson.M{
+		"failed_login_attempts": 0,
+		"last_failed_login_at":  nil,
+		"locked_until":          nil,
+	})
+}
+
+func (r *UserRepository) FindByNutritionistStatus(ctx context.Context, status entity.NutritionistStatus) ([]*entity.User, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	cursor, err := collection.Find(ctx, bson.M{"nutritionist_status": status})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*entity.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *UserRepository) UpdateType(ctx context.Context, id string, userType string) error {
+	return r.updateByID(ctx, id, bson.M{"type": userType})
+}
+
+func (r *UserRepository) UpdateAnthropometrics(ctx context.Context, id string, anthropometrics *entity.Anthropometrics) error {
+	return r.updateByID(ctx, id, bson.M{"anthropometrics": anthropometrics})
+}
+
+func (r *UserRepository) UpdateNutritionistReview(ctx context.Context, id string, status entity.NutritionistStatus, userType, reviewerID, note string, reviewedAt time.Time) error {
+	return r.updateByID(ctx, id, bson.M{
+		"type":                     userType,
+		"nutritionist_status":      status,
+		"nutritionist_reviewed_by": reviewerID,
+		"nutritionist_reviewed_at": reviewedAt,
+		"nutritionist_review_note": note,
+	})
+}
+
+func (r *UserRepository) updateByID(ctx context.Context, id string, fields bson.M) error {
+	collection := r.client.Database(r.database).Collection(r.collection)
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": fields})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return usecase.ErrUserNotFound
+	}
+	return nil
+}
+
+// NormalizeEmails stores the emails of the users in lowercase and without
+// surrounding spaces. Users whose emails only differ in casing are left as
+// they are and logged, since merging accounts needs a manual decision.
+func (r *UserRepository) NormalizeEmails(ctx context.Context) (int, error) {
+	collection := r.client.Database(r.database).Collection(r.collection)
+
+	normalized := bson.M{"$toLower": bson.M{"$trim": bson.M{"input": "$email"}}}
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"email": bson.M{"$type": "string"}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   normalized,
+			"ids":   bson.M{"$push": "$_id"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var conflicts []struct {
+		Email string               `bson:"_id"`
+		IDs   []primitive.ObjectID `bson:"ids"`
+	}
+	if err = cursor.All(ctx, &conflicts); err != nil {
+		return 0, err
+	}
+
+	skipped := bson.A{}
+	for _, conflict := range conflicts {
+		log.Printf("[UserRepository] Skipping %d users sharing the email %s", len(conflict.IDs), conflict.Email)
+		for _, id := range conflict.IDs {
+			skipped = append(skipped, id)
+		}
+	}
+
+	result, err := collection.UpdateMany(ctx,
+		bson.M{
+			"_id":   bson.M{"$nin": skipped},
+			"email": bson.M{"$type": "string"},
+			"$expr": bson.M{"$ne": bson.A{"$email", normalized}},
+		},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"email": normalized}}}},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.ModifiedCount), nil
+}
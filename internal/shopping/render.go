@@ -0,0 +1,62 @@
+package shopping
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// Markdown renders the list as a Markdown checklist
+func Markdown(list *entity.ShoppingList) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Lista de compras: %s\n\n", list.DietName)
+	fmt.Fprintf(&b, "%s a %s (%d dias)\n", formatDate(list.From), formatDate(list.To), list.Days)
+
+	for _, category := range list.Categories {
+		fmt.Fprintf(&b, "\n## %s\n\n", category.Name)
+		for _, item := range category.Items {
+			fmt.Fprintf(&b, "- [ ] %s\n", formatItem(item))
+		}
+	}
+	return b.String()
+}
+
+// Text renders the list as a plain-text checklist
+func Text(list *entity.ShoppingList) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "LISTA DE COMPRAS: %s\n", list.DietName)
+	fmt.Fprintf(&b, "%s a %s (%d dias)\n", formatDate(list.From), formatDate(list.To), list.Days)
+
+	for _, category := range list.Categories {
+		fmt.Fprintf(&b, "\n%s\n", strings.ToUpper(category.Name))
+		for _, item := range category.Items {
+			fmt.Fprintf(&b, "[ ] %s\n", formatItem(item))
+		}
+	}
+	return b.String()
+}
+
+// formatItem writes "Arroz: 1,5 kg (2 x 1 kg)"
+func formatItem(item entity.ShoppingItem) string {
+	line := fmt.Sprintf("%s: %s %s", item.Description, formatNumber(item.Quantity), item.Unit)
+	if item.Package != nil {
+		line += fmt.Sprintf(" (%d x %s %s)", item.Package.Count, formatNumber(item.Package.Size), item.Package.Unit)
+	}
+	return line
+}
+
+// formatNumber uses the decimal comma
+func formatNumber(value float64) string {
+	return strings.Replace(strconv.FormatFloat(value, 'f', -1, 64), ".", ",", 1)
+}
+
+// formatDate converts AAAA-MM-DD to DD/MM/AAAA
+func formatDate(date string) string {
+	parts := strings.Split(date, "-")
+	if len(parts) != 3 {
+		return date
+	}
+	return parts[2] + "/" + parts[1] + "/" + parts[0]
+}
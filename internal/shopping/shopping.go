@@ -0,0 +1,183 @@
+// Package shopping merges the ingredients of the meals a diet prescribes into
+// a shopping list.
+package shopping
+
+import (
+	"math"
+	"sort"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/units"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+// OtherCategory groups the ingredients without a catalog food
+const OtherCategory = "Outros"
+
+// packageSizes are the usual retail sizes in grams or milliliters. Larger
+// quantities are bought in several of the largest package.
+var packageSizes = map[string][]float64{
+	"g":  {100, 200, 250, 500, 1000},
+	"ml": {200, 500, 1000},
+}
+
+// item accumulates the occurrences of an ingredient. Quantities are kept in
+// the base unit of each kind (g, ml or the count unit itself) and, when every
+// occurrence converts, also in grams, used when the units cannot be summed.
+type item struct {
+	foodID      string
+	description string
+	category    string
+	byUnit      map[string]float64
+	units       []string
+	grams       float64
+	gramsOK     bool
+}
+
+// Build merges the ingredients of the meals of each day. Ingredients are the
+// same when they reference the same food or, without one, have the same
+// description; substitutes are alternatives and are not bought. With
+// withPackages the quantities are rounded up to package sizes.
+func Build(days []entity.ScheduledDay, foods map[string]*entity.Food, withPackages bool) []entity.ShoppingCategory {
+	items := map[string]*item{}
+	var order []string
+
+	for _, day := range days {
+		for _, meal := range day.Meals {
+			for _, ingredient := range meal.Ingredients {
+				key := ingredient.FoodID
+				if key == "" {
+					key = "desc:" + utils.NormalizeText(ingredient.Description)
+				}
+
+				current, ok := items[key]
+				if !ok {
+					current = &item{
+						foodID:      ingredient.FoodID,
+						description: ingredient.Description,
+						category:    OtherCategory,
+						byUnit:      map[string]float64{},
+						gramsOK:     true,
+					}
+					if food := foods[ingredient.FoodID]; food != nil && food.Category != "" {
+						current.category = food.Category
+					}
+					items[key] = current
+					order = append(order, key)
+				}
+				current.add(ingredient, foods[ingredient.FoodID])
+			}
+		}
+	}
+
+	byCategory := map[string][]entity.ShoppingItem{}
+	for _, key := range order {
+		for _, shoppingItem := range items[key].lines(withPackages) {
+			byCategory[items[key].category] = append(byCategory[items[key].category], shoppingItem)
+		}
+	}
+
+	categories := make([]entity.ShoppingCategory, 0, len(byCategory))
+	for name, categoryItems := range byCategory {
+		sort.SliceStable(categoryItems, func(i, j int) bool {
+			return utils.NormalizeText(categoryItems[i].Description) < utils.NormalizeText(categoryItems[j].Description)
+		})
+		categories = append(categories, entity.ShoppingCategory{Name: name, Items: categoryItems})
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if (categories[i].Name == OtherCategory) != (categories[j].Name == OtherCategory) {
+			return categories[j].Name == OtherCategory
+		}
+		return utils.NormalizeText(categories[i].Name) < utils.NormalizeText(categories[j].Name)
+	})
+	return categories
+}
+
+func (it *item) add(ingredient entity.Ingredient, food *entity.Food) {
+	code, quantity := ingredient.Unit, ingredient.Quantity
+	if unit, err := units.Parse(ingredient.Unit); err == nil {
+		switch unit.Kind {
+		case units.Mass:
+			code, quantity = "g", quantity*unit.Factor
+		case units.Volume:
+			code, quantity = "ml", quantity*unit.Factor
+		default:
+			code = unit.Code
+		}
+	}
+
+	if _, ok := it.byUnit[code]; !ok {
+		it.units = append(it.units, code)
+	}
+	it.byUnit[code] += quantity
+
+	if grams, ok := units.IngredientGrams(ingredient, food); ok {
+		it.grams += grams
+	} else {
+		it.gramsOK = false
+	}
+}
+
+// lines returns a single line when the occurrences share a unit or all of
+// them convert to grams, otherwise one line per unit
+func (it *item) lines(withPackages bool) []entity.ShoppingItem {
+	if len(it.units) > 1 && it.gramsOK {
+		return []entity.ShoppingItem{it.line(it.grams, "g", withPackages)}
+	}
+
+	lines := make([]entity.ShoppingItem, 0, len(it.units))
+	for _, code := range it.units {
+		lines = append(lines, it.line(it.byUnit[code], code, withPackages))
+	}
+	return lines
+}
+
+func (it *item) line(quantity float64, code string, withPackages bool) entity.ShoppingItem {
+	shoppingItem := entity.ShoppingItem{
+		FoodID:      it.foodID,
+		Description: it.description,
+	}
+
+	shoppingItem.Quantity, shoppingItem.Unit = display(quantity, code)
+	if !withPackages {
+		return shoppingItem
+	}
+
+	if sizes, ok := packageSizes[code]; ok {
+		shoppingItem.Package = packageFor(quantity, code, sizes)
+	} else {
+		// Unidades e fatias são compradas inteiras
+		shoppingItem.Quantity = math.Ceil(quantity)
+	}
+	return shoppingItem
+}
+
+// display converts large metric quantities to kg or l and rounds the
+// quantity up, so the list never falls short
+func display(quantity float64, code string) (float64, string) {
+	switch code {
+	case "g", "ml":
+		if quantity >= 1000 {
+			larger := map[string]string{"g": "kg", "ml": "l"}[code]
+			return math.Ceil(quantity/10) / 100, larger
+		}
+		return math.Ceil(quantity), code
+	default:
+		return math.Ceil(quantity*2) / 2, code
+	}
+}
+
+// packageFor picks the smallest package that covers the quantity or, above
+// the largest one, how many of the largest are needed
+func packageFor(quantity float64, code string, sizes []float64) *entity.ShoppingPackage {
+	size := sizes[len(sizes)-1]
+	for _, candidate := range sizes {
+		if candidate >= quantity {
+			size = candidate
+			break
+		}
+	}
+
+	sizeValue, sizeUnit := display(size, code)
+	return &entity.ShoppingPackage{Size: sizeValue, Unit: sizeUnit, Count: int(math.Ceil(quantity / size))}
+}
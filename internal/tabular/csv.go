@@ -0,0 +1,69 @@
+// Package tabular reads and writes spreadsheets as rows of text cells, in CSV
+// or in the XLSX format of Excel, LibreOffice and Google Sheets.
+package tabular
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ReadCSV reads every row of the file, up to MaxRows. A UTF-8 byte order mark
+// is skipped and the separator is ";" (common in Brazilian spreadsheets) when
+// the first line has more of them than ",".
+func ReadCSV(r io.Reader) ([][]string, error) {
+	buffered := bufio.NewReader(r)
+
+	if bom, err := buffered.Peek(len(utf8BOM)); err == nil && bytes.Equal(bom, utf8BOM) {
+		if _, err := buffered.Discard(len(utf8BOM)); err != nil {
+			return nil, err
+		}
+	}
+
+	head, err := buffered.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	if end := bytes.IndexByte(head, '\n'); end >= 0 {
+		head = head[:end]
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	if bytes.Count(head, []byte(";")) > bytes.Count(head, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	rows := [][]string{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == MaxRows {
+			return nil, fmt.Errorf("the file has more than %d rows", MaxRows)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// WriteCSV writes the rows separated by ",", with a byte order mark so Excel
+// opens the accents correctly
+func WriteCSV(w io.Writer, rows [][]string) error {
+	if _, err := w.Write(utf8BOM); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
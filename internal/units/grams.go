@@ -0,0 +1,87 @@
+package units
+
+import "github.com/victorgiudicissi/your-diet/internal/entity"
+
+// Measures are the food-specific data used to convert to grams
+type Measures struct {
+	// DensityGPerMl converts volumes to mass. Zero means unknown, in which case
+	// 1 g/ml (water) is assumed.
+	DensityGPerMl float64
+	// GramWeights maps a unit code to the weight in grams of one unit of it for
+	// this food, e.g. {"un": 50, "concha": 140}. It takes precedence over the
+	// generic volume of household measures.
+	GramWeights map[string]float64
+}
+
+// Merge returns the measures with the values of override taking precedence
+func (m Measures) Merge(override Measures) Measures {
+	merged := Measures{DensityGPerMl: m.DensityGPerMl, GramWeights: map[string]float64{}}
+	for code, grams := range m.GramWeights {
+		merged.GramWeights[code] = grams
+	}
+	for code, grams := range override.GramWeights {
+		merged.GramWeights[code] = grams
+	}
+	if override.DensityGPerMl > 0 {
+		merged.DensityGPerMl = override.DensityGPerMl
+	}
+	return merged
+}
+
+// ToGrams converts a quantity of the food to grams
+func ToGrams(quantity float64, unitName string, measures Measures) (float64, error) {
+	unit, err := Parse(unitName)
+	if err != nil {
+		return 0, err
+	}
+
+	if grams, ok := measures.GramWeights[unit.Code]; ok && grams > 0 {
+		return quantity * grams, nil
+	}
+
+	switch unit.Kind {
+	case Mass:
+		return quantity * unit.Factor, nil
+	case Volume:
+		density := measures.DensityGPerMl
+		if density <= 0 {
+			density = 1
+		}
+		return quantity * unit.Factor * density, nil
+	default:
+		return 0, ErrNoGramWeight
+	}
+}
+
+// WithUnitWeight returns the measures with the weight in grams of one unit of
+// the measure taking precedence, as informed on an ingredient
+func (m Measures) WithUnitWeight(unitName string, grams float64) Measures {
+	if grams <= 0 {
+		return m
+	}
+
+	unit, err := Parse(unitName)
+	if err != nil {
+		return m
+	}
+	return m.Merge(Measures{GramWeights: map[string]float64{unit.Code: grams}})
+}
+
+// IngredientGrams converts the prescribed quantity to grams using the food
+// measures (density and gram weights), overridden by the weight informed on
+// the ingredient itself. food may be nil when the ingredient has no food of
+// the catalog.
+func IngredientGrams(ingredient entity.Ingredient, food *entity.Food) (float64, bool) {
+	measures := Measures{}
+	if food != nil {
+		measures = Measures{DensityGPerMl: food.DensityGPerMl, GramWeights: food.GramWeights}
+	}
+
+	measures = measures.WithUnitWeight(ingredient.Unit, ingredient.UnitWeightG)
+
+	grams, err := ToGrams(ingredient.Quantity, ingredient.Unit, measures)
+	if err != nil {
+		return 0, false
+	}
+	return grams, true
+}
@@ -0,0 +1,139 @@
+// Package units converts ingredient quantities between metric units, Brazilian
+// household measures and grams.
+package units
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+// Kind groups units that convert to each other without knowing the food
+type Kind string
+
+const (
+	Mass   Kind = "MASS"
+	Volume Kind = "VOLUME"
+	Count  Kind = "COUNT"
+)
+
+// Unit is a measurement unit. Factor converts one unit to the base unit of its
+// kind: grams for mass and milliliters for volume. Count units (unidade,
+// fatia) only convert with the weight of the specific food.
+type Unit struct {
+	Code    string  `json:"code"`
+	Name    string  `json:"name"`
+	Kind    Kind    `json:"kind"`
+	Factor  float64 `json:"factor,omitempty"`
+	aliases []string
+}
+
+var (
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrNoGramWeight is returned when a count unit cannot be converted to
+	// grams because the weight of one unit of the food is unknown
+	ErrNoGramWeight = errors.New("gram weight unknown for this unit and food")
+)
+
+// Volumes of the household measures follow the usual references for
+// Brazilian food consumption surveys (medida caseira média).
+var all = []Unit{
+	{Code: "mg", Name: "miligrama", Kind: Mass, Factor: 0.001, aliases: []string{"miligramas"}},
+	{Code: "g", Name: "grama", Kind: Mass, Factor: 1, aliases: []string{"gr", "gramas"}},
+	{Code: "kg", Name: "quilograma", Kind: Mass, Factor: 1000, aliases: []string{"quilo", "quilos", "quilogramas"}},
+	{Code: "ml", Name: "mililitro", Kind: Volume, Factor: 1, aliases: []string{"mililitros"}},
+	{Code: "l", Name: "litro", Kind: Volume, Factor: 1000, aliases: []string{"lt", "litros"}},
+	{Code: "colher de cafe", Name: "colher de café", Kind: Volume, Factor: 2, aliases: []string{"colheres de cafe"}},
+	{Code: "colher de cha", Name: "colher de chá", Kind: Volume, Factor: 5, aliases: []string{"colheres de cha"}},
+	{Code: "colher de sobremesa", Name: "colher de sobremesa", Kind: Volume, Factor: 10, aliases: []string{"colheres de sobremesa"}},
+	{Code: "colher de sopa", Name: "colher de sopa", Kind: Volume, Factor: 15, aliases: []string{"colheres de sopa", "cs"}},
+	{Code: "xicara", Name: "xícara", Kind: Volume, Factor: 240, aliases: []string{"xicaras", "xicara de cha", "xicaras de cha"}},
+	{Code: "copo americano", Name: "copo americano", Kind: Volume, Factor: 190, aliases: []string{"copos americanos"}},
+	{Code: "concha", Name: "concha", Kind: Volume, Factor: 130, aliases: []string{"conchas", "concha media"}},
+	{Code: "escumadeira", Name: "escumadeira", Kind: Volume, Factor: 90, aliases: []string{"escumadeiras", "escumadeira media"}},
+	{Code: "un", Name: "unidade", Kind: Count, aliases: []string{"unidades", "und"}},
+	{Code: "fatia(s)", Name: "fatia", Kind: Count, aliases: []string{"fatias"}},
+}
+
+var byName = func() map[string]Unit {
+	index := map[string]Unit{}
+	for _, unit := range all {
+		index[utils.NormalizeText(unit.Code)] = unit
+		index[utils.NormalizeText(unit.Name)] = unit
+		for _, alias := range unit.aliases {
+			index[utils.NormalizeText(alias)] = unit
+		}
+	}
+	return index
+}()
+
+// Parse finds a unit by code, name or alias, ignoring case, accents and
+// punctuation: "Colher de Sopa", "colheres de sopa" and "cs" are the same unit.
+func Parse(name string) (Unit, error) {
+	unit, ok := byName[utils.NormalizeText(name)]
+	if !ok {
+		return Unit{}, ErrUnknownUnit
+	}
+	return unit, nil
+}
+
+// IsKnown reports whether the unit can be parsed
+func IsKnown(name string) bool {
+	_, err := Parse(name)
+	return err == nil
+}
+
+// All lists the supported units grouped by kind
+func All() []Unit {
+	units := make([]Unit, len(all))
+	copy(units, all)
+	sort.SliceStable(units, func(i, j int) bool { return units[i].Kind < units[j].Kind })
+	return units
+}
+
+// Convert converts a quantity between units of the same kind, such as
+// colheres de sopa to ml. Count units only convert to themselves.
+func Convert(quantity float64, from, to string) (float64, error) {
+	source, err := Parse(from)
+	if err != nil {
+		return 0, err
+	}
+
+	target, err := Parse(to)
+	if err != nil {
+		return 0, err
+	}
+
+	if source.Code == target.Code {
+		return quantity, nil
+	}
+
+	if source.Kind != target.Kind || source.Kind == Count {
+		return 0, ErrNoGramWeight
+	}
+
+	return quantity * source.Factor / target.Factor, nil
+}
+
+// Round rounds a computed quantity, such as a scaled one, to what can be
+// measured in the unit: halves for household measures and count units
+// (never below half a unit) and one decimal for metric units.
+func Round(quantity float64, unitName string) float64 {
+	unit, err := Parse(unitName)
+	if err != nil || unit.metric() {
+		return math.Round(quantity*10) / 10
+	}
+	return math.Max(math.Round(quantity*2)/2, 0.5)
+}
+
+// metric reports whether the unit belongs to the metric system
+func (u Unit) metric() bool {
+	switch u.Code {
+	case "mg", "g", "kg", "ml", "l":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+const (
+	// PasswordResetTokenDuration is how long a "forgot password" link is valid
+	PasswordResetTokenDuration = 1 * time.Hour
+	// EmailVerificationTokenDuration is how long an email verification link is valid
+	EmailVerificationTokenDuration = 48 * time.Hour
+
+	revokeReasonPasswordReset = "password_reset"
+)
+
+// accountEmails issues single-use tokens and emails them to the user
+type accountEmails struct {
+	tokenRepo UserTokenRepository
+	mailer    Mailer
+	baseURL   string
+}
+
+func newAccountEmails(tokenRepo UserTokenRepository, mailer Mailer, baseURL string) *accountEmails {
+	return &accountEmails{
+		tokenRepo: tokenRepo,
+		mailer:    mailer,
+		baseURL:   strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (a *accountEmails) sendEmailVerification(ctx context.Context, user *entity.User) error {
+	token, err := a.issue(ctx, user, entity.PurposeEmailVerification, EmailVerificationTokenDuration)
+	if err != nil {
+		return err
+	}
+
+	return a.mailer.Send(ctx, &entity.EmailMessage{
+		To:      user.Email,
+		Subject: "Confirme seu email no Your Diet",
+		Body: fmt.Sprintf(
+			"Olá!\n\nPara confirmar seu email, acesse o link abaixo:\n\n%s\n\nO link expira em %d horas.\nSe você não criou uma conta no Your Diet, ignore esta mensagem.\n",
+			a.link("verify-email", token), int(EmailVerificationTokenDuration.Hours()),
+		),
+	})
+}
+
+func (a *accountEmails) sendPasswordReset(ctx context.Context, user *entity.User) error {
+	token, err := a.issue(ctx, user, entity.PurposePasswordReset, PasswordResetTokenDuration)
+	if err != nil {
+		return err
+	}
+
+	return a.mailer.Send(ctx, &entity.EmailMessage{
+		To:      user.Email,
+		Subject: "Redefinição de senha do Your Diet",
+		Body: fmt.Sprintf(
+			"Olá!\n\nRecebemos um pedido para redefinir a sua senha. Para escolher uma nova senha, acesse o link abaixo:\n\n%s\n\nO link expira em %d minutos e só pode ser usado uma vez.\nSe você não fez este pedido, ignore esta mensagem.\n",
+			a.link("reset-password", token), int(PasswordResetTokenDuration.Minutes()),
+		),
+	})
+}
+
+// issue invalidates any pending token for the same purpose, so only the most
+// recently emailed link works, and stores the hash of a fresh one.
+func (a *accountEmails) issue(ctx context.Context, user *entity.User, purpose entity.UserTokenPurpose, ttl time.Duration) (string, error) {
+	now := time.Now()
+
+	if err := a.tokenRepo.InvalidateAll(ctx, user.ID.Hex(), purpose, now); err != nil {
+		return "", err
+	}
+
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = a.tokenRepo.Create(ctx, &entity.UserToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID.Hex(),
+		Purpose:   purpose,
+		TokenHash: hash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (a *accountEmails) link(path, token string) string {
+	if a.baseURL == "" {
+		return token
+	}
+	return fmt.Sprintf("%s/%s?token=%s", a.baseURL, path, token)
+}
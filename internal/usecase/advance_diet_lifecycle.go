@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// AdvanceDietLifecycleUseCase starts scheduled diets whose first day arrived
+// and expires active diets that reached their end. It runs periodically in
+// the background.
+type AdvanceDietLifecycleUseCase interface {
+	Execute(ctx context.Context, now time.Time) (int, error)
+}
+
+type advanceDietLifecycleUseCase struct {
+	dietRepo     DietRepository
+	revisionRepo DietRevisionRepository
+}
+
+// NewAdvanceDietLifecycle creates a new instance of AdvanceDietLifecycleUseCase
+func NewAdvanceDietLifecycle(dietRepo DietRepository, revisionRepo DietRevisionRepository) AdvanceDietLifecycleUseCase {
+	return &advanceDietLifecycleUseCase{
+		dietRepo:     dietRepo,
+		revisionRepo: revisionRepo,
+	}
+}
+
+func (uc *advanceDietLifecycleUseCase) Execute(ctx context.Context, now time.Time) (int, error) {
+	diets, err := uc.dietRepo.FindDiets(ctx, &DietFilter{
+		Statuses: []string{string(entity.DietScheduled), string(entity.DietActive)},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Expirar antes de iniciar libera o calendário para a dieta seguinte
+	withStatus := func(status entity.DietStatus) []*entity.Diet {
+		var selected []*entity.Diet
+		for _, diet := range diets {
+			if diet.Status == string(status) {
+				selected = append(selected, diet)
+			}
+		}
+		return selected
+	}
+
+	changed := 0
+	for _, diet := range append(withStatus(entity.DietActive), withStatus(entity.DietScheduled)...) {
+		next := statusForDates(diet, now)
+		if string(next) == diet.Status || (diet.Status == string(entity.DietActive) && next == entity.DietScheduled) {
+			continue
+		}
+
+		if err := ensureBaselineRevision(ctx, uc.revisionRepo, diet); err != nil {
+			return changed, err
+		}
+
+		previous := diet.Status
+		diet.Status = string(next)
+		err := writeInFreeCalendar(ctx, uc.dietRepo, diet, func() error {
+			return uc.dietRepo.UpdateDiet(ctx, diet, diet.Version)
+		})
+		if err != nil {
+			if errors.Is(err, ErrActiveDietConflict) {
+				log.Printf("[AdvanceDietLifecycleUseCase] Not starting diet %s: %v", diet.ID, err)
+				continue
+			}
+			if errors.Is(err, ErrVersionConflict) {
+				log.Printf("[AdvanceDietLifecycleUseCase] Diet %s changed concurrently, retrying on the next run", diet.ID)
+				continue
+			}
+			if errors.Is(err, ErrCalendarBusy) {
+				log.Printf("[AdvanceDietLifecycleUseCase] Calendar of diet %s is busy, retrying on the next run", diet.ID)
+				continue
+			}
+			return changed, err
+		}
+
+		log.Printf("[AdvanceDietLifecycleUseCase] Diet %s moved from %s to %s", diet.ID, previous, next)
+		changed++
+
+		if _, err := recordRevision(ctx, uc.revisionRepo, diet, entity.RevisionUpdated, entity.RevisionAuthorSystem, nil); err != nil {
+			return changed, err
+		}
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+)
+
+// AssignUserRoleUseCase changes the role (user type) of a user
+type AssignUserRoleUseCase interface {
+	Execute(ctx context.Context, userID, roleName string) error
+}
+
+type assignUserRoleUseCase struct {
+	userRepo UserRepository
+	roleRepo RoleRepository
+}
+
+// NewAssignUserRole creates a new instance of AssignUserRoleUseCase
+func NewAssignUserRole(userRepo UserRepository, roleRepo RoleRepository) AssignUserRoleUseCase {
+	return &assignUserRoleUseCase{
+		userRepo: userRepo,
+		roleRepo: roleRepo,
+	}
+}
+
+func (uc *assignUserRoleUseCase) Execute(ctx context.Context, userID, roleName string) error {
+	role, err := uc.roleRepo.FindByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+
+	if role == nil {
+		return ErrRoleNotFound
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	return uc.userRepo.UpdateType(ctx, userID, role.Name)
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// BackfillPatientLinksUseCase creates active links for nutritionist/patient
+// pairs that already had diets before links existed, so existing plans stay
+// editable.
+type BackfillPatientLinksUseCase interface {
+	Execute(ctx context.Context) (int, error)
+}
+
+type backfillPatientLinksUseCase struct {
+	dietRepo DietRepository
+	userRepo UserRepository
+	linkRepo PatientLinkRepository
+}
+
+// NewBackfillPatientLinks creates a new instance of BackfillPatientLinksUseCase
+func NewBackfillPatientLinks(dietRepo DietRepository, userRepo UserRepository, linkRepo PatientLinkRepository) BackfillPatientLinksUseCase {
+	return &backfillPatientLinksUseCase{
+		dietRepo: dietRepo,
+		userRepo: userRepo,
+		linkRepo: linkRepo,
+	}
+}
+
+func (uc *backfillPatientLinksUseCase) Execute(ctx context.Context) (int, error) {
+	diets, err := uc.dietRepo.FindDiets(ctx, &DietFilter{})
+	if err != nil {
+		return 0, err
+	}
+
+	type pair struct{ nutritionistID, patientEmail string }
+	firstDiet := map[pair]*entity.Diet{}
+	for _, diet := range diets {
+		key := pair{diet.CreatedBy, normalizeEmail(diet.UserEmail)}
+		if current, ok := firstDiet[key]; !ok || diet.CreatedAt.Before(current.CreatedAt) {
+			firstDiet[key] = diet
+		}
+	}
+
+	created := 0
+	for key, diet := range firstDiet {
+		existing, err := uc.linkRepo.FindOpen(ctx, key.nutritionistID, key.patientEmail)
+		if err != nil {
+			return created, err
+		}
+		if existing != nil {
+			continue
+		}
+
+		nutritionist, err := uc.userRepo.FindByID(ctx, key.nutritionistID)
+		if err != nil {
+			return created, err
+		}
+		if nutritionist == nil {
+			log.Printf("[BackfillPatientLinksUseCase] Skipping diets of unknown nutritionist %s", key.nutritionistID)
+			continue
+		}
+
+		startedAt := diet.CreatedAt
+		link := &entity.PatientLink{
+			ID:                uuid.NewString(),
+			NutritionistID:    key.nutritionistID,
+			NutritionistEmail: nutritionist.Email,
+			PatientEmail:      key.patientEmail,
+			Status:            entity.PatientLinkActive,
+			InvitedAt:         startedAt,
+			RespondedAt:       &startedAt,
+			StartedAt:         &startedAt,
+		}
+
+		if patient, err := uc.userRepo.FindByEmail(ctx, diet.UserEmail); err != nil {
+			return created, err
+		} else if patient != nil {
+			link.PatientID = patient.ID.Hex()
+		}
+
+		if err := uc.linkRepo.Create(ctx, link); err != nil {
+			// An invitation sent during the migration already opened the link
+			if errors.Is(err, ErrPatientLinkExists) {
+				continue
+			}
+			return created, err
+		}
+		created++
+	}
+
+	return created, nil
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ChangeDietStatusUseCase moves a diet through its lifecycle. Only the author
+// can change the status, following dietTransitions.
+type ChangeDietStatusUseCase interface {
+	// expectedVersion vem do If-Match; quando nil a versão lida é usada
+	Execute(ctx context.Context, userID, dietID string, status entity.DietStatus, expectedVersion *int64) (*entity.Diet, error)
+}
+
+type changeDietStatusUseCase struct {
+	dietRepo     DietRepository
+	linkRepo     PatientLinkRepository
+	revisionRepo DietRevisionRepository
+	nutrition    nutritionCalculator
+}
+
+// NewChangeDietStatus creates a new instance of ChangeDietStatusUseCase
+func NewChangeDietStatus(dietRepo DietRepository, linkRepo PatientLinkRepository, revisionRepo DietRevisionRepository, foodRepo FoodRepository, userRepo UserRepository) ChangeDietStatusUseCase {
+	return &changeDietStatusUseCase{
+		dietRepo:     dietRepo,
+		linkRepo:     linkRepo,
+		revisionRepo: revisionRepo,
+		nutrition:    nutritionCalculator{foodRepo: foodRepo, userRepo: userRepo},
+	}
+}
+
+func (uc *changeDietStatusUseCase) Execute(ctx context.Context, userID, dietID string, status entity.DietStatus, expectedVersion *int64) (*entity.Diet, error) {
+	diet, err := uc.dietRepo.GetDietByID(ctx, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	if diet == nil || diet.IsDeleted() {
+		return nil, ErrDietNotFound
+	}
+
+	if diet.CreatedBy != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if expectedVersion != nil && *expectedVersion != diet.Version {
+		return nil, ErrPreconditionFailed
+	}
+
+	if !canTransition(entity.DietStatus(diet.Status), status) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidDietTransition, diet.Status, status)
+	}
+
+	// Publicar uma dieta exige o vínculo ativo; arquivar não
+	if status != entity.DietArchived {
+		if err := requireActivePatient(ctx, uc.linkRepo, diet.CreatedBy, diet.UserEmail); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ensureBaselineRevision(ctx, uc.revisionRepo, diet); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := applyDietStatus(diet, status, now); err != nil {
+		return nil, err
+	}
+	diet.UpdatedAt = now
+
+	err = writeInFreeCalendar(ctx, uc.dietRepo, diet, func() error {
+		return uc.dietRepo.UpdateDiet(ctx, diet, diet.Version)
+	})
+	if err != nil {
+		return nil, versionError(err, expectedVersion)
+	}
+
+	if _, err := recordRevision(ctx, uc.revisionRepo, diet, entity.RevisionUpdated, userID, nil); err != nil {
+		return nil, err
+	}
+
+	if err := uc.nutrition.annotate(ctx, diet); err != nil {
+		return nil, err
+	}
+
+	return diet, nil
+}
+
+// applyDietStatus changes the status, adjusting the dates it depends on:
+// activating starts the diet today when it had not started yet and expiring
+// ends it today.
+func applyDietStatus(diet *entity.Diet, status entity.DietStatus, now time.Time) error {
+	today := truncateToDay(now)
+
+	switch status {
+	case entity.DietScheduled:
+		if diet.StartsAt == nil || !diet.StartsAt.After(today) {
+			return fmt.Errorf("%w: starts_at deve ser uma data futura para agendar a dieta", ErrInvalidDietTransition)
+		}
+		setDietDates(diet, *diet.StartsAt)
+	case entity.DietActive:
+		if diet.StartsAt == nil || diet.StartsAt.After(today) {
+			setDietDates(diet, today)
+		} else {
+			setDietDates(diet, *diet.StartsAt)
+		}
+		if !now.Before(*diet.EndsAt) {
+			return fmt.Errorf("%w: a dieta já terminou em %s", ErrInvalidDietTransition, diet.EndsAt.Format(entity.MealLogDateLayout))
+		}
+	case entity.DietExpired:
+		if diet.StartsAt != nil {
+			diet.DurationInDays = uint32(dayplan.DaysBetween(*diet.StartsAt, today) + 1)
+			setDietDates(diet, *diet.StartsAt)
+		}
+	}
+
+	diet.Status = string(status)
+	return nil
+}
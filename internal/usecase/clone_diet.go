@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// CloneDietUseCase copies a diet written by the nutritionist to another
+// linked patient, optionally scaling the calories. The copy starts a new
+// lifecycle and revision history.
+type CloneDietUseCase interface {
+	Execute(ctx context.Context, userID, dietID string, req *dto.DietCopyRequest) (*entity.Diet, error)
+}
+
+type cloneDietUseCase struct {
+	dietRepo DietRepository
+	copier   dietCopier
+}
+
+// NewCloneDiet creates a new instance of CloneDietUseCase
+func NewCloneDiet(dietRepo DietRepository, linkRepo PatientLinkRepository, revisionRepo DietRevisionRepository, foodRepo FoodRepository, userRepo UserRepository) CloneDietUseCase {
+	return &cloneDietUseCase{
+		dietRepo: dietRepo,
+		copier:   newDietCopier(dietRepo, linkRepo, revisionRepo, foodRepo, userRepo),
+	}
+}
+
+func (uc *cloneDietUseCase) Execute(ctx context.Context, userID, dietID string, req *dto.DietCopyRequest) (*entity.Diet, error) {
+	source, err := uc.dietRepo.GetDietByID(ctx, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source == nil || source.IsDeleted() {
+		return nil, ErrDietNotFound
+	}
+
+	if source.CreatedBy != userID {
+		return nil, ErrUnauthorized
+	}
+
+	return uc.copier.create(ctx, userID, source, req)
+}
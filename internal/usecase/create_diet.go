@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"time"
 
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
 	"github.com/victorgiudicissi/your-diet/internal/entity"
 )
 
@@ -11,17 +13,48 @@ type CreateDiet interface {
 }
 
 type createDietUseCase struct {
-	dietRepo DietRepository
+	dietRepo     DietRepository
+	linkRepo     PatientLinkRepository
+	revisionRepo DietRevisionRepository
+	foodRepo     FoodRepository
 }
 
-func NewCreateDiet(dietRepo DietRepository) CreateDiet {
+func NewCreateDiet(dietRepo DietRepository, linkRepo PatientLinkRepository, revisionRepo DietRevisionRepository, foodRepo FoodRepository) CreateDiet {
 	return &createDietUseCase{
-		dietRepo: dietRepo,
+		dietRepo:     dietRepo,
+		linkRepo:     linkRepo,
+		revisionRepo: revisionRepo,
+		foodRepo:     foodRepo,
 	}
 }
 
 func (uc *createDietUseCase) Execute(ctx context.Context, diet *entity.Diet) error {
-	if err := uc.dietRepo.CreateDiet(ctx, diet); err != nil {
+	return insertDiet(ctx, uc.dietRepo, uc.linkRepo, uc.revisionRepo, uc.foodRepo, diet)
+}
+
+// insertDiet stores a new diet written by a nutritionist for a linked patient,
+// starting its lifecycle and its revision history
+func insertDiet(ctx context.Context, dietRepo DietRepository, linkRepo PatientLinkRepository, revisionRepo DietRevisionRepository, foodRepo FoodRepository, diet *entity.Diet) error {
+	// O email é guardado normalizado para que as buscas por paciente o encontrem
+	diet.UserEmail = normalizeEmail(diet.UserEmail)
+
+	if err := requireActivePatient(ctx, linkRepo, diet.CreatedBy, diet.UserEmail); err != nil {
+		return err
+	}
+
+	if err := validateFoodReferences(ctx, foodRepo, dayplan.AllMeals(diet)); err != nil {
+		return err
+	}
+
+	initDietLifecycle(diet, time.Now())
+	err := writeInFreeCalendar(ctx, dietRepo, diet, func() error {
+		return dietRepo.CreateDiet(ctx, diet)
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := recordRevision(ctx, revisionRepo, diet, entity.RevisionCreated, diet.CreatedBy, nil); err != nil {
 		return err
 	}
 
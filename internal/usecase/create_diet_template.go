@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// CreateDietTemplateUseCase stores a reusable diet template of the nutritionist
+type CreateDietTemplateUseCase interface {
+	Execute(ctx context.Context, userID string, template *entity.DietTemplate) (*entity.DietTemplate, error)
+}
+
+type createDietTemplateUseCase struct {
+	templateRepo DietTemplateRepository
+	foodRepo     FoodRepository
+	nutrition    nutritionCalculator
+}
+
+// NewCreateDietTemplate creates a new instance of CreateDietTemplateUseCase
+func NewCreateDietTemplate(templateRepo DietTemplateRepository, foodRepo FoodRepository) CreateDietTemplateUseCase {
+	return &createDietTemplateUseCase{
+		templateRepo: templateRepo,
+		foodRepo:     foodRepo,
+		nutrition:    nutritionCalculator{foodRepo: foodRepo},
+	}
+}
+
+func (uc *createDietTemplateUseCase) Execute(ctx context.Context, userID string, template *entity.DietTemplate) (*entity.DietTemplate, error) {
+	if err := validateFoodReferences(ctx, uc.foodRepo, dayplan.AllMeals(templateDiet(template))); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template.ID = uuid.NewString()
+	template.OwnerID = userID
+	template.CreatedAt = now
+	template.UpdatedAt = now
+
+	if err := uc.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	if err := annotateTemplates(ctx, uc.nutrition, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
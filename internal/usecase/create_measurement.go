@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// CreateMeasurementUseCase records a body measurement of a patient, informed
+// by the patient (weight) or by a linked nutritionist (full assessment)
+type CreateMeasurementUseCase interface {
+	// linkID vazio registra a medida do próprio usuário
+	Execute(ctx context.Context, userID, linkID string, measurement *entity.Measurement) (*entity.Measurement, error)
+}
+
+type createMeasurementUseCase struct {
+	measurementRepo MeasurementRepository
+	linkRepo        PatientLinkRepository
+	userRepo        UserRepository
+}
+
+// NewCreateMeasurement creates a new instance of CreateMeasurementUseCase
+func NewCreateMeasurement(measurementRepo MeasurementRepository, linkRepo PatientLinkRepository, userRepo UserRepository) CreateMeasurementUseCase {
+	return &createMeasurementUseCase{
+		measurementRepo: measurementRepo,
+		linkRepo:        linkRepo,
+		userRepo:        userRepo,
+	}
+}
+
+func (uc *createMeasurementUseCase) Execute(ctx context.Context, userID, linkID string, measurement *entity.Measurement) (*entity.Measurement, error) {
+	patient, source, err := measurementPatient(ctx, uc.linkRepo, uc.userRepo, userID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateMeasurement(measurement, source); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	measurement.ID = uuid.NewString()
+	measurement.PatientID = patient.ID.Hex()
+	measurement.Source = source
+	measurement.CreatedBy = userID
+	measurement.CreatedAt = now
+	measurement.UpdatedAt = now
+	if measurement.MeasuredAt.IsZero() {
+		measurement.MeasuredAt = now
+	}
+
+	if err := uc.measurementRepo.Create(ctx, measurement); err != nil {
+		return nil, err
+	}
+
+	deriveMeasurementMetrics(measurement, anthropometricHeight(patient))
+	return measurement, nil
+}
@@ -3,8 +3,10 @@ package usecase
 import (
 	"context"
 	"errors"
+	"log"
 
 	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 var ErrEmailAlreadyExists = errors.New("a user with this email already exists")
@@ -12,6 +14,7 @@ var ErrEmailAlreadyExists = errors.New("a user with this email already exists")
 // CreateUserUseCase handles the logic for creating a new user.
 type createUserUseCase struct {
 	userRepo UserRepository
+	emails   *accountEmails
 }
 
 type CreateUser interface {
@@ -19,12 +22,17 @@ type CreateUser interface {
 }
 
 // NewCreateUser creates a new instance of CreateUserUseCase.
-func NewCreateUser(userRepo UserRepository) CreateUser {
-	return &createUserUseCase{userRepo: userRepo}
+func NewCreateUser(userRepo UserRepository, tokenRepo UserTokenRepository, mailer Mailer, baseURL string) CreateUser {
+	return &createUserUseCase{
+		userRepo: userRepo,
+		emails:   newAccountEmails(tokenRepo, mailer, baseURL),
+	}
 }
 
-// Execute creates a new user.
+// Execute creates a new user and emails the address verification link.
 func (uc *createUserUseCase) Execute(ctx context.Context, user *entity.User) error {
+	user.Email = normalizeEmail(user.Email)
+
 	existing, err := uc.userRepo.FindByEmail(ctx, user.Email)
 	if err != nil {
 		return err
@@ -33,10 +41,21 @@ func (uc *createUserUseCase) Execute(ctx context.Context, user *entity.User) err
 		return ErrEmailAlreadyExists
 	}
 
-	_, err = uc.userRepo.Create(ctx, user)
+	user.EmailVerified = false
+
+	id, err := uc.userRepo.Create(ctx, user)
 	if err != nil {
 		return err
 	}
 
+	if user.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return err
+	}
+
+	// The account is already created; the user can ask for a new link later.
+	if err := uc.emails.sendEmailVerification(ctx, user); err != nil {
+		log.Printf("[CreateUserUseCase] Failed to send verification email: %v", err)
+	}
+
 	return nil
 }
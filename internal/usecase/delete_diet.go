@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+// DeleteDietUseCase remove (soft delete) uma dieta. Apenas o autor pode removê-la.
+type DeleteDietUseCase interface {
+	Execute(ctx context.Context, userID, dietID string) error
+}
+
+type deleteDietUseCase struct {
+	dietRepo DietRepository
+}
+
+// NewDeleteDiet cria uma nova instância de DeleteDietUseCase
+func NewDeleteDiet(dietRepo DietRepository) DeleteDietUseCase {
+	return &deleteDietUseCase{
+		dietRepo: dietRepo,
+	}
+}
+
+func (uc *deleteDietUseCase) Execute(ctx context.Context, userID, dietID string) error {
+	diet, err := uc.dietRepo.GetDietByID(ctx, dietID)
+	if err != nil {
+		return err
+	}
+
+	if diet == nil || diet.IsDeleted() {
+		return ErrDietNotFound
+	}
+
+	if diet.CreatedBy != userID {
+		return ErrUnauthorized
+	}
+
+	return uc.dietRepo.SoftDeleteDiet(ctx, diet.ID, userID, time.Now())
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+)
+
+// DeleteDietTemplateUseCase removes a template of the nutritionist. Diets
+// created from it are kept.
+type DeleteDietTemplateUseCase interface {
+	Execute(ctx context.Context, userID, templateID string) error
+}
+
+type deleteDietTemplateUseCase struct {
+	templateRepo DietTemplateRepository
+}
+
+// NewDeleteDietTemplate creates a new instance of DeleteDietTemplateUseCase
+func NewDeleteDietTemplate(templateRepo DietTemplateRepository) DeleteDietTemplateUseCase {
+	return &deleteDietTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+func (uc *deleteDietTemplateUseCase) Execute(ctx context.Context, userID, templateID string) error {
+	if _, err := findOwnedTemplate(ctx, uc.templateRepo, userID, templateID); err != nil {
+		return err
+	}
+
+	return uc.templateRepo.Delete(ctx, templateID)
+}
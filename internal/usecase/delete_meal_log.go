@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+)
+
+// DeleteMealLogUseCase lets the patient remove a meal log
+type DeleteMealLogUseCase interface {
+	Execute(ctx context.Context, userID, dietID, logID string) error
+}
+
+type deleteMealLogUseCase struct {
+	dietRepo    DietRepository
+	userRepo    UserRepository
+	mealLogRepo MealLogRepository
+}
+
+// NewDeleteMealLog creates a new instance of DeleteMealLogUseCase
+func NewDeleteMealLog(dietRepo DietRepository, userRepo UserRepository, mealLogRepo MealLogRepository) DeleteMealLogUseCase {
+	return &deleteMealLogUseCase{
+		dietRepo:    dietRepo,
+		userRepo:    userRepo,
+		mealLogRepo: mealLogRepo,
+	}
+}
+
+func (uc *deleteMealLogUseCase) Execute(ctx context.Context, userID, dietID, logID string) error {
+	diet, _, err := patientDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return err
+	}
+
+	log, err := findDietMealLog(ctx, uc.mealLogRepo, diet.ID, logID)
+	if err != nil {
+		return err
+	}
+
+	return uc.mealLogRepo.Delete(ctx, log.ID)
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+)
+
+// DeleteMeasurementUseCase removes a measurement. Only the author of the
+// measurement can remove it.
+type DeleteMeasurementUseCase interface {
+	Execute(ctx context.Context, userID, linkID, measurementID string) error
+}
+
+type deleteMeasurementUseCase struct {
+	measurementRepo MeasurementRepository
+	linkRepo        PatientLinkRepository
+	userRepo        UserRepository
+}
+
+// NewDeleteMeasurement creates a new instance of DeleteMeasurementUseCase
+func NewDeleteMeasurement(measurementRepo MeasurementRepository, linkRepo PatientLinkRepository, userRepo UserRepository) DeleteMeasurementUseCase {
+	return &deleteMeasurementUseCase{
+		measurementRepo: measurementRepo,
+		linkRepo:        linkRepo,
+		userRepo:        userRepo,
+	}
+}
+
+func (uc *deleteMeasurementUseCase) Execute(ctx context.Context, userID, linkID, measurementID string) error {
+	patient, _, err := measurementPatient(ctx, uc.linkRepo, uc.userRepo, userID, linkID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := findPatientMeasurement(ctx, uc.measurementRepo, patient.ID.Hex(), measurementID)
+	if err != nil {
+		return err
+	}
+	if existing.CreatedBy != userID {
+		return ErrUnauthorized
+	}
+
+	return uc.measurementRepo.Delete(ctx, existing.ID)
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+const (
+	DietSortCreatedAt = "created_at"
+	DietSortUpdatedAt = "updated_at"
+	DietSortName      = "name"
+
+	defaultDietPageSize = 20
+	maxDietPageSize     = 100
+)
+
+// DietCursor marks the last diet of a page. The next page starts right after
+// it in the (sort field, _id) order, so pages stay stable while diets are added.
+type DietCursor struct {
+	SortBy   string    `json:"s"`
+	Desc     bool      `json:"d"`
+	LastTime time.Time `json:"t,omitempty"`
+	LastName string    `json:"n,omitempty"`
+	LastID   string    `json:"id"`
+}
+
+func isValidDietSort(sortBy string) bool {
+	switch sortBy {
+	case DietSortCreatedAt, DietSortUpdatedAt, DietSortName:
+		return true
+	}
+	return false
+}
+
+func newDietCursor(diet *entity.Diet, sortBy string, desc bool) *DietCursor {
+	cursor := &DietCursor{SortBy: sortBy, Desc: desc, LastID: diet.ID}
+	switch sortBy {
+	case DietSortUpdatedAt:
+		cursor.LastTime = diet.UpdatedAt
+	case DietSortName:
+		cursor.LastName = diet.DietName
+	default:
+		cursor.LastTime = diet.CreatedAt
+	}
+	return cursor
+}
+
+// encode turns the cursor into the opaque token handed to clients
+func (c *DietCursor) encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeDietCursor(token string) (*DietCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor DietCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.LastID == "" || !isValidDietSort(cursor.SortBy) {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
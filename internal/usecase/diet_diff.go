@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// diffSnapshots compares two snapshots of a diet. Meals are matched by name
+// and ingredients by description; repeated names are paired in order. Meals
+// of day templates are compared within the template with the same name.
+func diffSnapshots(from, to entity.DietSnapshot) ([]entity.FieldDiff, []entity.MealDiff) {
+	fields := []entity.FieldDiff{}
+	fields = appendFieldDiff(fields, "name", from.DietName, to.DietName)
+	fields = appendFieldDiff(fields, "duration_in_days", from.DurationInDays, to.DurationInDays)
+	fields = appendFieldDiff(fields, "status", from.Status, to.Status)
+	fields = appendFieldDiff(fields, "observations", from.Observations, to.Observations)
+	fields = appendFieldDiff(fields, "texture", from.Texture, to.Texture)
+	fields = appendFieldDiff(fields, "day_rule", describeDayRule(from.DayRule), describeDayRule(to.DayRule))
+
+	meals := diffMeals("", from.Meals, to.Meals)
+
+	toDays := indexByKey(len(to.Days), func(i int) string { return to.Days[i].Name })
+	matched := make(map[int]bool, len(to.Days))
+
+	fromDays := indexByKey(len(from.Days), func(i int) string { return from.Days[i].Name })
+	for i, key := range fromDays.keys {
+		old := from.Days[i]
+		j, ok := toDays.positions[key]
+		if !ok {
+			meals = append(meals, diffMeals(old.Name, old.Meals, nil)...)
+			continue
+		}
+
+		matched[j] = true
+		fields = appendFieldDiff(fields, "days."+old.Name+".weekdays", describeWeekdays(old.Weekdays), describeWeekdays(to.Days[j].Weekdays))
+		meals = append(meals, diffMeals(old.Name, old.Meals, to.Days[j].Meals)...)
+	}
+
+	for j, day := range to.Days {
+		if !matched[j] {
+			meals = append(meals, diffMeals(day.Name, nil, day.Meals)...)
+		}
+	}
+
+	return fields, meals
+}
+
+// diffMeals compares the meals of one day; day is the name of the template,
+// empty for the meals of the diet itself
+func diffMeals(day string, from, to []entity.Meal) []entity.MealDiff {
+	meals := []entity.MealDiff{}
+	toMeals := indexByKey(len(to), func(i int) string { return to[i].Name })
+	matched := make(map[int]bool, len(to))
+
+	fromMeals := indexByKey(len(from), func(i int) string { return from[i].Name })
+	for i, key := range fromMeals.keys {
+		old := from[i]
+		j, ok := toMeals.positions[key]
+		if !ok {
+			meals = append(meals, entity.MealDiff{Day: day, Name: old.Name, Change: entity.DiffRemoved})
+			continue
+		}
+
+		matched[j] = true
+		if diff, changed := diffMeal(old, to[j]); changed {
+			diff.Day = day
+			meals = append(meals, diff)
+		}
+	}
+
+	for j, meal := range to {
+		if !matched[j] {
+			meals = append(meals, entity.MealDiff{Day: day, Name: meal.Name, Change: entity.DiffAdded})
+		}
+	}
+
+	return meals
+}
+
+// describeDayRule renders the rule as comparable text, such as "ROTATION: A, B"
+func describeDayRule(rule *entity.DayRule) string {
+	if rule == nil {
+		return ""
+	}
+	if len(rule.Rotation) == 0 {
+		return string(rule.Type)
+	}
+	return string(rule.Type) + ": " + strings.Join(rule.Rotation, ", ")
+}
+
+func describeWeekdays(weekdays []entity.Weekday) string {
+	names := make([]string, 0, len(weekdays))
+	for _, weekday := range weekdays {
+		names = append(names, string(weekday))
+	}
+	return strings.Join(names, ", ")
+}
+
+func diffMeal(from, to entity.Meal) (entity.MealDiff, bool) {
+	diff := entity.MealDiff{Name: to.Name, Change: entity.DiffModified}
+	diff.Fields = appendFieldDiff(nil, "description", from.Description, to.Description)
+	diff.Fields = appendFieldDiff(diff.Fields, "time_of_day", from.TimeOfDay, to.TimeOfDay)
+	diff.Fields = appendFieldDiff(diff.Fields, "window_start", from.WindowStart, to.WindowStart)
+	diff.Fields = appendFieldDiff(diff.Fields, "window_end", from.WindowEnd, to.WindowEnd)
+	diff.Fields = appendFieldDiff(diff.Fields, "slot", from.Slot, to.Slot)
+
+	toIngredients := indexByKey(len(to.Ingredients), func(i int) string { return to.Ingredients[i].Description })
+	matched := make(map[int]bool, len(to.Ingredients))
+
+	fromIngredients := indexByKey(len(from.Ingredients), func(i int) string { return from.Ingredients[i].Description })
+	for i, key := range fromIngredients.keys {
+		old := from.Ingredients[i]
+		j, ok := toIngredients.positions[key]
+		if !ok {
+			diff.Ingredients = append(diff.Ingredients, entity.IngredientDiff{
+				Description:  old.Description,
+				Change:       entity.DiffRemoved,
+				FromQuantity: &old.Quantity,
+				FromUnit:     old.Unit,
+			})
+			continue
+		}
+
+		matched[j] = true
+		current := to.Ingredients[j]
+		if old.Quantity == current.Quantity && old.Unit == current.Unit && reflect.DeepEqual(old.Substitutes, current.Substitutes) {
+			continue
+		}
+
+		diff.Ingredients = append(diff.Ingredients, entity.IngredientDiff{
+			Description:        current.Description,
+			Change:             entity.DiffModified,
+			FromQuantity:       &old.Quantity,
+			ToQuantity:         &current.Quantity,
+			FromUnit:           old.Unit,
+			ToUnit:             current.Unit,
+			SubstitutesChanged: !reflect.DeepEqual(old.Substitutes, current.Substitutes),
+		})
+	}
+
+	for j := range to.Ingredients {
+		if !matched[j] {
+			added := to.Ingredients[j]
+			diff.Ingredients = append(diff.Ingredients, entity.IngredientDiff{
+				Description: added.Description,
+				Change:      entity.DiffAdded,
+				ToQuantity:  &added.Quantity,
+				ToUnit:      added.Unit,
+			})
+		}
+	}
+
+	return diff, len(diff.Fields) > 0 || len(diff.Ingredients) > 0
+}
+
+func appendFieldDiff(fields []entity.FieldDiff, name string, from, to interface{}) []entity.FieldDiff {
+	if from == to {
+		return fields
+	}
+	return append(fields, entity.FieldDiff{Field: name, From: from, To: to})
+}
+
+// keyIndex maps a normalized name to its position; keys keeps the original
+// order. The n-th repetition of a name gets the key "name#n" so duplicates are
+// paired in order.
+type keyIndex struct {
+	keys      []string
+	positions map[string]int
+}
+
+func indexByKey(n int, name func(i int) string) keyIndex {
+	index := keyIndex{keys: make([]string, 0, n), positions: make(map[string]int, n)}
+	seen := make(map[string]int, n)
+
+	for i := 0; i < n; i++ {
+		base := strings.ToLower(strings.TrimSpace(name(i)))
+		key := fmt.Sprintf("%s#%d", base, seen[base])
+		seen[base]++
+
+		index.keys = append(index.keys, key)
+		index.positions[key] = i
+	}
+	return index
+}
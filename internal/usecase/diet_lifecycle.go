@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// dietTransitions lists the statuses a nutritionist may move a diet to.
+// SCHEDULED -> ACTIVE and ACTIVE -> EXPIRED also happen automatically when
+// the dates are reached.
+var dietTransitions = map[entity.DietStatus][]entity.DietStatus{
+	entity.DietDraft:     {entity.DietScheduled, entity.DietActive, entity.DietArchived},
+	entity.DietScheduled: {entity.DietDraft, entity.DietActive, entity.DietArchived},
+	entity.DietActive:    {entity.DietExpired, entity.DietArchived},
+	entity.DietExpired:   {entity.DietArchived},
+	entity.DietArchived:  {},
+}
+
+// canTransition reports whether the diet may move from one status to another
+func canTransition(from, to entity.DietStatus) bool {
+	for _, allowed := range dietTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// occupiesCalendar reports whether the status counts towards the rule of a
+// single active diet per patient
+func occupiesCalendar(status string) bool {
+	return status == string(entity.DietScheduled) || status == string(entity.DietActive)
+}
+
+// setDietDates fills EndsAt from StartsAt and the duration
+func setDietDates(diet *entity.Diet, startsAt time.Time) {
+	start := truncateToDay(startsAt)
+	end := start.AddDate(0, 0, int(diet.DurationInDays))
+	diet.StartsAt = &start
+	diet.EndsAt = &end
+}
+
+// statusForDates returns the status of a published diet on the instant
+func statusForDates(diet *entity.Diet, now time.Time) entity.DietStatus {
+	switch {
+	case diet.StartsAt != nil && now.Before(*diet.StartsAt):
+		return entity.DietScheduled
+	case diet.EndsAt != nil && !now.Before(*diet.EndsAt):
+		return entity.DietExpired
+	default:
+		return entity.DietActive
+	}
+}
+
+// initDietLifecycle sets the dates and the initial status of a new diet.
+// Diets start today unless starts_at was informed; drafts keep their status.
+func initDietLifecycle(diet *entity.Diet, now time.Time) {
+	if diet.StartsAt != nil {
+		setDietDates(diet, *diet.StartsAt)
+	} else if diet.Status != string(entity.DietDraft) {
+		setDietDates(diet, now)
+	}
+
+	if diet.Status != string(entity.DietDraft) {
+		diet.Status = string(statusForDates(diet, now))
+	}
+}
+
+// refreshDietLifecycle recomputes the end and, for scheduled or active diets,
+// the status after the dates or the duration of the diet changed. Expired
+// diets stay expired: expiring by hand keeps today as the last day, and
+// dietTransitions never leads back to ACTIVE.
+func refreshDietLifecycle(diet *entity.Diet, now time.Time) {
+	if diet.StartsAt != nil {
+		setDietDates(diet, *diet.StartsAt)
+	}
+
+	switch entity.DietStatus(diet.Status) {
+	case entity.DietScheduled, entity.DietActive:
+		diet.Status = string(statusForDates(diet, now))
+	}
+}
+
+// writeInFreeCalendar runs write after checking the calendar of the patient
+// with requireFreeCalendar. The calendar stays locked until the write ends, so
+// concurrent requests cannot both pass the check.
+func writeInFreeCalendar(ctx context.Context, dietRepo DietRepository, diet *entity.Diet, write func() error) error {
+	if !occupiesCalendar(diet.Status) || diet.StartsAt == nil || diet.EndsAt == nil {
+		return write()
+	}
+
+	unlock, err := dietRepo.LockCalendar(ctx, normalizeEmail(diet.UserEmail))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := requireFreeCalendar(ctx, dietRepo, diet); err != nil {
+		return err
+	}
+	return write()
+}
+
+// requireFreeCalendar enforces a single active diet per patient: the period
+// of a scheduled or active diet cannot overlap another scheduled or active
+// diet of the same patient.
+func requireFreeCalendar(ctx context.Context, dietRepo DietRepository, diet *entity.Diet) error {
+	if !occupiesCalendar(diet.Status) || diet.StartsAt == nil || diet.EndsAt == nil {
+		return nil
+	}
+
+	email := normalizeEmail(diet.UserEmail)
+	others, err := dietRepo.FindDiets(ctx, &DietFilter{
+		UserEmail: &email,
+		Statuses:  []string{string(entity.DietScheduled), string(entity.DietActive)},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, other := range others {
+		if other.ID == diet.ID || other.StartsAt == nil || other.EndsAt == nil {
+			continue
+		}
+		if other.StartsAt.Before(*diet.EndsAt) && diet.StartsAt.Before(*other.EndsAt) {
+			return fmt.Errorf("%w: %q (%s a %s)", ErrActiveDietConflict, other.DietName,
+				other.StartsAt.Format(entity.MealLogDateLayout), other.EndsAt.AddDate(0, 0, -1).Format(entity.MealLogDateLayout))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// maxRevisionAttempts limits the retries when a concurrent write takes the
+// revision number first
+const maxRevisionAttempts = 5
+
+// recordRevision stores a new immutable snapshot of the diet, numbered after
+// the latest revision.
+func recordRevision(ctx context.Context, revisionRepo DietRevisionRepository, diet *entity.Diet, action entity.DietRevisionAction, changedBy string, rolledBackFrom *int) (*entity.DietRevision, error) {
+	for attempt := 1; ; attempt++ {
+		latest, err := revisionRepo.FindLatest(ctx, diet.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		number := 1
+		if latest != nil {
+			number = latest.Number + 1
+		}
+
+		revision := &entity.DietRevision{
+			ID:             uuid.NewString(),
+			DietID:         diet.ID,
+			Number:         number,
+			Action:         action,
+			Snapshot:       diet.Snapshot(),
+			ChangedBy:      changedBy,
+			ChangedAt:      time.Now(),
+			RolledBackFrom: rolledBackFrom,
+		}
+
+		err = revisionRepo.Create(ctx, revision)
+		if errors.Is(err, ErrRevisionExists) && attempt < maxRevisionAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return revision, nil
+	}
+}
+
+// ensureBaselineRevision records the current state of diets created before
+// revisions existed, so their history starts from what was stored. Only the
+// use cases that change the diet call it.
+func ensureBaselineRevision(ctx context.Context, revisionRepo DietRevisionRepository, diet *entity.Diet) error {
+	latest, err := revisionRepo.FindLatest(ctx, diet.ID)
+	if err != nil {
+		return err
+	}
+
+	if latest != nil {
+		return nil
+	}
+
+	// Outra escrita concorrente já gravou a primeira revisão
+	if err := revisionRepo.Create(ctx, baselineRevision(diet)); err != nil && !errors.Is(err, ErrRevisionExists) {
+		return err
+	}
+	return nil
+}
+
+// baselineRevision is the first revision of a diet created before revisions
+// existed. The read use cases show it without storing it.
+func baselineRevision(diet *entity.Diet) *entity.DietRevision {
+	return &entity.DietRevision{
+		ID:        uuid.NewString(),
+		DietID:    diet.ID,
+		Number:    1,
+		Action:    entity.RevisionBaseline,
+		Snapshot:  diet.Snapshot(),
+		ChangedBy: diet.CreatedBy,
+		ChangedAt: diet.UpdatedAt,
+	}
+}
@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/units"
+)
+
+// findOwnedTemplate returns the template when it belongs to the nutritionist
+func findOwnedTemplate(ctx context.Context, templateRepo DietTemplateRepository, userID, templateID string) (*entity.DietTemplate, error) {
+	template, err := templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if template == nil {
+		return nil, ErrDietTemplateNotFound
+	}
+
+	if template.OwnerID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	return template, nil
+}
+
+// templateDiet returns a diet without patient with the plan of the template,
+// used to compute its nutrition and to instantiate it
+func templateDiet(template *entity.DietTemplate) *entity.Diet {
+	return &entity.Diet{
+		DietName:       template.Name,
+		DurationInDays: template.DurationInDays,
+		Meals:          template.Meals,
+		Days:           template.Days,
+		DayRule:        template.DayRule,
+		Observations:   template.Observations,
+	}
+}
+
+// annotateTemplates computes the nutrition of the templates. Templates have
+// no patient, so there is no target to compare with.
+func annotateTemplates(ctx context.Context, calculator nutritionCalculator, templates ...*entity.DietTemplate) error {
+	diets := make([]*entity.Diet, 0, len(templates))
+	for _, template := range templates {
+		diets = append(diets, templateDiet(template))
+	}
+
+	if err := calculator.annotate(ctx, diets...); err != nil {
+		return err
+	}
+
+	for i, template := range templates {
+		template.Nutrition = diets[i].Nutrition
+	}
+	return nil
+}
+
+// dietCopier creates diets for patients from templates or other diets,
+// optionally scaling every quantity to a calorie target
+type dietCopier struct {
+	dietRepo     DietRepository
+	linkRepo     PatientLinkRepository
+	revisionRepo DietRevisionRepository
+	foodRepo     FoodRepository
+	userRepo     UserRepository
+	nutrition    nutritionCalculator
+}
+
+func newDietCopier(dietRepo DietRepository, linkRepo PatientLinkRepository, revisionRepo DietRevisionRepository, foodRepo FoodRepository, userRepo UserRepository) dietCopier {
+	return dietCopier{
+		dietRepo:     dietRepo,
+		linkRepo:     linkRepo,
+		revisionRepo: revisionRepo,
+		foodRepo:     foodRepo,
+		userRepo:     userRepo,
+		nutrition:    nutritionCalculator{foodRepo: foodRepo, userRepo: userRepo},
+	}
+}
+
+// create stores the plan as a new diet of the patient of the request
+func (c dietCopier) create(ctx context.Context, userID string, plan *entity.Diet, req *dto.DietCopyRequest) (*entity.Diet, error) {
+	startsAt, err := req.StartDate()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	diet := &entity.Diet{
+		UserEmail:      normalizeEmail(req.UserEmail),
+		DietName:       plan.DietName,
+		DurationInDays: plan.DurationInDays,
+		Status:         req.Status,
+		StartsAt:       startsAt,
+		Meals:          copyMeals(plan.Meals),
+		Days:           copyDays(plan.Days),
+		DayRule:        plan.DayRule,
+		Observations:   plan.Observations,
+		Texture:        plan.Texture,
+		CreatedBy:      userID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		Version:        1,
+	}
+	if req.DietName != "" {
+		diet.DietName = req.DietName
+	}
+
+	link, err := activePatientLink(ctx, c.linkRepo, userID, diet.UserEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.scaleToTarget(ctx, diet, link, req); err != nil {
+		return nil, err
+	}
+
+	if err := insertDiet(ctx, c.dietRepo, c.linkRepo, c.revisionRepo, c.foodRepo, diet); err != nil {
+		return nil, err
+	}
+
+	if err := c.nutrition.annotate(ctx, diet); err != nil {
+		return nil, err
+	}
+
+	return diet, nil
+}
+
+// scaleToTarget multiplies every quantity of the diet so its daily energy
+// matches the target of the request, when one was asked. The patient target
+// comes from the account of the linked patient.
+func (c dietCopier) scaleToTarget(ctx context.Context, diet *entity.Diet, link *entity.PatientLink, req *dto.DietCopyRequest) error {
+	var target float64
+	switch {
+	case req.TargetKcal != nil:
+		target = *req.TargetKcal
+	case req.ScaleToPatientTarget:
+		patient, err := linkPatient(ctx, c.userRepo, link)
+		if err != nil {
+			return err
+		}
+		if patient == nil {
+			return ErrUserNotFound
+		}
+
+		assessment, err := assessEnergy(patient, "")
+		if err != nil {
+			return err
+		}
+		target = assessment.Targets.EnergyKcal
+	default:
+		return nil
+	}
+
+	if err := c.nutrition.annotate(ctx, diet); err != nil {
+		return err
+	}
+
+	current := diet.Nutrition.PerDay.EnergyKcal
+	if current <= 0 {
+		return fmt.Errorf("%w: a energia da dieta não pôde ser calculada", ErrInvalidDietScaling)
+	}
+	if target <= 0 {
+		return fmt.Errorf("%w: a meta de energia do paciente é %g kcal", ErrInvalidDietScaling, target)
+	}
+
+	scaleMeals(diet.Meals, target/current)
+	for i := range diet.Days {
+		scaleMeals(diet.Days[i].Meals, target/current)
+	}
+	diet.Nutrition = nil
+	return nil
+}
+
+// scaleMeals multiplies the quantities of the ingredients and their
+// substitutes, rounding them to what can be measured in each unit
+func scaleMeals(meals []entity.Meal, factor float64) {
+	for i := range meals {
+		scaleIngredients(meals[i].Ingredients, factor)
+	}
+}
+
+func scaleIngredients(ingredients []entity.Ingredient, factor float64) {
+	for i := range ingredients {
+		ingredients[i].Quantity = units.Round(ingredients[i].Quantity*factor, ingredients[i].Unit)
+		scaleIngredients(ingredients[i].Substitutes, factor)
+	}
+}
+
+// copyMeals returns a copy of the meals that can be changed without touching
+// the source
+func copyMeals(meals []entity.Meal) []entity.Meal {
+	if meals == nil {
+		return nil
+	}
+
+	copied := make([]entity.Meal, len(meals))
+	for i, meal := range meals {
+		copied[i] = meal
+		copied[i].Ingredients = copyIngredients(meal.Ingredients)
+	}
+	return copied
+}
+
+func copyIngredients(ingredients []entity.Ingredient) []entity.Ingredient {
+	if ingredients == nil {
+		return nil
+	}
+
+	copied := make([]entity.Ingredient, len(ingredients))
+	for i, ingredient := range ingredients {
+		copied[i] = ingredient
+		copied[i].Substitutes = copyIngredients(ingredient.Substitutes)
+	}
+	return copied
+}
+
+func copyDays(days []entity.DayTemplate) []entity.DayTemplate {
+	if days == nil {
+		return nil
+	}
+
+	copied := make([]entity.DayTemplate, len(days))
+	for i, day := range days {
+		copied[i] = day
+		copied[i].Meals = copyMeals(day.Meals)
+	}
+	return copied
+}
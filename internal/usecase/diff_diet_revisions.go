@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// DiffDietRevisionsUseCase compares two revisions of a diet
+type DiffDietRevisionsUseCase interface {
+	Execute(ctx context.Context, userID, dietID string, from, to int) (*entity.DietDiff, error)
+}
+
+type diffDietRevisionsUseCase struct {
+	dietRepo     DietRepository
+	userRepo     UserRepository
+	revisionRepo DietRevisionRepository
+}
+
+// NewDiffDietRevisions cria uma nova instância de DiffDietRevisionsUseCase
+func NewDiffDietRevisions(dietRepo DietRepository, userRepo UserRepository, revisionRepo DietRevisionRepository) DiffDietRevisionsUseCase {
+	return &diffDietRevisionsUseCase{
+		dietRepo:     dietRepo,
+		userRepo:     userRepo,
+		revisionRepo: revisionRepo,
+	}
+}
+
+func (uc *diffDietRevisionsUseCase) Execute(ctx context.Context, userID, dietID string, from, to int) (*entity.DietDiff, error) {
+	diet, err := visibleDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := findRevision(ctx, uc.revisionRepo, diet, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRevision, err := findRevision(ctx, uc.revisionRepo, diet, to)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, meals := diffSnapshots(fromRevision.Snapshot, toRevision.Snapshot)
+
+	return &entity.DietDiff{
+		DietID: diet.ID,
+		From:   from,
+		To:     to,
+		Fields: fields,
+		Meals:  meals,
+	}, nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+)
+
+// DisableCalendarFeedUseCase revokes the iCalendar link of the user
+type DisableCalendarFeedUseCase interface {
+	Execute(ctx context.Context, userID string) error
+}
+
+type disableCalendarFeedUseCase struct {
+	calendarFeedRepo CalendarFeedRepository
+}
+
+// NewDisableCalendarFeed creates a new instance of DisableCalendarFeedUseCase
+func NewDisableCalendarFeed(calendarFeedRepo CalendarFeedRepository) DisableCalendarFeedUseCase {
+	return &disableCalendarFeedUseCase{
+		calendarFeedRepo: calendarFeedRepo,
+	}
+}
+
+func (uc *disableCalendarFeedUseCase) Execute(ctx context.Context, userID string) error {
+	return uc.calendarFeedRepo.Delete(ctx, userID)
+}
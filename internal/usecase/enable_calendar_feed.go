@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// EnableCalendarFeedUseCase creates the secret iCalendar link of the user.
+// Calling it again rotates the token, so a leaked link stops working.
+type EnableCalendarFeedUseCase interface {
+	Execute(ctx context.Context, userID string) (*entity.CalendarFeedOutput, error)
+}
+
+type enableCalendarFeedUseCase struct {
+	calendarFeedRepo CalendarFeedRepository
+	userRepo         UserRepository
+	baseURL          string
+}
+
+// NewEnableCalendarFeed creates a new instance of EnableCalendarFeedUseCase
+func NewEnableCalendarFeed(calendarFeedRepo CalendarFeedRepository, userRepo UserRepository, baseURL string) EnableCalendarFeedUseCase {
+	return &enableCalendarFeedUseCase{
+		calendarFeedRepo: calendarFeedRepo,
+		userRepo:         userRepo,
+		baseURL:          strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (uc *enableCalendarFeedUseCase) Execute(ctx context.Context, userID string) (*entity.CalendarFeedOutput, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &entity.CalendarFeed{
+		UserID:    userID,
+		TokenHash: hash,
+		CreatedAt: time.Now(),
+	}
+	if err := uc.calendarFeedRepo.Save(ctx, feed); err != nil {
+		return nil, err
+	}
+
+	// O token só é devolvido aqui; depois disso apenas o hash fica salvo
+	return &entity.CalendarFeedOutput{
+		URL:       uc.baseURL + "/v1/calendar/" + token + ".ics",
+		CreatedAt: feed.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// EndPatientLinkUseCase ends a link. Either side can end an active link and
+// the nutritionist can also cancel a pending invitation.
+type EndPatientLinkUseCase interface {
+	Execute(ctx context.Context, userID, linkID string) (*entity.PatientLink, error)
+}
+
+type endPatientLinkUseCase struct {
+	linkRepo PatientLinkRepository
+	userRepo UserRepository
+}
+
+// NewEndPatientLink creates a new instance of EndPatientLinkUseCase
+func NewEndPatientLink(linkRepo PatientLinkRepository, userRepo UserRepository) EndPatientLinkUseCase {
+	return &endPatientLinkUseCase{
+		linkRepo: linkRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (uc *endPatientLinkUseCase) Execute(ctx context.Context, userID, linkID string) (*entity.PatientLink, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	link, err := uc.linkRepo.FindByID(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	isNutritionist := link != nil && link.NutritionistID == userID
+	isPatient := link != nil && link.PatientEmail == normalizeEmail(user.Email)
+	if !isNutritionist && !isPatient {
+		return nil, ErrPatientLinkNotFound
+	}
+
+	from := link.Status
+	switch {
+	case from == entity.PatientLinkActive:
+	case from == entity.PatientLinkPending && isNutritionist:
+	default:
+		return nil, ErrInvalidLinkTransition
+	}
+
+	now := time.Now()
+	link.Status = entity.PatientLinkEnded
+	link.EndedAt = &now
+	link.EndedBy = userID
+
+	updated, err := uc.linkRepo.UpdateStatus(ctx, link, from)
+	if err != nil {
+		return nil, err
+	}
+
+	if !updated {
+		return nil, ErrInvalidLinkTransition
+	}
+
+	return link, nil
+}
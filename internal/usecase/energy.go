@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+const (
+	// targetTolerancePct é a diferença de energia aceita entre a dieta e a meta
+	targetTolerancePct = 10.0
+
+	defaultFatPct = 25.0
+)
+
+var activityFactors = map[entity.ActivityLevel]float64{
+	entity.ActivitySedentary:  1.2,
+	entity.ActivityLight:      1.375,
+	entity.ActivityModerate:   1.55,
+	entity.ActivityActive:     1.725,
+	entity.ActivityVeryActive: 1.9,
+}
+
+// goalDefaults are the calorie adjustment over the TDEE and the protein per
+// kg of body weight used when the nutritionist does not override them
+var goalDefaults = map[entity.NutritionGoal]struct {
+	calorieAdjustmentKcal float64
+	proteinGPerKg         float64
+}{
+	entity.GoalLoseWeight: {-500, 2.0},
+	entity.GoalMaintain:   {0, 1.6},
+	entity.GoalGainWeight: {300, 1.8},
+}
+
+// validateAnthropometrics checks the ranges and enums of the anthropometrics
+func validateAnthropometrics(a *entity.Anthropometrics) error {
+	switch {
+	case a.HeightCm < 50 || a.HeightCm > 250:
+		return fmt.Errorf("%w: height_cm deve estar entre 50 e 250", ErrInvalidAnthropometrics)
+	case a.WeightKg < 20 || a.WeightKg > 350:
+		return fmt.Errorf("%w: weight_kg deve estar entre 20 e 350", ErrInvalidAnthropometrics)
+	case a.TargetWeightKg != nil && (*a.TargetWeightKg < 20 || *a.TargetWeightKg > 350):
+		return fmt.Errorf("%w: target_weight_kg deve estar entre 20 e 350", ErrInvalidAnthropometrics)
+	case a.BodyFatPct != nil && (*a.BodyFatPct < 2 || *a.BodyFatPct > 70):
+		return fmt.Errorf("%w: body_fat_pct deve estar entre 2 e 70", ErrInvalidAnthropometrics)
+	case a.Sex != "" && a.Sex != "male" && a.Sex != "female":
+		return fmt.Errorf("%w: sex deve ser male ou female", ErrInvalidAnthropometrics)
+	case a.ProteinGPerKg != nil && (*a.ProteinGPerKg <= 0 || *a.ProteinGPerKg > 4):
+		return fmt.Errorf("%w: protein_g_per_kg deve estar entre 0 e 4", ErrInvalidAnthropometrics)
+	case a.FatPct != nil && (*a.FatPct <= 0 || *a.FatPct >= 100):
+		return fmt.Errorf("%w: fat_pct deve estar entre 0 e 100", ErrInvalidAnthropometrics)
+	}
+
+	if _, ok := activityFactors[a.ActivityLevel]; !ok {
+		return fmt.Errorf("%w: activity_level desconhecido: %q", ErrInvalidAnthropometrics, a.ActivityLevel)
+	}
+	if _, ok := goalDefaults[a.Goal]; !ok {
+		return fmt.Errorf("%w: goal desconhecido: %q", ErrInvalidAnthropometrics, a.Goal)
+	}
+	if a.Equation == entity.EquationKatchMcArdle && a.BodyFatPct == nil {
+		return fmt.Errorf("%w: a equação de Katch-McArdle exige body_fat_pct", ErrInvalidAnthropometrics)
+	}
+	if !isKnownEquation(a.Equation) {
+		return fmt.Errorf("%w: equation desconhecida: %q", ErrInvalidAnthropometrics, a.Equation)
+	}
+	return nil
+}
+
+func isKnownEquation(equation entity.EnergyEquation) bool {
+	switch equation {
+	case entity.EquationMifflinStJeor, entity.EquationHarrisBenedict, entity.EquationKatchMcArdle:
+		return true
+	}
+	return false
+}
+
+// assessEnergy estimates the energy expenditure of the patient with the
+// equation of the anthropometrics, or with the informed one
+func assessEnergy(user *entity.User, equation entity.EnergyEquation) (*entity.EnergyAssessment, error) {
+	a := user.Anthropometrics
+	if a == nil {
+		return nil, ErrAnthropometricsNotFound
+	}
+
+	if equation == "" {
+		equation = a.Equation
+	}
+	if !isKnownEquation(equation) {
+		return nil, fmt.Errorf("%w: equation desconhecida: %q", ErrInvalidAnthropometrics, equation)
+	}
+
+	sex := a.Sex
+	if sex == "" {
+		sex = strings.ToLower(user.Gender)
+	}
+
+	byEquation := map[entity.EnergyEquation]float64{}
+	for _, candidate := range []entity.EnergyEquation{entity.EquationMifflinStJeor, entity.EquationHarrisBenedict, entity.EquationKatchMcArdle} {
+		if bmr, err := basalMetabolicRate(candidate, sex, user.Age, a); err == nil {
+			byEquation[candidate] = round2(bmr)
+		}
+	}
+
+	bmr, err := basalMetabolicRate(equation, sex, user.Age, a)
+	if err != nil {
+		return nil, err
+	}
+
+	factor := activityFactors[a.ActivityLevel]
+	tdee := bmr * factor
+
+	return &entity.EnergyAssessment{
+		PatientEmail:    user.Email,
+		Anthropometrics: *a,
+		Age:             user.Age,
+		Sex:             sex,
+		Equation:        equation,
+		BMRKcal:         round2(bmr),
+		ActivityFactor:  factor,
+		TDEEKcal:        round2(tdee),
+		Targets:         energyTargets(tdee, a),
+		BMRByEquation:   byEquation,
+	}, nil
+}
+
+// basalMetabolicRate applies one of the equations, in kcal/day. Mifflin-St Jeor
+// and Harris-Benedict (revised by Roza and Shizgal) need the sex; when it is not
+// male or female the average of both is used. Katch-McArdle uses the lean mass
+// and needs the body fat.
+func basalMetabolicRate(equation entity.EnergyEquation, sex string, age int, a *entity.Anthropometrics) (float64, error) {
+	w, h, years := a.WeightKg, a.HeightCm, float64(age)
+
+	switch equation {
+	case entity.EquationMifflinStJeor:
+		base := 10*w + 6.25*h - 5*years
+		return bySex(sex, base+5, base-161), nil
+	case entity.EquationHarrisBenedict:
+		male := 88.362 + 13.397*w + 4.799*h - 5.677*years
+		female := 447.593 + 9.247*w + 3.098*h - 4.330*years
+		return bySex(sex, male, female), nil
+	case entity.EquationKatchMcArdle:
+		if a.BodyFatPct == nil {
+			return 0, fmt.Errorf("%w: a equação de Katch-McArdle exige body_fat_pct", ErrInvalidAnthropometrics)
+		}
+		leanMass := w * (1 - *a.BodyFatPct/100)
+		return 370 + 21.6*leanMass, nil
+	default:
+		return 0, fmt.Errorf("%w: equation desconhecida: %q", ErrInvalidAnthropometrics, equation)
+	}
+}
+
+func bySex(sex string, male, female float64) float64 {
+	switch sex {
+	case "male":
+		return male
+	case "female":
+		return female
+	default:
+		return (male + female) / 2
+	}
+}
+
+// energyTargets adjusts the TDEE to the goal and splits it in macronutrients:
+// protein by body weight, fat as a share of the energy and carbohydrates with
+// the remaining energy
+func energyTargets(tdee float64, a *entity.Anthropometrics) entity.EnergyTargets {
+	defaults := goalDefaults[a.Goal]
+
+	adjustment := defaults.calorieAdjustmentKcal
+	if a.CalorieAdjustmentKcal != nil {
+		adjustment = *a.CalorieAdjustmentKcal
+	}
+	proteinGPerKg := defaults.proteinGPerKg
+	if a.ProteinGPerKg != nil {
+		proteinGPerKg = *a.ProteinGPerKg
+	}
+	fatPct := defaultFatPct
+	if a.FatPct != nil {
+		fatPct = *a.FatPct
+	}
+
+	energy := math.Max(tdee+adjustment, 0)
+	protein := proteinGPerKg * a.WeightKg
+	fat := energy * fatPct / 100 / 9
+	carbohydrate := math.Max((energy-protein*4-fat*9)/4, 0)
+
+	targets := entity.Nutrients{EnergyKcal: energy, ProteinG: protein, CarbohydrateG: carbohydrate, FatG: fat}
+	return entity.EnergyTargets{
+		EnergyKcal:    round2(energy),
+		ProteinG:      round2(protein),
+		CarbohydrateG: round2(carbohydrate),
+		FatG:          round2(fat),
+		MacroSplit:    targets.MacroSplit(),
+	}
+}
+
+// targetDeviation compares the daily nutrients of a diet with the targets
+func targetDeviation(perDay entity.Nutrients, targets entity.EnergyTargets) *entity.TargetDeviation {
+	deviation := &entity.TargetDeviation{
+		Targets:           targets,
+		EnergyDiffKcal:    round2(perDay.EnergyKcal - targets.EnergyKcal),
+		ProteinDiffG:      round2(perDay.ProteinG - targets.ProteinG),
+		CarbohydrateDiffG: round2(perDay.CarbohydrateG - targets.CarbohydrateG),
+		FatDiffG:          round2(perDay.FatG - targets.FatG),
+	}
+
+	if targets.EnergyKcal > 0 {
+		deviation.EnergyDiffPct = round2((perDay.EnergyKcal - targets.EnergyKcal) / targets.EnergyKcal * 100)
+	}
+	deviation.WithinTolerance = math.Abs(deviation.EnergyDiffPct) <= targetTolerancePct
+	return deviation
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -1,8 +1,64 @@
 package usecase
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var (
-	ErrUnauthorized = errors.New("unauthorized user")
-	ErrUserNotFound = errors.New("user not found")
+	ErrUnauthorized            = errors.New("unauthorized user")
+	ErrUserNotFound            = errors.New("user not found")
+	ErrInvalidRefreshToken     = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused      = errors.New("refresh token reuse detected, session revoked")
+	ErrSessionRevoked          = errors.New("session is no longer active")
+	ErrInvalidUserToken        = errors.New("invalid, expired or already used token")
+	ErrEmailNotVerified        = errors.New("email address has not been verified")
+	ErrAccountLocked           = errors.New("account temporarily locked due to too many failed login attempts")
+	ErrRoleNotFound            = errors.New("role not found")
+	ErrUnknownPermission       = errors.New("unknown permission")
+	ErrNoNutritionistRequest   = errors.New("user has not requested a nutritionist account")
+	ErrPatientNotLinked        = errors.New("patient is not linked to this nutritionist")
+	ErrPatientLinkExists       = errors.New("there is already a pending or active link with this patient")
+	ErrPatientLinkNotFound     = errors.New("patient link not found")
+	ErrInvalidLinkTransition   = errors.New("patient link cannot change to the requested status")
+	ErrSelfInvitation          = errors.New("nutritionists cannot invite themselves")
+	ErrDietNotFound            = errors.New("diet not found")
+	ErrDietNotDeleted          = errors.New("diet is not deleted")
+	ErrInvalidDietTransition   = errors.New("diet cannot change to the requested status")
+	ErrDietArchived            = errors.New("archived diets cannot be changed")
+	ErrDietNotActive           = errors.New("meals can only be logged on the active diet")
+	ErrActiveDietConflict      = errors.New("patient already has an active or scheduled diet in this period")
+	ErrRevisionNotFound        = errors.New("diet revision not found")
+	ErrRevisionExists          = errors.New("diet revision number already taken")
+	ErrPreconditionFailed      = errors.New("diet was modified since it was read")
+	ErrVersionConflict         = errors.New("diet was modified by another request, reload it and try again")
+	ErrCalendarBusy            = errors.New("another change to the patient's diets is in progress, try again shortly")
+	ErrInvalidCursor           = errors.New("invalid pagination cursor")
+	ErrInvalidListParams       = errors.New("invalid list parameters")
+	ErrFoodNotFound            = errors.New("food not found")
+	ErrInvalidFoodMeasures     = errors.New("invalid food measures")
+	ErrInvalidAnthropometrics  = errors.New("invalid anthropometrics")
+	ErrAnthropometricsNotFound = errors.New("patient anthropometrics were not informed")
+	ErrMeasurementNotFound     = errors.New("measurement not found")
+	ErrInvalidMeasurement      = errors.New("invalid measurement")
+	ErrMealLogNotFound         = errors.New("meal log not found")
+	ErrInvalidMealLog          = errors.New("invalid meal log")
+	ErrDietTemplateNotFound    = errors.New("diet template not found")
+	ErrInvalidDietScaling      = errors.New("diet cannot be scaled to the calorie target")
+	ErrInvalidExportTemplate   = errors.New("invalid export template")
+	ErrCalendarFeedNotFound    = errors.New("calendar feed not found")
 )
+
+// AccountLockedError is returned while an account is locked and tells the
+// caller how long to wait before trying again.
+type AccountLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *AccountLockedError) Error() string {
+	return ErrAccountLocked.Error()
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/fhir"
+)
+
+// ExportDietFhirUseCase describes a diet as a FHIR NutritionOrder, ordered by
+// the nutritionist who wrote it
+type ExportDietFhirUseCase interface {
+	Execute(ctx context.Context, userID, dietID string) (*fhir.NutritionOrder, error)
+}
+
+type exportDietFhirUseCase struct {
+	dietRepo  DietRepository
+	userRepo  UserRepository
+	nutrition nutritionCalculator
+}
+
+// NewExportDietFhir creates a new instance of ExportDietFhirUseCase
+func NewExportDietFhir(dietRepo DietRepository, userRepo UserRepository, foodRepo FoodRepository) ExportDietFhirUseCase {
+	return &exportDietFhirUseCase{
+		dietRepo:  dietRepo,
+		userRepo:  userRepo,
+		nutrition: nutritionCalculator{foodRepo: foodRepo},
+	}
+}
+
+func (uc *exportDietFhirUseCase) Execute(ctx context.Context, userID, dietID string) (*fhir.NutritionOrder, error) {
+	diet, err := visibleDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	nutritionist, err := uc.userRepo.FindByID(ctx, diet.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Os nutrientes do pedido são os valores diários calculados da dieta
+	if err := uc.nutrition.annotate(ctx, diet); err != nil {
+		return nil, err
+	}
+
+	return fhir.NutritionOrderFromDiet(diet, nutritionist), nil
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/pdf"
+)
+
+// ExportDietPdfUseCase prints a diet with the export template of the
+// nutritionist who wrote it
+type ExportDietPdfUseCase interface {
+	Execute(ctx context.Context, userID, dietID string) (*entity.Diet, []byte, error)
+}
+
+type exportDietPdfUseCase struct {
+	dietRepo           DietRepository
+	userRepo           UserRepository
+	exportTemplateRepo ExportTemplateRepository
+	nutrition          nutritionCalculator
+}
+
+// NewExportDietPdf creates a new instance of ExportDietPdfUseCase
+func NewExportDietPdf(dietRepo DietRepository, userRepo UserRepository, exportTemplateRepo ExportTemplateRepository, foodRepo FoodRepository) ExportDietPdfUseCase {
+	return &exportDietPdfUseCase{
+		dietRepo:           dietRepo,
+		userRepo:           userRepo,
+		exportTemplateRepo: exportTemplateRepo,
+		nutrition:          nutritionCalculator{foodRepo: foodRepo},
+	}
+}
+
+func (uc *exportDietPdfUseCase) Execute(ctx context.Context, userID, dietID string) (*entity.Diet, []byte, error) {
+	diet, err := visibleDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// O PDF sai sempre com a identidade de quem escreveu a dieta, mesmo
+	// quando é o paciente que o baixa
+	template, err := uc.exportTemplateRepo.FindByOwner(ctx, diet.CreatedBy)
+	if err != nil {
+		return nil, nil, err
+	}
+	if template == nil {
+		template = defaultExportTemplate(diet.CreatedBy)
+	}
+
+	nutritionist, err := uc.userRepo.FindByID(ctx, diet.CreatedBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if template.ShowNutrition {
+		if err := uc.nutrition.annotate(ctx, diet); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	document, err := pdf.RenderDiet(diet, exportOptions(template, nutritionist))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return diet, document, nil
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"log"
+	"strings"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/pdf"
+)
+
+const (
+	defaultAccentColor = "#2E7D32"
+	maxLogoBytes       = 512 * 1024
+	maxHeaderLines     = 5
+	// maxLogoSide limits the pixels of the uploaded logo, since a small file
+	// can declare a huge image
+	maxLogoSide = 1024
+	// The logo is stored at 4 times the 48 points it takes in the PDF
+	storedLogoHeight = 192
+	storedLogoWidth  = 4 * storedLogoHeight
+)
+
+// defaultExportTemplate is used while the nutritionist has not customized the
+// exported diets
+func defaultExportTemplate(ownerID string) *entity.ExportTemplate {
+	return &entity.ExportTemplate{
+		OwnerID:     ownerID,
+		HeaderLines: []string{},
+		AccentColor: defaultAccentColor,
+	}
+}
+
+func validateExportTemplate(template *entity.ExportTemplate) error {
+	if _, err := pdf.ParseColor(template.AccentColor); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidExportTemplate, err)
+	}
+
+	if len(template.HeaderLines) > maxHeaderLines {
+		return fmt.Errorf("%w: no máximo %d linhas de cabeçalho", ErrInvalidExportTemplate, maxHeaderLines)
+	}
+
+	if len(template.Logo) > maxLogoBytes {
+		return fmt.Errorf("%w: o logo deve ter no máximo %d KB", ErrInvalidExportTemplate, maxLogoBytes/1024)
+	}
+
+	if len(template.Logo) > 0 {
+		if _, _, err := decodeLogo(template.Logo); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidExportTemplate, err)
+		}
+	}
+
+	return nil
+}
+
+// decodeLogo reads the size of the image before decoding its pixels
+func decodeLogo(data []byte) (image.Image, string, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("o logo deve ser uma imagem PNG ou JPEG: %v", err)
+	}
+	if config.Width > maxLogoSide || config.Height > maxLogoSide {
+		return nil, "", fmt.Errorf("o logo deve ter no máximo %dx%d pixels", maxLogoSide, maxLogoSide)
+	}
+
+	logo, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("o logo deve ser uma imagem PNG ou JPEG: %v", err)
+	}
+	return logo, format, nil
+}
+
+// shrinkLogo reduces a validated logo to the resolution used in the PDF,
+// keeping its format
+func shrinkLogo(data []byte) ([]byte, error) {
+	logo, format, err := decodeLogo(data)
+	if err != nil {
+		return nil, err
+	}
+
+	scaled := pdf.Downscale(logo, storedLogoWidth, storedLogoHeight)
+	if scaled == logo {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: 90})
+	} else {
+		err = png.Encode(&buf, scaled)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// exportOptions applies the template of the nutritionist to the PDF. Without
+// a professional name the email of the nutritionist identifies the author. A
+// logo saved before the size limit existed is left out.
+func exportOptions(template *entity.ExportTemplate, nutritionist *entity.User) pdf.DietOptions {
+	accent, err := pdf.ParseColor(template.AccentColor)
+	if err != nil {
+		accent, _ = pdf.ParseColor(defaultAccentColor)
+	}
+
+	options := pdf.DietOptions{
+		ClinicName:       strings.TrimSpace(template.ClinicName),
+		HeaderLines:      template.HeaderLines,
+		Accent:           accent,
+		ProfessionalName: strings.TrimSpace(template.ProfessionalName),
+		Footer:           template.Footer,
+		ShowNutrition:    template.ShowNutrition,
+	}
+
+	if len(template.Logo) > 0 {
+		if options.Logo, _, err = decodeLogo(template.Logo); err != nil {
+			log.Printf("[ExportDietPdfUseCase] Ignoring the logo of %s: %v", template.OwnerID, err)
+		}
+	}
+
+	if nutritionist != nil {
+		if options.ProfessionalName == "" {
+			options.ProfessionalName = nutritionist.Email
+		}
+		options.ProfessionalRegistration = nutritionist.ProfessionalRegistration
+	}
+
+	return options
+}
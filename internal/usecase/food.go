@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// FoodFilter defines the search over the food catalog. Every term must be
+// contained in the normalized food name.
+type FoodFilter struct {
+	Terms  []string
+	Source *entity.FoodSource
+	Limit  int64
+}
+
+// validateFoodReferences makes sure every food_id used by the meals, including
+// substitutes, exists in the catalog.
+func validateFoodReferences(ctx context.Context, foodRepo FoodRepository, meals []entity.Meal) error {
+	ids := collectFoodIDs(meals)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	foods, err := foodRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]bool, len(foods))
+	for _, food := range foods {
+		found[food.ID] = true
+	}
+
+	for _, id := range ids {
+		if !found[id] {
+			return fmt.Errorf("%w: %s", ErrFoodNotFound, id)
+		}
+	}
+	return nil
+}
+
+// collectFoodIDs lists the distinct food ids referenced by the meals
+func collectFoodIDs(meals []entity.Meal) []string {
+	seen := map[string]bool{}
+	ids := []string{}
+
+	var visit func(ingredients []entity.Ingredient)
+	visit = func(ingredients []entity.Ingredient) {
+		for _, ingredient := range ingredients {
+			if ingredient.FoodID != "" && !seen[ingredient.FoodID] {
+				seen[ingredient.FoodID] = true
+				ids = append(ids, ingredient.FoodID)
+			}
+			visit(ingredient.Substitutes)
+		}
+	}
+
+	for _, meal := range meals {
+		visit(meal.Ingredients)
+	}
+	return ids
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/ical"
+)
+
+// GetCalendarFeedUseCase renders the iCalendar of the user who owns the token
+// with the meals of their active diet
+type GetCalendarFeedUseCase interface {
+	Execute(ctx context.Context, token string) ([]byte, error)
+}
+
+type getCalendarFeedUseCase struct {
+	calendarFeedRepo CalendarFeedRepository
+	userRepo         UserRepository
+	dietRepo         DietRepository
+}
+
+// NewGetCalendarFeed creates a new instance of GetCalendarFeedUseCase
+func NewGetCalendarFeed(calendarFeedRepo CalendarFeedRepository, userRepo UserRepository, dietRepo DietRepository) GetCalendarFeedUseCase {
+	return &getCalendarFeedUseCase{
+		calendarFeedRepo: calendarFeedRepo,
+		userRepo:         userRepo,
+		dietRepo:         dietRepo,
+	}
+}
+
+// Execute builds the calendar on every request, so an update of the diet
+// reaches the calendar apps on their next refresh. Without an active diet
+// the calendar is empty, which removes the previous events.
+func (uc *getCalendarFeedUseCase) Execute(ctx context.Context, token string) ([]byte, error) {
+	feed, err := uc.calendarFeedRepo.FindByTokenHash(ctx, hashOpaqueToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if feed == nil {
+		return nil, ErrCalendarFeedNotFound
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrCalendarFeedNotFound
+	}
+
+	email := normalizeEmail(user.Email)
+	diets, err := uc.dietRepo.FindDiets(ctx, &DietFilter{
+		UserEmail: &email,
+		Statuses:  []string{string(entity.DietScheduled), string(entity.DietActive)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// O status salvo pode estar atrasado até a próxima execução do ciclo de
+	// vida, por isso a dieta ativa é escolhida pelas datas
+	now := time.Now()
+	for _, diet := range diets {
+		if statusForDates(diet, now) == entity.DietActive {
+			return ical.DietCalendar(diet, dietStart(diet, now.Location()), now).Bytes(), nil
+		}
+	}
+
+	return (&ical.Calendar{Name: "Your Diet", Events: []ical.Event{}}).Bytes(), nil
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// GetDietUseCase retorna uma dieta para o paciente dono dela ou para o
+// nutricionista que a criou
+type GetDietUseCase interface {
+	Execute(ctx context.Context, userID, dietID string) (*entity.Diet, error)
+}
+
+type getDietUseCase struct {
+	dietRepo  DietRepository
+	userRepo  UserRepository
+	nutrition nutritionCalculator
+}
+
+// NewGetDiet cria uma nova instância de GetDietUseCase
+func NewGetDiet(dietRepo DietRepository, userRepo UserRepository, foodRepo FoodRepository) GetDietUseCase {
+	return &getDietUseCase{
+		dietRepo:  dietRepo,
+		userRepo:  userRepo,
+		nutrition: nutritionCalculator{foodRepo: foodRepo, userRepo: userRepo},
+	}
+}
+
+func (uc *getDietUseCase) Execute(ctx context.Context, userID, dietID string) (*entity.Diet, error) {
+	diet, err := visibleDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.nutrition.annotate(ctx, diet); err != nil {
+		return nil, err
+	}
+
+	return diet, nil
+}
+
+// visibleDiet loads a diet the user is allowed to read: the patient it belongs
+// to or the nutritionist who created it. Drafts are visible only to the
+// nutritionist.
+func visibleDiet(ctx context.Context, dietRepo DietRepository, userRepo UserRepository, userID, dietID string) (*entity.Diet, error) {
+	diet, err := dietRepo.GetDietByID(ctx, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	if diet == nil {
+		return nil, ErrDietNotFound
+	}
+
+	// O autor continua enxergando a dieta removida para poder restaurá-la
+	if diet.CreatedBy == userID {
+		return diet, nil
+	}
+
+	user, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if normalizeEmail(diet.UserEmail) != normalizeEmail(user.Email) {
+		return nil, ErrUnauthorized
+	}
+
+	if diet.IsDeleted() || diet.Status == string(entity.DietDraft) {
+		return nil, ErrDietNotFound
+	}
+
+	return diet, nil
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+// GetDietAdherenceUseCase computes how much the patient followed a diet
+type GetDietAdherenceUseCase interface {
+	// from e to no formato AAAA-MM-DD; vazios usam o início da dieta e hoje
+	Execute(ctx context.Context, userID, dietID, from, to string) (*entity.DietAdherence, error)
+}
+
+type getDietAdherenceUseCase struct {
+	dietRepo    DietRepository
+	userRepo    UserRepository
+	mealLogRepo MealLogRepository
+}
+
+// NewGetDietAdherence creates a new instance of GetDietAdherenceUseCase
+func NewGetDietAdherence(dietRepo DietRepository, userRepo UserRepository, mealLogRepo MealLogRepository) GetDietAdherenceUseCase {
+	return &getDietAdherenceUseCase{
+		dietRepo:    dietRepo,
+		userRepo:    userRepo,
+		mealLogRepo: mealLogRepo,
+	}
+}
+
+func (uc *getDietAdherenceUseCase) Execute(ctx context.Context, userID, dietID, from, to string) (*entity.DietAdherence, error) {
+	if err := validateDateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	diet, err := visibleDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	start, end := dietPeriod(diet, now)
+	if from != "" {
+		if date, _ := time.ParseInLocation(entity.MealLogDateLayout, from, now.Location()); date.After(start) {
+			start = date
+		}
+	}
+	if to != "" {
+		if date, _ := time.ParseInLocation(entity.MealLogDateLayout, to, now.Location()); date.Before(end) {
+			end = date
+		}
+	}
+
+	logs, err := uc.mealLogRepo.FindByDiet(ctx, diet.ID, start.Format(entity.MealLogDateLayout), end.Format(entity.MealLogDateLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	return computeAdherence(diet, logs, start, end, now), nil
+}
+
+// validateDateRange checks optional AAAA-MM-DD dates
+func validateDateRange(from, to string) error {
+	for _, value := range []string{from, to} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse(entity.MealLogDateLayout, value); err != nil {
+			return fmt.Errorf("%w: data inválida %q, use AAAA-MM-DD", ErrInvalidListParams, value)
+		}
+	}
+	if from != "" && to != "" && to < from {
+		return fmt.Errorf("%w: from deve ser anterior a to", ErrInvalidListParams)
+	}
+	return nil
+}
+
+// dietPeriod returns the first and the last day of the diet up to today
+func dietPeriod(diet *entity.Diet, now time.Time) (time.Time, time.Time) {
+	start := dietStart(diet, now.Location())
+	end := start.AddDate(0, 0, int(diet.DurationInDays)-1)
+	if today := truncateToDay(now); today.Before(end) {
+		end = today
+	}
+	return start, end
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// adherenceScore is how much a logged meal counts towards the adherence
+func adherenceScore(status entity.MealLogStatus) float64 {
+	switch status {
+	case entity.MealFollowed, entity.MealSubstituted:
+		return 1
+	case entity.MealPartial:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// computeAdherence crosses the meals of the diet with the logs, day by day.
+// Each day expects the meals of its day template. Logs of meals that are no
+// longer in the diet are ignored.
+func computeAdherence(diet *entity.Diet, logs []*entity.MealLog, start, end, now time.Time) *entity.DietAdherence {
+	adherence := &entity.DietAdherence{
+		DietID:   diet.ID,
+		From:     start.Format(entity.MealLogDateLayout),
+		To:       end.Format(entity.MealLogDateLayout),
+		ByStatus: map[entity.MealLogStatus]int{},
+		Meals:    []entity.MealAdherence{},
+		Weeks:    []entity.WeekAdherence{},
+	}
+
+	byKey := make(map[string]*entity.MealLog, len(logs))
+	for _, log := range logs {
+		byKey[log.Date+"|"+utils.NormalizeText(log.MealName)] = log
+	}
+
+	type slot struct {
+		key       string
+		adherence entity.MealAdherence
+		score     float64
+	}
+	var slots []*slot
+	byName := map[string]*slot{}
+	for _, meal := range dayplan.AllMeals(diet) {
+		key := utils.NormalizeText(meal.Name)
+		if byName[key] != nil {
+			continue
+		}
+		byName[key] = &slot{key: key, adherence: entity.MealAdherence{MealName: meal.Name, ByStatus: map[entity.MealLogStatus]int{}}}
+		slots = append(slots, byName[key])
+	}
+	dietFirstDay := dietStart(diet, now.Location())
+
+	var totalScore float64
+	var week *entity.WeekAdherence
+	var weekScore float64
+	closeWeek := func() {
+		if week != nil {
+			week.AdherencePct = percentage(weekScore, week.Expected)
+			adherence.Weeks = append(adherence.Weeks, *week)
+		}
+	}
+
+	today := truncateToDay(now).Format(entity.MealLogDateLayout)
+	streak := 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format(entity.MealLogDateLayout)
+		adherence.Days++
+
+		weekStart := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)).Format(entity.MealLogDateLayout)
+		if week == nil || week.WeekStart != weekStart {
+			closeWeek()
+			week = &entity.WeekAdherence{WeekStart: weekStart}
+			weekScore = 0
+		}
+
+		var expected []*slot
+		seen := map[string]bool{}
+		for _, meal := range dayplan.MealsOn(diet, dietFirstDay, day) {
+			if key := utils.NormalizeText(meal.Name); !seen[key] {
+				seen[key] = true
+				expected = append(expected, byName[key])
+			}
+		}
+
+		fullDay := len(expected) > 0
+		for _, s := range expected {
+			s.adherence.Expected++
+			week.Expected++
+			adherence.ExpectedMeals++
+
+			log, ok := byKey[date+"|"+s.key]
+			if !ok {
+				fullDay = false
+				continue
+			}
+
+			score := adherenceScore(log.Status)
+			s.adherence.Logged++
+			s.adherence.ByStatus[log.Status]++
+			s.score += score
+			week.Logged++
+			weekScore += score
+			adherence.LoggedMeals++
+			adherence.ByStatus[log.Status]++
+			totalScore += score
+			if score < 1 {
+				fullDay = false
+			}
+		}
+
+		switch {
+		case fullDay:
+			streak++
+			adherence.CurrentStreakDays = streak
+		case date == today:
+			// O dia de hoje ainda pode ser completado e não interrompe a sequência
+		default:
+			streak = 0
+			adherence.CurrentStreakDays = 0
+		}
+		if streak > adherence.LongestStreakDays {
+			adherence.LongestStreakDays = streak
+		}
+	}
+	closeWeek()
+
+	for _, s := range slots {
+		s.adherence.AdherencePct = percentage(s.score, s.adherence.Expected)
+		adherence.Meals = append(adherence.Meals, s.adherence)
+	}
+
+	adherence.AdherencePct = percentage(totalScore, adherence.ExpectedMeals)
+	adherence.LoggedAdherencePct = percentage(totalScore, adherence.LoggedMeals)
+	return adherence
+}
+
+func percentage(score float64, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round2(score / float64(total) * 100)
+}
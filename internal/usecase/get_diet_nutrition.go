@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// GetDietNutritionUseCase returns the nutritional breakdown of a diet
+type GetDietNutritionUseCase interface {
+	Execute(ctx context.Context, userID, dietID string) (*entity.DietNutrition, error)
+}
+
+type getDietNutritionUseCase struct {
+	dietRepo  DietRepository
+	userRepo  UserRepository
+	nutrition nutritionCalculator
+}
+
+// NewGetDietNutrition cria uma nova instância de GetDietNutritionUseCase
+func NewGetDietNutrition(dietRepo DietRepository, userRepo UserRepository, foodRepo FoodRepository) GetDietNutritionUseCase {
+	return &getDietNutritionUseCase{
+		dietRepo:  dietRepo,
+		userRepo:  userRepo,
+		nutrition: nutritionCalculator{foodRepo: foodRepo, userRepo: userRepo},
+	}
+}
+
+func (uc *getDietNutritionUseCase) Execute(ctx context.Context, userID, dietID string) (*entity.DietNutrition, error) {
+	diet, err := visibleDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.nutrition.annotate(ctx, diet); err != nil {
+		return nil, err
+	}
+
+	return diet.Nutrition, nil
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// GetDietRevisionUseCase returns a single revision of a diet
+type GetDietRevisionUseCase interface {
+	Execute(ctx context.Context, userID, dietID string, number int) (*entity.DietRevision, error)
+}
+
+type getDietRevisionUseCase struct {
+	dietRepo     DietRepository
+	userRepo     UserRepository
+	revisionRepo DietRevisionRepository
+}
+
+// NewGetDietRevision cria uma nova instância de GetDietRevisionUseCase
+func NewGetDietRevision(dietRepo DietRepository, userRepo UserRepository, revisionRepo DietRevisionRepository) GetDietRevisionUseCase {
+	return &getDietRevisionUseCase{
+		dietRepo:     dietRepo,
+		userRepo:     userRepo,
+		revisionRepo: revisionRepo,
+	}
+}
+
+func (uc *getDietRevisionUseCase) Execute(ctx context.Context, userID, dietID string, number int) (*entity.DietRevision, error) {
+	diet, err := visibleDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	return findRevision(ctx, uc.revisionRepo, diet, number)
+}
+
+// findRevision finds the revision by number; diets without stored revisions
+// have only the baseline
+func findRevision(ctx context.Context, revisionRepo DietRevisionRepository, diet *entity.Diet, number int) (*entity.DietRevision, error) {
+	revision, err := revisionRepo.FindByNumber(ctx, diet.ID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if revision != nil {
+		return revision, nil
+	}
+
+	if number == 1 {
+		latest, err := revisionRepo.FindLatest(ctx, diet.ID)
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil {
+			return baselineRevision(diet), nil
+		}
+	}
+
+	return nil, ErrRevisionNotFound
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/mealtime"
+)
+
+// maxScheduleDays limits the days expanded by a single request
+const maxScheduleDays = 92
+
+// GetDietScheduleUseCase expands the plan of a diet into dated meals
+type GetDietScheduleUseCase interface {
+	// from e to no formato AAAA-MM-DD; vazios usam hoje e os 7 dias seguintes
+	Execute(ctx context.Context, userID, dietID, from, to string) (*entity.DietSchedule, error)
+}
+
+type getDietScheduleUseCase struct {
+	dietRepo DietRepository
+	userRepo UserRepository
+}
+
+// NewGetDietSchedule creates a new instance of GetDietScheduleUseCase
+func NewGetDietSchedule(dietRepo DietRepository, userRepo UserRepository) GetDietScheduleUseCase {
+	return &getDietScheduleUseCase{
+		dietRepo: dietRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (uc *getDietScheduleUseCase) Execute(ctx context.Context, userID, dietID, from, to string) (*entity.DietSchedule, error) {
+	if err := validateDateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	diet, err := visibleDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	start := truncateToDay(now)
+	if from != "" {
+		start, _ = time.ParseInLocation(entity.MealLogDateLayout, from, now.Location())
+	}
+	end := start.AddDate(0, 0, 6)
+	if to != "" {
+		end, _ = time.ParseInLocation(entity.MealLogDateLayout, to, now.Location())
+	}
+	if dayplan.DaysBetween(start, end) >= maxScheduleDays {
+		return nil, fmt.Errorf("%w: o intervalo deve ter no máximo %d dias", ErrInvalidListParams, maxScheduleDays)
+	}
+
+	return expandSchedule(diet, start, end), nil
+}
+
+// dietStart returns the first day of the diet. Drafts and diets created before
+// starts_at existed start on the day they were created.
+func dietStart(diet *entity.Diet, loc *time.Location) time.Time {
+	if diet.StartsAt != nil {
+		return truncateToDay(diet.StartsAt.In(loc))
+	}
+	return truncateToDay(diet.CreatedAt.In(loc))
+}
+
+// expandSchedule lists the meals of each day between start and end that falls
+// within the duration of the diet
+func expandSchedule(diet *entity.Diet, start, end time.Time) *entity.DietSchedule {
+	schedule := &entity.DietSchedule{
+		DietID: diet.ID,
+		From:   start.Format(entity.MealLogDateLayout),
+		To:     end.Format(entity.MealLogDateLayout),
+		Days:   []entity.ScheduledDay{},
+	}
+
+	first := dietStart(diet, start.Location())
+	last := first.AddDate(0, 0, int(diet.DurationInDays)-1)
+	if start.Before(first) {
+		start = first
+	}
+	if end.After(last) {
+		end = last
+	}
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		scheduled := entity.ScheduledDay{
+			Date:      day.Format(entity.MealLogDateLayout),
+			Weekday:   dayplan.WeekdayOf(day),
+			DayNumber: dayplan.DaysBetween(first, day) + 1,
+			Meals:     []entity.ScheduledMeal{},
+		}
+
+		meals := diet.Meals
+		if template := dayplan.TemplateFor(diet, first, day); template != nil {
+			scheduled.Template = template.Name
+			meals = template.Meals
+		}
+
+		for _, meal := range meals {
+			scheduledMeal := entity.ScheduledMeal{Meal: meal}
+			if minutes, err := mealtime.ParseClock(meal.TimeOfDay); err == nil {
+				at := time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, day.Location())
+				scheduledMeal.At = &at
+			}
+			scheduled.Meals = append(scheduled.Meals, scheduledMeal)
+		}
+		schedule.Days = append(schedule.Days, scheduled)
+	}
+
+	return schedule
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// GetDietTemplateUseCase returns a template of the nutritionist
+type GetDietTemplateUseCase interface {
+	Execute(ctx context.Context, userID, templateID string) (*entity.DietTemplate, error)
+}
+
+type getDietTemplateUseCase struct {
+	templateRepo DietTemplateRepository
+	nutrition    nutritionCalculator
+}
+
+// NewGetDietTemplate creates a new instance of GetDietTemplateUseCase
+func NewGetDietTemplate(templateRepo DietTemplateRepository, foodRepo FoodRepository) GetDietTemplateUseCase {
+	return &getDietTemplateUseCase{
+		templateRepo: templateRepo,
+		nutrition:    nutritionCalculator{foodRepo: foodRepo},
+	}
+}
+
+func (uc *getDietTemplateUseCase) Execute(ctx context.Context, userID, templateID string) (*entity.DietTemplate, error) {
+	template, err := findOwnedTemplate(ctx, uc.templateRepo, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := annotateTemplates(ctx, uc.nutrition, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
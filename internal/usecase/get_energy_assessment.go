@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// GetEnergyAssessmentUseCase returns the energy expenditure and targets of a
+// patient, either of the logged user or of a patient linked to the nutritionist
+type GetEnergyAssessmentUseCase interface {
+	// linkID vazio calcula para o próprio usuário; equation vazia usa a
+	// equação escolhida pelo nutricionista
+	Execute(ctx context.Context, userID, linkID string, equation entity.EnergyEquation) (*entity.EnergyAssessment, error)
+}
+
+type getEnergyAssessmentUseCase struct {
+	linkRepo PatientLinkRepository
+	userRepo UserRepository
+}
+
+// NewGetEnergyAssessment creates a new instance of GetEnergyAssessmentUseCase
+func NewGetEnergyAssessment(linkRepo PatientLinkRepository, userRepo UserRepository) GetEnergyAssessmentUseCase {
+	return &getEnergyAssessmentUseCase{
+		linkRepo: linkRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (uc *getEnergyAssessmentUseCase) Execute(ctx context.Context, userID, linkID string, equation entity.EnergyEquation) (*entity.EnergyAssessment, error) {
+	var patient *entity.User
+	var err error
+
+	if linkID == "" {
+		patient, err = uc.userRepo.FindByID(ctx, userID)
+		if err == nil && patient == nil {
+			err = ErrUserNotFound
+		}
+	} else {
+		patient, err = linkedPatient(ctx, uc.linkRepo, uc.userRepo, userID, linkID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return assessEnergy(patient, equation)
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// GetExportTemplateUseCase returns the export template of the nutritionist,
+// or the default one when it was never customized
+type GetExportTemplateUseCase interface {
+	Execute(ctx context.Context, userID string) (*entity.ExportTemplate, error)
+}
+
+type getExportTemplateUseCase struct {
+	exportTemplateRepo ExportTemplateRepository
+}
+
+// NewGetExportTemplate creates a new instance of GetExportTemplateUseCase
+func NewGetExportTemplate(exportTemplateRepo ExportTemplateRepository) GetExportTemplateUseCase {
+	return &getExportTemplateUseCase{
+		exportTemplateRepo: exportTemplateRepo,
+	}
+}
+
+func (uc *getExportTemplateUseCase) Execute(ctx context.Context, userID string) (*entity.ExportTemplate, error) {
+	template, err := uc.exportTemplateRepo.FindByOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if template == nil {
+		return defaultExportTemplate(userID), nil
+	}
+
+	return template, nil
+}
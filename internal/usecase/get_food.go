@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// GetFoodUseCase returns a single food of the catalog
+type GetFoodUseCase interface {
+	Execute(ctx context.Context, id string) (*entity.Food, error)
+}
+
+type getFoodUseCase struct {
+	foodRepo FoodRepository
+}
+
+// NewGetFood creates a new instance of GetFoodUseCase
+func NewGetFood(foodRepo FoodRepository) GetFoodUseCase {
+	return &getFoodUseCase{
+		foodRepo: foodRepo,
+	}
+}
+
+func (uc *getFoodUseCase) Execute(ctx context.Context, id string) (*entity.Food, error) {
+	food, err := uc.foodRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if food == nil {
+		return nil, ErrFoodNotFound
+	}
+
+	return food, nil
+}
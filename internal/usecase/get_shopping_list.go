@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/shopping"
+)
+
+// defaultShoppingDays is the range of the shopping list when days is not informed
+const defaultShoppingDays = 7
+
+// GetShoppingListUseCase sums the ingredients of a diet over a range of days
+type GetShoppingListUseCase interface {
+	// from no formato AAAA-MM-DD, vazio usa hoje; days 0 usa 7 dias
+	Execute(ctx context.Context, userID, dietID, from string, days int, withPackages bool) (*entity.ShoppingList, error)
+}
+
+type getShoppingListUseCase struct {
+	dietRepo DietRepository
+	userRepo UserRepository
+	foodRepo FoodRepository
+}
+
+// NewGetShoppingList creates a new instance of GetShoppingListUseCase
+func NewGetShoppingList(dietRepo DietRepository, userRepo UserRepository, foodRepo FoodRepository) GetShoppingListUseCase {
+	return &getShoppingListUseCase{
+		dietRepo: dietRepo,
+		userRepo: userRepo,
+		foodRepo: foodRepo,
+	}
+}
+
+func (uc *getShoppingListUseCase) Execute(ctx context.Context, userID, dietID, from string, days int, withPackages bool) (*entity.ShoppingList, error) {
+	if err := validateDateRange(from, ""); err != nil {
+		return nil, err
+	}
+
+	if days == 0 {
+		days = defaultShoppingDays
+	}
+	if days < 1 || days > maxScheduleDays {
+		return nil, fmt.Errorf("%w: days deve estar entre 1 e %d", ErrInvalidListParams, maxScheduleDays)
+	}
+
+	diet, err := visibleDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	start := truncateToDay(now)
+	if from != "" {
+		start, _ = time.ParseInLocation(entity.MealLogDateLayout, from, now.Location())
+	}
+	end := start.AddDate(0, 0, days-1)
+
+	schedule := expandSchedule(diet, start, end)
+
+	var meals []entity.Meal
+	for _, day := range schedule.Days {
+		for _, meal := range day.Meals {
+			meals = append(meals, meal.Meal)
+		}
+	}
+
+	foods := map[string]*entity.Food{}
+	if ids := collectFoodIDs(meals); len(ids) > 0 {
+		found, err := uc.foodRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, food := range found {
+			foods[food.ID] = food
+		}
+	}
+
+	return &entity.ShoppingList{
+		DietID:     diet.ID,
+		DietName:   diet.DietName,
+		From:       schedule.From,
+		To:         schedule.To,
+		Days:       len(schedule.Days),
+		Categories: shopping.Build(schedule.Days, foods, withPackages),
+	}, nil
+}
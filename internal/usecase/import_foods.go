@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ImportFoodsUseCase loads parsed foods into the catalog. Foods are keyed by
+// source and source id, so running an import again updates them in place.
+type ImportFoodsUseCase interface {
+	Execute(ctx context.Context, foods []*entity.Food) (int, error)
+}
+
+type importFoodsUseCase struct {
+	foodRepo FoodRepository
+}
+
+// NewImportFoods creates a new instance of ImportFoodsUseCase
+func NewImportFoods(foodRepo FoodRepository) ImportFoodsUseCase {
+	return &importFoodsUseCase{
+		foodRepo: foodRepo,
+	}
+}
+
+func (uc *importFoodsUseCase) Execute(ctx context.Context, foods []*entity.Food) (int, error) {
+	valid := make([]*entity.Food, 0, len(foods))
+	for _, food := range foods {
+		// Linhas sem nome não servem para busca
+		if food.Name == "" || food.NormalizedName == "" {
+			continue
+		}
+		valid = append(valid, food)
+	}
+
+	if len(valid) == 0 {
+		return 0, nil
+	}
+
+	return uc.foodRepo.UpsertMany(ctx, valid)
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// InstantiateDietTemplateUseCase creates a diet for a linked patient from a
+// template of the nutritionist, optionally scaling the calories
+type InstantiateDietTemplateUseCase interface {
+	Execute(ctx context.Context, userID, templateID string, req *dto.DietCopyRequest) (*entity.Diet, error)
+}
+
+type instantiateDietTemplateUseCase struct {
+	templateRepo DietTemplateRepository
+	copier       dietCopier
+}
+
+// NewInstantiateDietTemplate creates a new instance of InstantiateDietTemplateUseCase
+func NewInstantiateDietTemplate(templateRepo DietTemplateRepository, dietRepo DietRepository, linkRepo PatientLinkRepository, revisionRepo DietRevisionRepository, foodRepo FoodRepository, userRepo UserRepository) InstantiateDietTemplateUseCase {
+	return &instantiateDietTemplateUseCase{
+		templateRepo: templateRepo,
+		copier:       newDietCopier(dietRepo, linkRepo, revisionRepo, foodRepo, userRepo),
+	}
+}
+
+func (uc *instantiateDietTemplateUseCase) Execute(ctx context.Context, userID, templateID string, req *dto.DietCopyRequest) (*entity.Diet, error) {
+	template, err := findOwnedTemplate(ctx, uc.templateRepo, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.copier.create(ctx, userID, templateDiet(template), req)
+}
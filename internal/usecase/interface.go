@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/victorgiudicissi/your-diet/internal/entity"
 )
 
@@ -11,12 +13,139 @@ type (
 		CreateDiet(ctx context.Context, diet *entity.Diet) error
 		GetDietByID(ctx context.Context, id string) (*entity.Diet, error)
 		FindDiets(ctx context.Context, filter *DietFilter) ([]*entity.Diet, error)
-		UpdateDiet(ctx context.Context, diet *entity.Diet) error
+		UpdateDiet(ctx context.Context, diet *entity.Diet, expectedVersion int64) error
+		SoftDeleteDiet(ctx context.Context, id, deletedBy string, deletedAt time.Time) error
+		RestoreDiet(ctx context.Context, id string) error
+		// LockCalendar serializes the changes to the calendar of the patient;
+		// the returned function releases the lock. It returns ErrCalendarBusy
+		// when another change keeps the lock for too long.
+		LockCalendar(ctx context.Context, userEmail string) (func(), error)
+		NormalizeUserEmails(ctx context.Context) (int, error)
+	}
+
+	DietRevisionRepository interface {
+		Create(ctx context.Context, revision *entity.DietRevision) error
+		FindByDiet(ctx context.Context, dietID string) ([]*entity.DietRevision, error)
+		FindByNumber(ctx context.Context, dietID string, number int) (*entity.DietRevision, error)
+		FindLatest(ctx context.Context, dietID string) (*entity.DietRevision, error)
+	}
+
+	FoodRepository interface {
+		UpsertMany(ctx context.Context, foods []*entity.Food) (int, error)
+		FindByID(ctx context.Context, id string) (*entity.Food, error)
+		FindByIDs(ctx context.Context, ids []string) ([]*entity.Food, error)
+		UpdateMeasures(ctx context.Context, id string, densityGPerMl float64, gramWeights map[string]float64) error
+		Search(ctx context.Context, filter *FoodFilter) ([]*entity.Food, error)
 	}
 
 	UserRepository interface {
 		Create(ctx context.Context, user *entity.User) (string, error)
 		FindByEmail(ctx context.Context, email string) (*entity.User, error)
 		FindByID(ctx context.Context, id string) (*entity.User, error)
+		UpdatePassword(ctx context.Context, id string, passwordHash string) error
+		MarkEmailVerified(ctx context.Context, id string, verifiedAt time.Time) error
+		// RecordFailedLogin counts the failure atomically, restarting the count
+		// when the previous failure happened before windowStart, and returns
+		// the consecutive failures
+		RecordFailedLogin(ctx context.Context, id string, failedAt, windowStart time.Time) (int, error)
+		LockLogin(ctx context.Context, id string, lockedUntil time.Time) error
+		ResetFailedLogins(ctx context.Context, id string) error
+		FindByNutritionistStatus(ctx context.Context, status entity.NutritionistStatus) ([]*entity.User, error)
+		UpdateType(ctx context.Context, id string, userType string) error
+		UpdateAnthropometrics(ctx context.Context, id string, anthropometrics *entity.Anthropometrics) error
+		UpdateNutritionistReview(ctx context.Context, id string, status entity.NutritionistStatus, userType, reviewerID, note string, reviewedAt time.Time) error
+		NormalizeEmails(ctx context.Context) (int, error)
+	}
+
+	RoleRepository interface {
+		FindByName(ctx context.Context, name string) (*entity.Role, error)
+		List(ctx context.Context) ([]*entity.Role, error)
+		Create(ctx context.Context, role *entity.Role) error
+		// Upsert replaces the description and permissions of the role, creating it if needed
+		Upsert(ctx context.Context, role *entity.Role) error
+		// AddSeededPermissions grants built-in permissions that were never seeded before
+		AddSeededPermissions(ctx context.Context, name string, permissions []string) error
+	}
+
+	SessionRepository interface {
+		Create(ctx context.Context, session *entity.Session) error
+		FindByID(ctx context.Context, id string) (*entity.Session, error)
+		// FindByTokenHash returns the session whose current or previously used
+		// refresh token matches the given hash.
+		FindByTokenHash(ctx context.Context, hash string) (*entity.Session, error)
+		// Rotate replaces the current refresh token hash only if it still matches
+		// currentHash, reporting whether the swap happened.
+		Rotate(ctx context.Context, id, currentHash, newHash string, refreshedAt, expiresAt time.Time) (bool, error)
+		Revoke(ctx context.Context, id, reason string, revokedAt time.Time) error
+		RevokeAllForUser(ctx context.Context, userID, reason string, revokedAt time.Time) error
+	}
+
+	UserTokenRepository interface {
+		Create(ctx context.Context, token *entity.UserToken) error
+		// Consume marks an unused, unexpired token as used and returns it, or
+		// returns nil when no such token exists.
+		Consume(ctx context.Context, tokenHash string, purpose entity.UserTokenPurpose, now time.Time) (*entity.UserToken, error)
+		// InvalidateAll marks every pending token of the user for the purpose as used
+		InvalidateAll(ctx context.Context, userID string, purpose entity.UserTokenPurpose, now time.Time) error
+	}
+
+	PatientLinkRepository interface {
+		// Create returns ErrPatientLinkExists when the pair already has a
+		// pending or active link
+		Create(ctx context.Context, link *entity.PatientLink) error
+		FindByID(ctx context.Context, id string) (*entity.PatientLink, error)
+		// FindOpen returns the pending or active link between the nutritionist and the patient email
+		FindOpen(ctx context.Context, nutritionistID, patientEmail string) (*entity.PatientLink, error)
+		FindByNutritionist(ctx context.Context, nutritionistID string, status *entity.PatientLinkStatus) ([]*entity.PatientLink, error)
+		FindByPatientEmail(ctx context.Context, patientEmail string, status *entity.PatientLinkStatus) ([]*entity.PatientLink, error)
+		// UpdateStatus moves a link from one status to another, returning false
+		// when the link was not in the expected status anymore.
+		UpdateStatus(ctx context.Context, link *entity.PatientLink, from entity.PatientLinkStatus) (bool, error)
+	}
+
+	MeasurementRepository interface {
+		Create(ctx context.Context, measurement *entity.Measurement) error
+		FindByID(ctx context.Context, id string) (*entity.Measurement, error)
+		// FindByPatient returns the measurements in chronological order
+		FindByPatient(ctx context.Context, patientID string, from, to *time.Time) ([]*entity.Measurement, error)
+		Update(ctx context.Context, measurement *entity.Measurement) error
+		Delete(ctx context.Context, id string) error
+	}
+
+	MealLogRepository interface {
+		// Upsert saves the log of the meal on the day, replacing the previous one
+		Upsert(ctx context.Context, log *entity.MealLog) (*entity.MealLog, error)
+		FindByID(ctx context.Context, id string) (*entity.MealLog, error)
+		// FindByDiet returns the logs between the dates (AAAA-MM-DD, inclusive; empty means open)
+		FindByDiet(ctx context.Context, dietID, from, to string) ([]*entity.MealLog, error)
+		UpdateReview(ctx context.Context, id, reviewedBy, note string, reviewedAt time.Time) error
+		Delete(ctx context.Context, id string) error
+	}
+
+	DietTemplateRepository interface {
+		Create(ctx context.Context, template *entity.DietTemplate) error
+		FindByID(ctx context.Context, id string) (*entity.DietTemplate, error)
+		// FindByOwner returns the templates of the nutritionist sorted by name
+		FindByOwner(ctx context.Context, ownerID string) ([]*entity.DietTemplate, error)
+		Update(ctx context.Context, template *entity.DietTemplate) error
+		Delete(ctx context.Context, id string) error
+	}
+
+	ExportTemplateRepository interface {
+		// FindByOwner returns nil when the nutritionist never customized the template
+		FindByOwner(ctx context.Context, ownerID string) (*entity.ExportTemplate, error)
+		Save(ctx context.Context, template *entity.ExportTemplate) error
+	}
+
+	CalendarFeedRepository interface {
+		// Save replaces the feed of the user, invalidating the previous token
+		Save(ctx context.Context, feed *entity.CalendarFeed) error
+		FindByTokenHash(ctx context.Context, tokenHash string) (*entity.CalendarFeed, error)
+		Delete(ctx context.Context, userID string) error
+	}
+
+	// TokenSigner signs access tokens with the currently active key
+	TokenSigner interface {
+		Sign(claims jwt.Claims) (string, error)
 	}
 )
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// InvitePatientUseCase lets a nutritionist invite a patient by email
+type InvitePatientUseCase interface {
+	Execute(ctx context.Context, nutritionistID, patientEmail string) (*entity.PatientLink, error)
+}
+
+type invitePatientUseCase struct {
+	linkRepo PatientLinkRepository
+	userRepo UserRepository
+	mailer   Mailer
+	baseURL  string
+}
+
+// NewInvitePatient creates a new instance of InvitePatientUseCase
+func NewInvitePatient(linkRepo PatientLinkRepository, userRepo UserRepository, mailer Mailer, baseURL string) InvitePatientUseCase {
+	return &invitePatientUseCase{
+		linkRepo: linkRepo,
+		userRepo: userRepo,
+		mailer:   mailer,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Execute creates a pending link and emails the patient. The patient does not
+// need an account yet; the invitation shows up once they register.
+func (uc *invitePatientUseCase) Execute(ctx context.Context, nutritionistID, patientEmail string) (*entity.PatientLink, error) {
+	patientEmail = normalizeEmail(patientEmail)
+
+	nutritionist, err := uc.userRepo.FindByID(ctx, nutritionistID)
+	if err != nil {
+		return nil, err
+	}
+
+	if nutritionist == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if normalizeEmail(nutritionist.Email) == patientEmail {
+		return nil, ErrSelfInvitation
+	}
+
+	existing, err := uc.linkRepo.FindOpen(ctx, nutritionistID, patientEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, ErrPatientLinkExists
+	}
+
+	link := &entity.PatientLink{
+		ID:                uuid.NewString(),
+		NutritionistID:    nutritionistID,
+		NutritionistEmail: nutritionist.Email,
+		PatientEmail:      patientEmail,
+		Status:            entity.PatientLinkPending,
+		InvitedAt:         time.Now(),
+	}
+
+	if err := uc.linkRepo.Create(ctx, link); err != nil {
+		return nil, err
+	}
+
+	err = uc.mailer.Send(ctx, &entity.EmailMessage{
+		To:      patientEmail,
+		Subject: "Você recebeu um convite no Your Diet",
+		Body: fmt.Sprintf(
+			"Olá!\n\n%s convidou você para acompanhar sua alimentação no Your Diet.\n\nPara aceitar ou recusar o convite, acesse:\n\n%s/invitations\n\nSe ainda não tem uma conta, cadastre-se com este email para ver o convite.\n",
+			nutritionist.Email, uc.baseURL,
+		),
+	})
+	if err != nil {
+		// The invitation is still visible in the app even if the email fails.
+		log.Printf("[InvitePatientUseCase] Failed to send invitation email: %v", err)
+	}
+
+	return link, nil
+}
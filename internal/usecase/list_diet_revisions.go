@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ListDietRevisionsUseCase lists the history of a diet, newest first
+type ListDietRevisionsUseCase interface {
+	Execute(ctx context.Context, userID, dietID string) ([]*entity.DietRevision, error)
+}
+
+type listDietRevisionsUseCase struct {
+	dietRepo     DietRepository
+	userRepo     UserRepository
+	revisionRepo DietRevisionRepository
+}
+
+// NewListDietRevisions cria uma nova instância de ListDietRevisionsUseCase
+func NewListDietRevisions(dietRepo DietRepository, userRepo UserRepository, revisionRepo DietRevisionRepository) ListDietRevisionsUseCase {
+	return &listDietRevisionsUseCase{
+		dietRepo:     dietRepo,
+		userRepo:     userRepo,
+		revisionRepo: revisionRepo,
+	}
+}
+
+func (uc *listDietRevisionsUseCase) Execute(ctx context.Context, userID, dietID string) ([]*entity.DietRevision, error) {
+	diet, err := visibleDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := uc.revisionRepo.FindByDiet(ctx, diet.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revisions) == 0 {
+		return []*entity.DietRevision{baselineRevision(diet)}, nil
+	}
+
+	return revisions, nil
+}
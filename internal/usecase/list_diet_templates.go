@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ListDietTemplatesUseCase lists the templates of the nutritionist with their nutrition
+type ListDietTemplatesUseCase interface {
+	Execute(ctx context.Context, userID string) ([]*entity.DietTemplate, error)
+}
+
+type listDietTemplatesUseCase struct {
+	templateRepo DietTemplateRepository
+	nutrition    nutritionCalculator
+}
+
+// NewListDietTemplates creates a new instance of ListDietTemplatesUseCase
+func NewListDietTemplates(templateRepo DietTemplateRepository, foodRepo FoodRepository) ListDietTemplatesUseCase {
+	return &listDietTemplatesUseCase{
+		templateRepo: templateRepo,
+		nutrition:    nutritionCalculator{foodRepo: foodRepo},
+	}
+}
+
+func (uc *listDietTemplatesUseCase) Execute(ctx context.Context, userID string) ([]*entity.DietTemplate, error) {
+	templates, err := uc.templateRepo.FindByOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := annotateTemplates(ctx, uc.nutrition, templates...); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
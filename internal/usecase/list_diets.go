@@ -2,28 +2,54 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/victorgiudicissi/your-diet/internal/dto"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
 )
 
 type DietFilter struct {
-	UserEmail *string
-	CreatedBy *string
+	UserEmail  *string
+	UserEmails []string
+	CreatedBy  *string
+	// IncludeDeleted também retorna as dietas removidas (soft delete)
+	IncludeDeleted bool
+
+	Status          *string
+	Statuses        []string
+	ExcludeStatuses []string
+	NameContains    *string
+	CreatedFrom     *time.Time
+	CreatedTo       *time.Time
+	UpdatedFrom     *time.Time
+	UpdatedTo       *time.Time
+
+	// SortBy vazio mantém a ordem natural; Limit 0 retorna todas as dietas
+	SortBy   string
+	SortDesc bool
+	Limit    int64
+	After    *DietCursor
 }
 
 type listDietsUseCase struct {
-	dietRepo DietRepository
-	userRepo UserRepository
+	dietRepo  DietRepository
+	userRepo  UserRepository
+	linkRepo  PatientLinkRepository
+	nutrition nutritionCalculator
 }
 
 type ListDiets interface {
 	Execute(ctx context.Context, input *dto.ListDietsInput) (*dto.ListDietsUseCaseOutput, error)
 }
 
-func NewListDiets(dietRepo DietRepository, userRepo UserRepository) ListDiets {
+func NewListDiets(dietRepo DietRepository, userRepo UserRepository, linkRepo PatientLinkRepository, foodRepo FoodRepository) ListDiets {
 	return &listDietsUseCase{
-		dietRepo: dietRepo,
-		userRepo: userRepo,
+		dietRepo:  dietRepo,
+		userRepo:  userRepo,
+		linkRepo:  linkRepo,
+		nutrition: nutritionCalculator{foodRepo: foodRepo, userRepo: userRepo},
 	}
 }
 
@@ -37,13 +63,35 @@ func (uc *listDietsUseCase) Execute(ctx context.Context, input *dto.ListDietsInp
 		return nil, ErrUserNotFound
 	}
 
-	filter := &DietFilter{}
+	filter, limit, err := newPagedDietFilter(input)
+	if err != nil {
+		return nil, err
+	}
 
-	filter.UserEmail = &user.Email
+	email := normalizeEmail(user.Email)
+	filter.UserEmail = &email
+	// Rascunhos ainda não são o plano do paciente
+	filter.ExcludeStatuses = []string{string(entity.DietDraft)}
 	if input.CreatedBySearch && user.Type == "NUTRITIONIST" {
+		// Nutricionistas só enxergam as dietas dos pacientes com vínculo ativo
 		filter.CreatedBy = &input.UserID
+		filter.UserEmail = nil
+		filter.ExcludeStatuses = nil
+		// Apenas o autor enxerga as dietas removidas, para poder restaurá-las
+		filter.IncludeDeleted = input.IncludeDeleted
+
 		if input.UserEmail != "" {
-			filter.UserEmail = &input.UserEmail
+			if err := requireActivePatient(ctx, uc.linkRepo, input.UserID, input.UserEmail); err != nil {
+				return nil, err
+			}
+			patientEmail := normalizeEmail(input.UserEmail)
+			filter.UserEmail = &patientEmail
+		} else {
+			emails, err := activePatientEmails(ctx, uc.linkRepo, input.UserID)
+			if err != nil {
+				return nil, err
+			}
+			filter.UserEmails = emails
 		}
 	}
 
@@ -52,5 +100,80 @@ func (uc *listDietsUseCase) Execute(ctx context.Context, input *dto.ListDietsInp
 		return nil, err
 	}
 
-	return dto.NewListDietsUseCaseOutput(diets), nil
+	// Uma dieta a mais que o limite indica que existe próxima página
+	next := ""
+	if len(diets) > limit {
+		diets = diets[:limit]
+		next = newDietCursor(diets[limit-1], filter.SortBy, filter.SortDesc).encode()
+	}
+
+	if err := uc.nutrition.annotate(ctx, diets...); err != nil {
+		return nil, err
+	}
+
+	return dto.NewListDietsUseCaseOutput(diets, limit, next), nil
+}
+
+// newPagedDietFilter valida os parâmetros de filtro, ordenação e paginação
+func newPagedDietFilter(input *dto.ListDietsInput) (*DietFilter, int, error) {
+	filter := &DietFilter{
+		CreatedFrom: input.CreatedFrom,
+		CreatedTo:   input.CreatedTo,
+		UpdatedFrom: input.UpdatedFrom,
+		UpdatedTo:   input.UpdatedTo,
+		SortBy:      DietSortCreatedAt,
+		SortDesc:    true,
+	}
+
+	if input.Status != "" {
+		status := strings.ToUpper(input.Status)
+		filter.Status = &status
+	}
+
+	if name := strings.TrimSpace(input.Name); name != "" {
+		filter.NameContains = &name
+	}
+
+	if input.SortBy != "" {
+		if !isValidDietSort(input.SortBy) {
+			return nil, 0, fmt.Errorf("%w: sortBy must be one of created_at, updated_at, name", ErrInvalidListParams)
+		}
+		filter.SortBy = input.SortBy
+	}
+
+	switch strings.ToLower(input.Order) {
+	case "":
+		// Datas mais recentes primeiro; nomes em ordem alfabética
+		filter.SortDesc = filter.SortBy != DietSortName
+	case "asc":
+		filter.SortDesc = false
+	case "desc":
+		filter.SortDesc = true
+	default:
+		return nil, 0, fmt.Errorf("%w: order must be asc or desc", ErrInvalidListParams)
+	}
+
+	limit := input.Limit
+	switch {
+	case limit == 0:
+		limit = defaultDietPageSize
+	case limit < 0 || limit > maxDietPageSize:
+		return nil, 0, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidListParams, maxDietPageSize)
+	}
+	filter.Limit = int64(limit + 1)
+
+	if input.Cursor != "" {
+		cursor, err := decodeDietCursor(input.Cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// O cursor só vale para a mesma ordenação em que foi gerado
+		if cursor.SortBy != filter.SortBy || cursor.Desc != filter.SortDesc {
+			return nil, 0, ErrInvalidCursor
+		}
+		filter.After = cursor
+	}
+
+	return filter, limit, nil
 }
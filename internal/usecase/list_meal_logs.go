@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ListMealLogsUseCase lists the meal logs of a diet for the patient or the
+// nutritionist who created it
+type ListMealLogsUseCase interface {
+	// from e to no formato AAAA-MM-DD; vazios não limitam o período
+	Execute(ctx context.Context, userID, dietID, from, to string) ([]*entity.MealLog, error)
+}
+
+type listMealLogsUseCase struct {
+	dietRepo    DietRepository
+	userRepo    UserRepository
+	mealLogRepo MealLogRepository
+}
+
+// NewListMealLogs creates a new instance of ListMealLogsUseCase
+func NewListMealLogs(dietRepo DietRepository, userRepo UserRepository, mealLogRepo MealLogRepository) ListMealLogsUseCase {
+	return &listMealLogsUseCase{
+		dietRepo:    dietRepo,
+		userRepo:    userRepo,
+		mealLogRepo: mealLogRepo,
+	}
+}
+
+func (uc *listMealLogsUseCase) Execute(ctx context.Context, userID, dietID, from, to string) ([]*entity.MealLog, error) {
+	if err := validateDateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	diet, err := visibleDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.mealLogRepo.FindByDiet(ctx, diet.ID, from, to)
+}
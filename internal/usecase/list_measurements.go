@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ListMeasurementsUseCase returns the measurements of a patient in a period
+// with the derived metrics and the progress over the period
+type ListMeasurementsUseCase interface {
+	// linkID vazio lista as medidas do próprio usuário
+	Execute(ctx context.Context, userID, linkID string, from, to *time.Time) (*entity.MeasurementSeries, error)
+}
+
+type listMeasurementsUseCase struct {
+	measurementRepo MeasurementRepository
+	linkRepo        PatientLinkRepository
+	userRepo        UserRepository
+}
+
+// NewListMeasurements creates a new instance of ListMeasurementsUseCase
+func NewListMeasurements(measurementRepo MeasurementRepository, linkRepo PatientLinkRepository, userRepo UserRepository) ListMeasurementsUseCase {
+	return &listMeasurementsUseCase{
+		measurementRepo: measurementRepo,
+		linkRepo:        linkRepo,
+		userRepo:        userRepo,
+	}
+}
+
+func (uc *listMeasurementsUseCase) Execute(ctx context.Context, userID, linkID string, from, to *time.Time) (*entity.MeasurementSeries, error) {
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, ErrInvalidListParams
+	}
+
+	patient, _, err := measurementPatient(ctx, uc.linkRepo, uc.userRepo, userID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	measurements, err := uc.measurementRepo.FindByPatient(ctx, patient.ID.Hex(), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	height := anthropometricHeight(patient)
+	for _, measurement := range measurements {
+		deriveMeasurementMetrics(measurement, height)
+	}
+
+	return &entity.MeasurementSeries{
+		PatientID:    patient.ID.Hex(),
+		From:         from,
+		To:           to,
+		Measurements: measurements,
+		Progress:     measurementProgress(measurements, patient.Anthropometrics),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ListNutritionistRequestsUseCase lists users by nutritionist approval status
+type ListNutritionistRequestsUseCase interface {
+	Execute(ctx context.Context, status entity.NutritionistStatus) ([]*entity.User, error)
+}
+
+type listNutritionistRequestsUseCase struct {
+	userRepo UserRepository
+}
+
+// NewListNutritionistRequests creates a new instance of ListNutritionistRequestsUseCase
+func NewListNutritionistRequests(userRepo UserRepository) ListNutritionistRequestsUseCase {
+	return &listNutritionistRequestsUseCase{userRepo: userRepo}
+}
+
+func (uc *listNutritionistRequestsUseCase) Execute(ctx context.Context, status entity.NutritionistStatus) ([]*entity.User, error) {
+	if status == "" {
+		status = entity.NutritionistPending
+	}
+
+	return uc.userRepo.FindByNutritionistStatus(ctx, status)
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ListNutritionistsUseCase lists the links (and invitations) of a patient
+type ListNutritionistsUseCase interface {
+	Execute(ctx context.Context, userID string, status *entity.PatientLinkStatus) ([]*entity.PatientLink, error)
+}
+
+type listNutritionistsUseCase struct {
+	linkRepo PatientLinkRepository
+	userRepo UserRepository
+}
+
+// NewListNutritionists creates a new instance of ListNutritionistsUseCase
+func NewListNutritionists(linkRepo PatientLinkRepository, userRepo UserRepository) ListNutritionistsUseCase {
+	return &listNutritionistsUseCase{
+		linkRepo: linkRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (uc *listNutritionistsUseCase) Execute(ctx context.Context, userID string, status *entity.PatientLinkStatus) ([]*entity.PatientLink, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return uc.linkRepo.FindByPatientEmail(ctx, normalizeEmail(user.Email), status)
+}
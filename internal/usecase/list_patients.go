@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ListPatientsUseCase lists the links of a nutritionist, optionally by status
+type ListPatientsUseCase interface {
+	Execute(ctx context.Context, nutritionistID string, status *entity.PatientLinkStatus) ([]*entity.PatientLink, error)
+}
+
+type listPatientsUseCase struct {
+	linkRepo PatientLinkRepository
+}
+
+// NewListPatients creates a new instance of ListPatientsUseCase
+func NewListPatients(linkRepo PatientLinkRepository) ListPatientsUseCase {
+	return &listPatientsUseCase{linkRepo: linkRepo}
+}
+
+func (uc *listPatientsUseCase) Execute(ctx context.Context, nutritionistID string, status *entity.PatientLinkStatus) ([]*entity.PatientLink, error) {
+	return uc.linkRepo.FindByNutritionist(ctx, nutritionistID, status)
+}
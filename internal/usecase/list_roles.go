@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ListRolesUseCase returns every stored role
+type ListRolesUseCase interface {
+	Execute(ctx context.Context) ([]*entity.Role, error)
+}
+
+type listRolesUseCase struct {
+	roleRepo RoleRepository
+}
+
+// NewListRoles creates a new instance of ListRolesUseCase
+func NewListRoles(roleRepo RoleRepository) ListRolesUseCase {
+	return &listRolesUseCase{roleRepo: roleRepo}
+}
+
+func (uc *listRolesUseCase) Execute(ctx context.Context) ([]*entity.Role, error) {
+	return uc.roleRepo.List(ctx)
+}
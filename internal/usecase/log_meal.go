@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// LogMealUseCase lets the patient report what they ate in a meal of the diet
+type LogMealUseCase interface {
+	Execute(ctx context.Context, userID, dietID string, log *entity.MealLog) (*entity.MealLog, error)
+}
+
+type logMealUseCase struct {
+	dietRepo    DietRepository
+	userRepo    UserRepository
+	mealLogRepo MealLogRepository
+}
+
+// NewLogMeal creates a new instance of LogMealUseCase
+func NewLogMeal(dietRepo DietRepository, userRepo UserRepository, mealLogRepo MealLogRepository) LogMealUseCase {
+	return &logMealUseCase{
+		dietRepo:    dietRepo,
+		userRepo:    userRepo,
+		mealLogRepo: mealLogRepo,
+	}
+}
+
+func (uc *logMealUseCase) Execute(ctx context.Context, userID, dietID string, log *entity.MealLog) (*entity.MealLog, error) {
+	diet, patient, err := patientDiet(ctx, uc.dietRepo, uc.userRepo, userID, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Só a dieta em andamento recebe registros
+	if diet.Status != string(entity.DietActive) {
+		return nil, ErrDietNotActive
+	}
+
+	now := time.Now()
+	if log.Date == "" {
+		log.Date = now.Format(entity.MealLogDateLayout)
+	}
+	if err := validateMealLog(diet, log, now); err != nil {
+		return nil, err
+	}
+
+	log.ID = uuid.NewString()
+	log.DietID = diet.ID
+	log.PatientID = patient.ID.Hex()
+	log.LoggedAt = now
+	log.UpdatedAt = now
+
+	return uc.mealLogRepo.Upsert(ctx, log)
+}
@@ -6,34 +6,52 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/victorgiudicissi/your-diet/internal/constants"
 	"github.com/victorgiudicissi/your-diet/internal/entity"
 	"golang.org/x/crypto/bcrypt"
 )
 
-var JWTSecretKey = []byte("your-very-secret-jwt-key-here")
-
 var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
 	ErrUserNotActive      = errors.New("user account is not active")
 )
 
+const (
+	// MaxFailedLoginAttempts is how many consecutive failures are tolerated before locking
+	MaxFailedLoginAttempts = 5
+	// LoginLockoutBaseDuration is the first lockout, doubled on every further failure
+	LoginLockoutBaseDuration = 1 * time.Minute
+	// LoginLockoutMaxDuration caps the exponential backoff
+	LoginLockoutMaxDuration = 1 * time.Hour
+	// FailedLoginWindow is how long a failure counts towards the lockout
+	FailedLoginWindow = 24 * time.Hour
+)
+
 type LoginUseCase interface {
 	Execute(ctx context.Context, input *entity.LoginUseCaseInput) (*entity.LoginUseCaseOutput, error)
 }
 
 type Claims struct {
 	UserID      string   `json:"user_id"`
+	SessionID   string   `json:"session_id"`
 	Permissions []string `json:"permissions"`
 	jwt.RegisteredClaims
 }
 
 type loginUseCase struct {
-	userRepo UserRepository
+	userRepo    UserRepository
+	sessionRepo SessionRepository
+	tokens      *tokenIssuer
+	// requireVerifiedEmail blocks login until the user confirms their email
+	requireVerifiedEmail bool
 }
 
-func NewLogin(userRepo UserRepository) LoginUseCase {
-	return &loginUseCase{userRepo: userRepo}
+func NewLogin(userRepo UserRepository, sessionRepo SessionRepository, roleRepo RoleRepository, signer TokenSigner, requireVerifiedEmail bool) LoginUseCase {
+	return &loginUseCase{
+		userRepo:             userRepo,
+		sessionRepo:          sessionRepo,
+		tokens:               &tokenIssuer{signer: signer, roleRepo: roleRepo},
+		requireVerifiedEmail: requireVerifiedEmail,
+	}
 }
 
 func (uc *loginUseCase) Execute(ctx context.Context, input *entity.LoginUseCaseInput) (*entity.LoginUseCaseOutput, error) {
@@ -45,32 +63,56 @@ func (uc *loginUseCase) Execute(ctx context.Context, input *entity.LoginUseCaseI
 		return nil, err
 	}
 
+	if user == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	now := time.Now()
+
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		return nil, &AccountLockedError{RetryAfter: user.LockedUntil.Sub(now)}
+	}
+
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password))
 	if err != nil {
-		return nil, ErrInvalidCredentials
+		return nil, uc.registerFailure(ctx, user, now)
 	}
 
-	permissions := constants.GetPermissionsByUserType(user.Type)
-
-	expirationTime := time.Now().Add(1000 * time.Minute)
-	claims := &Claims{
-		UserID:      user.ID.Hex(),
-		Permissions: permissions,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Subject:   user.ID.Hex(),
-		},
+	if user.FailedLoginAttempts > 0 || user.LockedUntil != nil {
+		if err := uc.userRepo.ResetFailedLogins(ctx, user.ID.Hex()); err != nil {
+			return nil, err
+		}
+	}
+
+	if uc.requireVerifiedEmail && !user.EmailVerified {
+		return nil, ErrEmailNotVerified
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(JWTSecretKey)
+	return uc.tokens.startSession(ctx, uc.sessionRepo, user, input.UserAgent, input.IPAddress)
+}
+
+// registerFailure counts a failed attempt and locks the account once the limit
+// is reached. Every failure past the limit doubles the lockout duration. The
+// count comes from the database, so parallel attempts all reach the limit.
+func (uc *loginUseCase) registerFailure(ctx context.Context, user *entity.User, now time.Time) error {
+	attempts, err := uc.userRepo.RecordFailedLogin(ctx, user.ID.Hex(), now, now.Add(-FailedLoginWindow))
 	if err != nil {
-		return nil, err // Internal server error (failed to sign token)
+		return err
+	}
+
+	if attempts < MaxFailedLoginAttempts {
+		return ErrInvalidCredentials
+	}
+
+	lockout := LoginLockoutMaxDuration
+	if shift := attempts - MaxFailedLoginAttempts; shift < 16 {
+		lockout = min(LoginLockoutBaseDuration<<shift, LoginLockoutMaxDuration)
+	}
+	lockedUntil := now.Add(lockout)
+
+	if err := uc.userRepo.LockLogin(ctx, user.ID.Hex(), lockedUntil); err != nil {
+		return err
 	}
 
-	return &entity.LoginUseCaseOutput{
-		Token:     tokenString,
-		ExpiresAt: expirationTime,
-	}, nil
+	return &AccountLockedError{RetryAfter: lockout}
 }
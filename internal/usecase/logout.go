@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+// LogoutUseCase revokes the session an access token was issued for
+type LogoutUseCase interface {
+	Execute(ctx context.Context, sessionID string) error
+}
+
+type logoutUseCase struct {
+	sessionRepo SessionRepository
+}
+
+// NewLogout creates a new instance of LogoutUseCase
+func NewLogout(sessionRepo SessionRepository) LogoutUseCase {
+	return &logoutUseCase{sessionRepo: sessionRepo}
+}
+
+func (uc *logoutUseCase) Execute(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return ErrSessionRevoked
+	}
+
+	return uc.sessionRepo.Revoke(ctx, sessionID, revokeReasonLogout, time.Now())
+}
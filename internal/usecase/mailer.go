@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// Mailer delivers transactional emails such as password reset and email
+// verification messages.
+type Mailer interface {
+	Send(ctx context.Context, message *entity.EmailMessage) error
+}
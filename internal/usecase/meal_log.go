@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+// patientDiet loads a diet that belongs to the user, who must be its patient.
+// Drafts are not the plan of the patient yet.
+func patientDiet(ctx context.Context, dietRepo DietRepository, userRepo UserRepository, userID, dietID string) (*entity.Diet, *entity.User, error) {
+	diet, err := dietRepo.GetDietByID(ctx, dietID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if diet == nil || diet.IsDeleted() || diet.Status == string(entity.DietDraft) {
+		return nil, nil, ErrDietNotFound
+	}
+
+	user, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, ErrUserNotFound
+	}
+
+	if normalizeEmail(diet.UserEmail) != normalizeEmail(user.Email) {
+		return nil, nil, ErrUnauthorized
+	}
+
+	return diet, user, nil
+}
+
+// findDietMeal returns the meal with the name among the meals of a day,
+// ignoring case and accents
+func findDietMeal(meals []entity.Meal, name string) (*entity.Meal, bool) {
+	normalized := utils.NormalizeText(name)
+	for i := range meals {
+		if utils.NormalizeText(meals[i].Name) == normalized {
+			return &meals[i], true
+		}
+	}
+	return nil, false
+}
+
+// validateMealLog checks the log against the diet and fills the canonical
+// names of the meal and of the chosen substitute
+func validateMealLog(diet *entity.Diet, log *entity.MealLog, now time.Time) error {
+	switch log.Status {
+	case entity.MealFollowed, entity.MealPartial, entity.MealSkipped, entity.MealSubstituted:
+	default:
+		return fmt.Errorf("%w: status desconhecido: %q", ErrInvalidMealLog, log.Status)
+	}
+
+	date, err := time.ParseInLocation(entity.MealLogDateLayout, log.Date, now.Location())
+	if err != nil {
+		return fmt.Errorf("%w: date deve estar no formato AAAA-MM-DD", ErrInvalidMealLog)
+	}
+	// Um dia de folga para o fuso horário do paciente
+	if date.After(now.AddDate(0, 0, 1)) {
+		return fmt.Errorf("%w: não é possível registrar refeições futuras", ErrInvalidMealLog)
+	}
+	start, end := dietPeriod(diet, now.AddDate(0, 0, 1))
+	if date.Before(start) || date.After(end) {
+		return fmt.Errorf("%w: %s está fora do período da dieta (%s a %s)", ErrInvalidMealLog, log.Date,
+			start.Format(entity.MealLogDateLayout), end.Format(entity.MealLogDateLayout))
+	}
+
+	// Em dietas com modelos de dia, a refeição precisa estar prevista na data
+	meal, ok := findDietMeal(dayplan.MealsOn(diet, dietStart(diet, now.Location()), date), log.MealName)
+	if !ok {
+		return fmt.Errorf("%w: a dieta não tem a refeição %q em %s", ErrInvalidMealLog, log.MealName, log.Date)
+	}
+	log.MealName = meal.Name
+
+	if log.Substitute == nil {
+		return nil
+	}
+	if log.Status != entity.MealSubstituted {
+		return fmt.Errorf("%w: substitute só pode ser informado com status SUBSTITUTED", ErrInvalidMealLog)
+	}
+
+	for _, ingredient := range meal.Ingredients {
+		if utils.NormalizeText(ingredient.Description) != utils.NormalizeText(log.Substitute.Ingredient) {
+			continue
+		}
+		for _, substitute := range ingredient.Substitutes {
+			if utils.NormalizeText(substitute.Description) == utils.NormalizeText(log.Substitute.Substitute) {
+				log.Substitute = &entity.ChosenSubstitute{Ingredient: ingredient.Description, Substitute: substitute.Description}
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %q não é um substituto de %q nesta refeição", ErrInvalidMealLog, log.Substitute.Substitute, log.Substitute.Ingredient)
+}
+
+// findDietMealLog loads a log of the diet
+func findDietMealLog(ctx context.Context, mealLogRepo MealLogRepository, dietID, logID string) (*entity.MealLog, error) {
+	log, err := mealLogRepo.FindByID(ctx, logID)
+	if err != nil {
+		return nil, err
+	}
+	if log == nil || log.DietID != dietID {
+		return nil, ErrMealLogNotFound
+	}
+	return log, nil
+}
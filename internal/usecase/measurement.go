@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// measurementPatient resolves whose measurements are being accessed: the
+// logged user when linkID is empty, or the patient of an active link of the
+// nutritionist. The source tells which fields may be informed.
+func measurementPatient(ctx context.Context, linkRepo PatientLinkRepository, userRepo UserRepository, userID, linkID string) (*entity.User, entity.MeasurementSource, error) {
+	if linkID != "" {
+		patient, err := linkedPatient(ctx, linkRepo, userRepo, userID, linkID)
+		return patient, entity.MeasurementByNutritionist, err
+	}
+
+	patient, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if patient == nil {
+		return nil, "", ErrUserNotFound
+	}
+	return patient, entity.MeasurementByPatient, nil
+}
+
+// validateMeasurement checks the ranges of the values. Patients only inform
+// their weight; the other measures are taken by the nutritionist.
+func validateMeasurement(m *entity.Measurement, source entity.MeasurementSource) error {
+	if source == entity.MeasurementByPatient &&
+		(m.HeightCm != nil || m.WaistCm != nil || m.HipCm != nil || m.BodyFatPct != nil || m.Skinfolds != nil) {
+		return fmt.Errorf("%w: pacientes só podem informar o peso", ErrInvalidMeasurement)
+	}
+
+	if m.WeightKg == nil && m.HeightCm == nil && m.WaistCm == nil && m.HipCm == nil && m.BodyFatPct == nil && m.Skinfolds == nil {
+		return fmt.Errorf("%w: informe ao menos uma medida", ErrInvalidMeasurement)
+	}
+
+	ranges := []struct {
+		name     string
+		value    *float64
+		min, max float64
+	}{
+		{"weight_kg", m.WeightKg, 20, 350},
+		{"height_cm", m.HeightCm, 50, 250},
+		{"waist_cm", m.WaistCm, 30, 250},
+		{"hip_cm", m.HipCm, 30, 250},
+		{"body_fat_pct", m.BodyFatPct, 2, 70},
+	}
+	for _, r := range ranges {
+		if r.value != nil && (*r.value < r.min || *r.value > r.max) {
+			return fmt.Errorf("%w: %s deve estar entre %g e %g", ErrInvalidMeasurement, r.name, r.min, r.max)
+		}
+	}
+
+	if m.Skinfolds != nil {
+		values := m.Skinfolds.Values()
+		if len(values) == 0 {
+			return fmt.Errorf("%w: informe ao menos uma dobra cutânea", ErrInvalidMeasurement)
+		}
+		for _, value := range values {
+			if value <= 0 || value > 80 {
+				return fmt.Errorf("%w: dobras cutâneas devem estar entre 0 e 80 mm", ErrInvalidMeasurement)
+			}
+		}
+	}
+
+	return nil
+}
+
+// deriveMeasurementMetrics fills BMI, waist-hip ratio and the sum of
+// skinfolds. The height of the measurement is used when informed, otherwise
+// the height of the anthropometrics.
+func deriveMeasurementMetrics(m *entity.Measurement, heightCm float64) {
+	if m.HeightCm != nil {
+		heightCm = *m.HeightCm
+	}
+
+	if m.WeightKg != nil && heightCm > 0 {
+		heightM := heightCm / 100
+		bmi := round2(*m.WeightKg / (heightM * heightM))
+		m.BMI = &bmi
+	}
+
+	if m.WaistCm != nil && m.HipCm != nil && *m.HipCm > 0 {
+		ratio := round2(*m.WaistCm / *m.HipCm)
+		m.WaistHipRatio = &ratio
+	}
+
+	if m.Skinfolds != nil {
+		var sum float64
+		for _, value := range m.Skinfolds.Values() {
+			sum += value
+		}
+		sum = round2(sum)
+		m.SkinfoldSumMm = &sum
+	}
+}
+
+// measurementProgress compares the first and the last measurements of the
+// period, which must be in chronological order
+func measurementProgress(measurements []*entity.Measurement, anthropometrics *entity.Anthropometrics) entity.MeasurementProgress {
+	var progress entity.MeasurementProgress
+
+	first, last := firstAndLast(measurements, func(m *entity.Measurement) *float64 { return m.WeightKg })
+	if first != nil {
+		progress.StartWeightKg = first.WeightKg
+		progress.CurrentWeightKg = last.WeightKg
+		progress.CurrentBMI = last.BMI
+		if last.BMI != nil {
+			progress.BMIClassification = bmiClassification(*last.BMI)
+		}
+
+		change := round2(*last.WeightKg - *first.WeightKg)
+		progress.WeightChangeKg = &change
+
+		if days := last.MeasuredAt.Sub(first.MeasuredAt).Hours() / 24; days >= 1 {
+			weekly := round2(change / days * 7)
+			progress.WeeklyRateKg = &weekly
+		}
+	}
+
+	if first, last := firstAndLast(measurements, func(m *entity.Measurement) *float64 { return m.WaistCm }); first != nil {
+		change := round2(*last.WaistCm - *first.WaistCm)
+		progress.WaistChangeCm = &change
+	}
+
+	if first, last := firstAndLast(measurements, func(m *entity.Measurement) *float64 { return m.BodyFatPct }); first != nil {
+		change := round2(*last.BodyFatPct - *first.BodyFatPct)
+		progress.BodyFatChangePct = &change
+	}
+
+	if anthropometrics != nil && anthropometrics.TargetWeightKg != nil {
+		target := *anthropometrics.TargetWeightKg
+		progress.TargetWeightKg = &target
+
+		if progress.CurrentWeightKg != nil {
+			remaining := round2(*progress.CurrentWeightKg - target)
+			progress.RemainingKg = &remaining
+
+			if distance := *progress.StartWeightKg - target; distance != 0 {
+				pct := round2((*progress.StartWeightKg - *progress.CurrentWeightKg) / distance * 100)
+				progress.GoalProgressPct = &pct
+			}
+		}
+	}
+
+	return progress
+}
+
+// firstAndLast returns the first and the last measurements with the value
+func firstAndLast(measurements []*entity.Measurement, value func(*entity.Measurement) *float64) (first, last *entity.Measurement) {
+	for _, m := range measurements {
+		if value(m) == nil {
+			continue
+		}
+		if first == nil {
+			first = m
+		}
+		last = m
+	}
+	return first, last
+}
+
+func bmiClassification(bmi float64) string {
+	switch {
+	case bmi < 18.5:
+		return "UNDERWEIGHT"
+	case bmi < 25:
+		return "NORMAL"
+	case bmi < 30:
+		return "OVERWEIGHT"
+	case bmi < 35:
+		return "OBESITY_I"
+	case bmi < 40:
+		return "OBESITY_II"
+	default:
+		return "OBESITY_III"
+	}
+}
+
+// findPatientMeasurement loads a measurement of the patient
+func findPatientMeasurement(ctx context.Context, measurementRepo MeasurementRepository, patientID, measurementID string) (*entity.Measurement, error) {
+	measurement, err := measurementRepo.FindByID(ctx, measurementID)
+	if err != nil {
+		return nil, err
+	}
+	if measurement == nil || measurement.PatientID != patientID {
+		return nil, ErrMeasurementNotFound
+	}
+	return measurement, nil
+}
+
+func anthropometricHeight(patient *entity.User) float64 {
+	if patient.Anthropometrics == nil {
+		return 0
+	}
+	return patient.Anthropometrics.HeightCm
+}
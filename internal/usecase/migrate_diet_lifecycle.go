@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// MigrateDietLifecycleUseCase converts the ENABLED/DISABLED statuses of old
+// diets into the lifecycle: the diet starts on the day it was created,
+// DISABLED becomes ARCHIVED and ENABLED follows the dates. When a patient ends
+// up with several active diets, the older ones expire the day before the
+// newest one starts. The email of the patient is normalized first, so diets
+// typed with another casing count as the same patient.
+type MigrateDietLifecycleUseCase interface {
+	Execute(ctx context.Context) (int, error)
+}
+
+type migrateDietLifecycleUseCase struct {
+	dietRepo DietRepository
+}
+
+// NewMigrateDietLifecycle creates a new instance of MigrateDietLifecycleUseCase
+func NewMigrateDietLifecycle(dietRepo DietRepository) MigrateDietLifecycleUseCase {
+	return &migrateDietLifecycleUseCase{
+		dietRepo: dietRepo,
+	}
+}
+
+func (uc *migrateDietLifecycleUseCase) Execute(ctx context.Context) (int, error) {
+	migrated, err := uc.dietRepo.NormalizeUserEmails(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	diets, err := uc.dietRepo.FindDiets(ctx, &DietFilter{})
+	if err != nil {
+		return migrated, err
+	}
+
+	now := time.Now()
+	changed := map[string]*entity.Diet{}
+	active := map[string][]*entity.Diet{}
+	for _, diet := range diets {
+		switch entity.DietStatus(diet.Status) {
+		case entity.Enabled, "":
+			setDietDates(diet, diet.CreatedAt.In(now.Location()))
+			diet.Status = string(statusForDates(diet, now))
+			changed[diet.ID] = diet
+		case entity.Disabled:
+			setDietDates(diet, diet.CreatedAt.In(now.Location()))
+			diet.Status = string(entity.DietArchived)
+			changed[diet.ID] = diet
+		}
+
+		if diet.Status == string(entity.DietActive) {
+			email := normalizeEmail(diet.UserEmail)
+			active[email] = append(active[email], diet)
+		}
+	}
+
+	for _, patientDiets := range active {
+		sort.Slice(patientDiets, func(i, j int) bool {
+			return patientDiets[i].StartsAt.After(*patientDiets[j].StartsAt)
+		})
+
+		newest := patientDiets[0]
+		for _, older := range patientDiets[1:] {
+			duration := dayplan.DaysBetween(*older.StartsAt, *newest.StartsAt)
+			if duration < 1 {
+				duration = 1
+			}
+			older.DurationInDays = uint32(duration)
+			setDietDates(older, *older.StartsAt)
+			older.Status = string(entity.DietExpired)
+			changed[older.ID] = older
+		}
+	}
+
+	for _, diet := range changed {
+		if err := uc.dietRepo.UpdateDiet(ctx, diet, diet.Version); err != nil {
+			if errors.Is(err, ErrVersionConflict) {
+				log.Printf("[MigrateDietLifecycleUseCase] Skipping diet %s updated during the migration", diet.ID)
+				continue
+			}
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/mealtime"
+)
+
+// MigrateMealTimesUseCase converts the free-text time_of_day of existing
+// diets into HH:MM times with a slot and sorts their meals chronologically.
+// Texts that cannot be parsed are kept as they are, with the OTHER slot.
+type MigrateMealTimesUseCase interface {
+	Execute(ctx context.Context) (int, error)
+}
+
+type migrateMealTimesUseCase struct {
+	dietRepo     DietRepository
+	revisionRepo DietRevisionRepository
+}
+
+// NewMigrateMealTimes creates a new instance of MigrateMealTimesUseCase
+func NewMigrateMealTimes(dietRepo DietRepository, revisionRepo DietRevisionRepository) MigrateMealTimesUseCase {
+	return &migrateMealTimesUseCase{
+		dietRepo:     dietRepo,
+		revisionRepo: revisionRepo,
+	}
+}
+
+func (uc *migrateMealTimesUseCase) Execute(ctx context.Context) (int, error) {
+	diets, err := uc.dietRepo.FindDiets(ctx, &DietFilter{})
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, diet := range diets {
+		// As refeições são convertidas numa cópia para a revisão de base
+		// guardar o texto original
+		meals := append([]entity.Meal(nil), diet.Meals...)
+		changed := false
+		for i := range meals {
+			if structureMealTime(&meals[i]) {
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		mealtime.Sort(meals)
+
+		if err := ensureBaselineRevision(ctx, uc.revisionRepo, diet); err != nil {
+			return migrated, err
+		}
+		diet.Meals = meals
+
+		if err := uc.dietRepo.UpdateDiet(ctx, diet, diet.Version); err != nil {
+			if errors.Is(err, ErrVersionConflict) {
+				log.Printf("[MigrateMealTimesUseCase] Skipping diet %s updated during the migration", diet.ID)
+				continue
+			}
+			return migrated, err
+		}
+		migrated++
+
+		if _, err := recordRevision(ctx, uc.revisionRepo, diet, entity.RevisionUpdated, entity.RevisionAuthorMigration, nil); err != nil {
+			return migrated, err
+		}
+	}
+
+	return migrated, nil
+}
+
+// structureMealTime fills the time, window and slot of a meal from its
+// free-text time_of_day, falling back to the meal name. It reports whether
+// the meal changed.
+func structureMealTime(meal *entity.Meal) bool {
+	if mealtime.IsStructured(*meal) {
+		return false
+	}
+
+	nameSlot, hasNameSlot := mealtime.SlotFromText(meal.Name)
+
+	parsed, ok := mealtime.ParseFreeText(meal.TimeOfDay)
+	if !ok {
+		parsed, ok = mealtime.ParseFreeText(meal.Name)
+	}
+
+	if !ok {
+		if meal.Slot != "" {
+			return false
+		}
+		meal.Slot = entity.SlotOther
+		if hasNameSlot {
+			meal.Slot = nameSlot
+		}
+		log.Printf("[MigrateMealTimesUseCase] Could not parse the time %q of meal %q", meal.TimeOfDay, meal.Name)
+		return true
+	}
+
+	if !parsed.ExplicitSlot && hasNameSlot {
+		parsed.Slot = nameSlot
+	}
+	if mealtime.IsSlot(string(meal.Slot)) {
+		parsed.Slot = meal.Slot
+	}
+
+	meal.TimeOfDay = parsed.TimeOfDay
+	if meal.WindowStart == "" && meal.WindowEnd == "" {
+		meal.WindowStart = parsed.WindowStart
+		meal.WindowEnd = parsed.WindowEnd
+	}
+	meal.Slot = parsed.Slot
+	return true
+}
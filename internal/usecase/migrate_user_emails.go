@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+)
+
+// MigrateUserEmailsUseCase stores the emails of the existing users in
+// lowercase, as registration does, so the patients of links and diets are
+// found whatever casing they used to sign up.
+type MigrateUserEmailsUseCase interface {
+	Execute(ctx context.Context) (int, error)
+}
+
+type migrateUserEmailsUseCase struct {
+	userRepo UserRepository
+}
+
+// NewMigrateUserEmails creates a new instance of MigrateUserEmailsUseCase
+func NewMigrateUserEmails(userRepo UserRepository) MigrateUserEmailsUseCase {
+	return &migrateUserEmailsUseCase{
+		userRepo: userRepo,
+	}
+}
+
+func (uc *migrateUserEmailsUseCase) Execute(ctx context.Context) (int, error) {
+	return uc.userRepo.NormalizeEmails(ctx)
+}
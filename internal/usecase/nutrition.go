@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/units"
+)
+
+// nutritionCalculator fills the computed nutrition of diets. Nutrients come
+// from the ingredient itself (nutrients_per_100g) or from the catalog food
+// referenced by food_id. When the patient has anthropometrics, the daily
+// values are compared with the patient's targets.
+type nutritionCalculator struct {
+	foodRepo FoodRepository
+	userRepo UserRepository
+}
+
+// annotate computes and sets the nutrition of every diet, loading all the
+// referenced foods with a single query.
+func (c nutritionCalculator) annotate(ctx context.Context, diets ...*entity.Diet) error {
+	var meals []entity.Meal
+	for _, diet := range diets {
+		meals = append(meals, dayplan.AllMeals(diet)...)
+	}
+
+	foods := map[string]*entity.Food{}
+	if ids := collectFoodIDs(meals); len(ids) > 0 {
+		found, err := c.foodRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, food := range found {
+			foods[food.ID] = food
+		}
+	}
+
+	targets := map[string]*entity.EnergyTargets{}
+	for _, diet := range diets {
+		diet.Nutrition = computeDietNutrition(diet, foods)
+
+		email := normalizeEmail(diet.UserEmail)
+		target, loaded := targets[email]
+		if !loaded {
+			var err error
+			if target, err = c.patientTargets(ctx, email); err != nil {
+				return err
+			}
+			targets[email] = target
+		}
+
+		if target != nil {
+			diet.Nutrition.Target = targetDeviation(diet.Nutrition.PerDay, *target)
+		}
+	}
+	return nil
+}
+
+// patientTargets returns the energy targets of the patient, or nil when the
+// patient has no account or no anthropometrics
+func (c nutritionCalculator) patientTargets(ctx context.Context, email string) (*entity.EnergyTargets, error) {
+	if c.userRepo == nil || email == "" {
+		return nil, nil
+	}
+
+	patient, err := c.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if patient == nil || patient.Anthropometrics == nil {
+		return nil, nil
+	}
+
+	assessment, err := assessEnergy(patient, "")
+	if err != nil {
+		return nil, nil
+	}
+	return &assessment.Targets, nil
+}
+
+func computeDietNutrition(diet *entity.Diet, foods map[string]*entity.Food) *entity.DietNutrition {
+	nutrition := &entity.DietNutrition{
+		DietID:     diet.ID,
+		Unresolved: []string{},
+	}
+
+	var perDay entity.Nutrients
+	nutrition.Meals, perDay = computeMealsNutrition(diet.Meals, foods, "", &nutrition.Unresolved)
+	if len(diet.Days) == 0 {
+		nutrition.PerDay = perDay.Rounded()
+		nutrition.Total = perDay.Scale(float64(diet.DurationInDays)).Rounded()
+		nutrition.MacroSplit = perDay.MacroSplit()
+		return nutrition
+	}
+
+	dayTotals := make(map[string]entity.Nutrients, len(diet.Days))
+	for _, day := range diet.Days {
+		meals, total := computeMealsNutrition(day.Meals, foods, day.Name+" / ", &nutrition.Unresolved)
+		dayTotals[day.Name] = total
+		nutrition.Days = append(nutrition.Days, entity.DayNutrition{
+			Name:      day.Name,
+			Nutrients: total.Rounded(),
+			Meals:     meals,
+		})
+	}
+
+	// O total percorre os dias da dieta, cada um com o modelo que lhe cabe
+	var total entity.Nutrients
+	start := dietStart(diet, time.Local)
+	for i := 0; i < int(diet.DurationInDays); i++ {
+		if template := dayplan.TemplateFor(diet, start, start.AddDate(0, 0, i)); template != nil {
+			total.Add(dayTotals[template.Name])
+		} else {
+			total.Add(perDay)
+		}
+	}
+
+	average := total
+	if diet.DurationInDays > 0 {
+		average = total.Scale(1 / float64(diet.DurationInDays))
+	}
+	nutrition.PerDay = average.Rounded()
+	nutrition.Total = total.Rounded()
+	nutrition.MacroSplit = average.MacroSplit()
+	return nutrition
+}
+
+// computeMealsNutrition computes the nutrition of the meals of one day,
+// returning the meals and the total of the day. Unresolved ingredients are
+// appended with the prefix, used to tell the day templates apart.
+func computeMealsNutrition(meals []entity.Meal, foods map[string]*entity.Food, prefix string, unresolved *[]string) ([]entity.MealNutrition, entity.Nutrients) {
+	result := make([]entity.MealNutrition, 0, len(meals))
+
+	var perDay entity.Nutrients
+	for _, meal := range meals {
+		mealNutrition := entity.MealNutrition{
+			Name:        meal.Name,
+			TimeOfDay:   meal.TimeOfDay,
+			Slot:        meal.Slot,
+			Ingredients: make([]entity.IngredientNutrition, 0, len(meal.Ingredients)),
+		}
+
+		var mealTotal entity.Nutrients
+		for _, ingredient := range meal.Ingredients {
+			ingredientNutrition := computeIngredientNutrition(ingredient, foods)
+			if ingredientNutrition.Resolved {
+				mealTotal.Add(*ingredientNutrition.Nutrients)
+			} else {
+				*unresolved = append(*unresolved, prefix+meal.Name+": "+ingredient.Description)
+			}
+			mealNutrition.Ingredients = append(mealNutrition.Ingredients, ingredientNutrition)
+		}
+
+		perDay.Add(mealTotal)
+		mealNutrition.Nutrients = mealTotal.Rounded()
+		result = append(result, mealNutrition)
+	}
+
+	return result, perDay
+}
+
+// computeIngredientNutrition scales the per-100g nutrients to the prescribed
+// quantity. Substitutes are alternatives and do not count towards the totals.
+func computeIngredientNutrition(ingredient entity.Ingredient, foods map[string]*entity.Food) entity.IngredientNutrition {
+	result := entity.IngredientNutrition{
+		Description: ingredient.Description,
+		FoodID:      ingredient.FoodID,
+	}
+
+	food := foods[ingredient.FoodID]
+
+	per100g := ingredient.NutrientsPer100g
+	if per100g == nil && food != nil {
+		per100g = &food.Nutrients
+	}
+
+	grams, ok := units.IngredientGrams(ingredient, food)
+	if ok {
+		result.Grams = &grams
+	}
+
+	if per100g == nil || !ok {
+		return result
+	}
+
+	nutrients := per100g.Scale(grams / 100).Rounded()
+	result.Nutrients = &nutrients
+	result.Resolved = true
+	return result
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// requireActivePatient makes sure the nutritionist has an active link with the
+// patient before acting on the patient's diets.
+func requireActivePatient(ctx context.Context, linkRepo PatientLinkRepository, nutritionistID, patientEmail string) error {
+	_, err := activePatientLink(ctx, linkRepo, nutritionistID, patientEmail)
+	return err
+}
+
+// activePatientLink returns the active link between the nutritionist and the
+// patient email
+func activePatientLink(ctx context.Context, linkRepo PatientLinkRepository, nutritionistID, patientEmail string) (*entity.PatientLink, error) {
+	link, err := linkRepo.FindOpen(ctx, nutritionistID, normalizeEmail(patientEmail))
+	if err != nil {
+		return nil, err
+	}
+
+	if link == nil || link.Status != entity.PatientLinkActive {
+		return nil, ErrPatientNotLinked
+	}
+
+	return link, nil
+}
+
+// linkedPatient returns the patient of an active link of the nutritionist
+func linkedPatient(ctx context.Context, linkRepo PatientLinkRepository, userRepo UserRepository, nutritionistID, linkID string) (*entity.User, error) {
+	link, err := linkRepo.FindByID(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if link == nil || link.NutritionistID != nutritionistID {
+		return nil, ErrPatientLinkNotFound
+	}
+	if link.Status != entity.PatientLinkActive {
+		return nil, ErrPatientNotLinked
+	}
+
+	patient, err := linkPatient(ctx, userRepo, link)
+	if err != nil {
+		return nil, err
+	}
+	if patient == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return patient, nil
+}
+
+// linkPatient loads the patient by the ID filled when the invitation was
+// accepted, falling back to the email for links created before it
+func linkPatient(ctx context.Context, userRepo UserRepository, link *entity.PatientLink) (*entity.User, error) {
+	if link.PatientID != "" {
+		return userRepo.FindByID(ctx, link.PatientID)
+	}
+	return userRepo.FindByEmail(ctx, link.PatientEmail)
+}
+
+// activePatientEmails lists the emails of every active patient of the nutritionist
+func activePatientEmails(ctx context.Context, linkRepo PatientLinkRepository, nutritionistID string) ([]string, error) {
+	status := entity.PatientLinkActive
+	links, err := linkRepo.FindByNutritionist(ctx, nutritionistID, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]string, 0, len(links))
+	for _, link := range links {
+		emails = append(emails, link.PatientEmail)
+	}
+	return emails, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// RefreshTokenUseCase exchanges a refresh token for a new access/refresh pair
+type RefreshTokenUseCase interface {
+	Execute(ctx context.Context, refreshToken string) (*entity.LoginUseCaseOutput, error)
+}
+
+type refreshTokenUseCase struct {
+	userRepo    UserRepository
+	sessionRepo SessionRepository
+	tokens      *tokenIssuer
+}
+
+// NewRefreshToken creates a new instance of RefreshTokenUseCase
+func NewRefreshToken(userRepo UserRepository, sessionRepo SessionRepository, roleRepo RoleRepository, signer TokenSigner) RefreshTokenUseCase {
+	return &refreshTokenUseCase{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		tokens:      &tokenIssuer{signer: signer, roleRepo: roleRepo},
+	}
+}
+
+// Execute rotates the refresh token of the session it belongs to. Presenting a
+// token that was already rotated means it leaked, so the whole session is revoked.
+func (uc *refreshTokenUseCase) Execute(ctx context.Context, refreshToken string) (*entity.LoginUseCaseOutput, error) {
+	now := time.Now()
+	presentedHash := hashOpaqueToken(refreshToken)
+
+	session, err := uc.sessionRepo.FindByTokenHash(ctx, presentedHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if session == nil || !session.IsActive(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if session.RefreshTokenHash != presentedHash {
+		return nil, uc.revokeForReuse(ctx, session, now)
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	newToken, newHash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := now.Add(RefreshTokenDuration)
+	rotated, err := uc.sessionRepo.Rotate(ctx, session.ID, presentedHash, newHash, now, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Another request rotated the same token first: the token was used twice.
+	if !rotated {
+		return nil, uc.revokeForReuse(ctx, session, now)
+	}
+
+	accessToken, accessExpiresAt, err := uc.tokens.signAccessToken(ctx, user, session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.LoginUseCaseOutput{
+		Token:            accessToken,
+		ExpiresAt:        accessExpiresAt,
+		RefreshToken:     newToken,
+		RefreshExpiresAt: expiresAt,
+	}, nil
+}
+
+func (uc *refreshTokenUseCase) revokeForReuse(ctx context.Context, session *entity.Session, now time.Time) error {
+	log.Printf("[RefreshTokenUseCase] Refresh token reuse detected for session %s of user %s", session.ID, session.UserID)
+
+	if err := uc.sessionRepo.Revoke(ctx, session.ID, revokeReasonReuse, now); err != nil {
+		return err
+	}
+
+	return ErrRefreshTokenReused
+}
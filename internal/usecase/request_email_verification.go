@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+)
+
+// RequestEmailVerificationUseCase (re)sends the email verification link
+type RequestEmailVerificationUseCase interface {
+	Execute(ctx context.Context, email string) error
+}
+
+type requestEmailVerificationUseCase struct {
+	userRepo UserRepository
+	emails   *accountEmails
+}
+
+// NewRequestEmailVerification creates a new instance of RequestEmailVerificationUseCase
+func NewRequestEmailVerification(userRepo UserRepository, tokenRepo UserTokenRepository, mailer Mailer, baseURL string) RequestEmailVerificationUseCase {
+	return &requestEmailVerificationUseCase{
+		userRepo: userRepo,
+		emails:   newAccountEmails(tokenRepo, mailer, baseURL),
+	}
+}
+
+func (uc *requestEmailVerificationUseCase) Execute(ctx context.Context, email string) error {
+	user, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if user == nil || user.EmailVerified {
+		return nil
+	}
+
+	return uc.emails.sendEmailVerification(ctx, user)
+}
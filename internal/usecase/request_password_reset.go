@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"log"
+)
+
+// RequestPasswordResetUseCase emails a password reset link to the user
+type RequestPasswordResetUseCase interface {
+	Execute(ctx context.Context, email string) error
+}
+
+type requestPasswordResetUseCase struct {
+	userRepo UserRepository
+	emails   *accountEmails
+}
+
+// NewRequestPasswordReset creates a new instance of RequestPasswordResetUseCase
+func NewRequestPasswordReset(userRepo UserRepository, tokenRepo UserTokenRepository, mailer Mailer, baseURL string) RequestPasswordResetUseCase {
+	return &requestPasswordResetUseCase{
+		userRepo: userRepo,
+		emails:   newAccountEmails(tokenRepo, mailer, baseURL),
+	}
+}
+
+// Execute does not report unknown emails so the endpoint cannot be used to
+// discover which addresses have an account.
+func (uc *requestPasswordResetUseCase) Execute(ctx context.Context, email string) error {
+	user, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		log.Printf("[RequestPasswordResetUseCase] Password reset requested for unknown email")
+		return nil
+	}
+
+	return uc.emails.sendPasswordReset(ctx, user)
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ResetPasswordUseCase sets a new password using a token from a reset email
+type ResetPasswordUseCase interface {
+	Execute(ctx context.Context, input *entity.ResetPasswordInput) error
+}
+
+type resetPasswordUseCase struct {
+	userRepo    UserRepository
+	tokenRepo   UserTokenRepository
+	sessionRepo SessionRepository
+}
+
+// NewResetPassword creates a new instance of ResetPasswordUseCase
+func NewResetPassword(userRepo UserRepository, tokenRepo UserTokenRepository, sessionRepo SessionRepository) ResetPasswordUseCase {
+	return &resetPasswordUseCase{
+		userRepo:    userRepo,
+		tokenRepo:   tokenRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+// Execute consumes the token, stores the new password and logs the user out
+// of every existing session.
+func (uc *resetPasswordUseCase) Execute(ctx context.Context, input *entity.ResetPasswordInput) error {
+	now := time.Now()
+
+	token, err := uc.tokenRepo.Consume(ctx, hashOpaqueToken(input.Token), entity.PurposePasswordReset, now)
+	if err != nil {
+		return err
+	}
+
+	if token == nil {
+		return ErrInvalidUserToken
+	}
+
+	if err := uc.userRepo.UpdatePassword(ctx, token.UserID, input.PasswordHash); err != nil {
+		return err
+	}
+
+	// Receiving the reset email proves ownership of the address as well.
+	if err := uc.userRepo.MarkEmailVerified(ctx, token.UserID, now); err != nil {
+		return err
+	}
+
+	return uc.sessionRepo.RevokeAllForUser(ctx, token.UserID, revokeReasonPasswordReset, now)
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// RespondInvitationUseCase lets a patient accept or decline an invitation
+type RespondInvitationUseCase interface {
+	Execute(ctx context.Context, userID, linkID string, accept bool) (*entity.PatientLink, error)
+}
+
+type respondInvitationUseCase struct {
+	linkRepo PatientLinkRepository
+	userRepo UserRepository
+}
+
+// NewRespondInvitation creates a new instance of RespondInvitationUseCase
+func NewRespondInvitation(linkRepo PatientLinkRepository, userRepo UserRepository) RespondInvitationUseCase {
+	return &respondInvitationUseCase{
+		linkRepo: linkRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (uc *respondInvitationUseCase) Execute(ctx context.Context, userID, linkID string, accept bool) (*entity.PatientLink, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	link, err := uc.linkRepo.FindByID(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invitations addressed to someone else are reported as missing
+	if link == nil || link.PatientEmail != normalizeEmail(user.Email) {
+		return nil, ErrPatientLinkNotFound
+	}
+
+	if link.Status != entity.PatientLinkPending {
+		return nil, ErrInvalidLinkTransition
+	}
+
+	now := time.Now()
+	link.RespondedAt = &now
+	link.PatientID = userID
+	link.Status = entity.PatientLinkDeclined
+	if accept {
+		link.Status = entity.PatientLinkActive
+		link.StartedAt = &now
+	}
+
+	updated, err := uc.linkRepo.UpdateStatus(ctx, link, entity.PatientLinkPending)
+	if err != nil {
+		return nil, err
+	}
+
+	if !updated {
+		return nil, ErrInvalidLinkTransition
+	}
+
+	return link, nil
+}
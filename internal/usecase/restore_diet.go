@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// RestoreDietUseCase desfaz a remoção de uma dieta. Apenas o autor pode restaurá-la.
+type RestoreDietUseCase interface {
+	Execute(ctx context.Context, userID, dietID string) (*entity.Diet, error)
+}
+
+type restoreDietUseCase struct {
+	dietRepo  DietRepository
+	nutrition nutritionCalculator
+}
+
+// NewRestoreDiet cria uma nova instância de RestoreDietUseCase
+func NewRestoreDiet(dietRepo DietRepository, foodRepo FoodRepository, userRepo UserRepository) RestoreDietUseCase {
+	return &restoreDietUseCase{
+		dietRepo:  dietRepo,
+		nutrition: nutritionCalculator{foodRepo: foodRepo, userRepo: userRepo},
+	}
+}
+
+func (uc *restoreDietUseCase) Execute(ctx context.Context, userID, dietID string) (*entity.Diet, error) {
+	diet, err := uc.dietRepo.GetDietByID(ctx, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	if diet == nil {
+		return nil, ErrDietNotFound
+	}
+
+	if diet.CreatedBy != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if !diet.IsDeleted() {
+		return nil, ErrDietNotDeleted
+	}
+
+	// A dieta restaurada não pode coincidir com outra dieta ativa do paciente
+	err = writeInFreeCalendar(ctx, uc.dietRepo, diet, func() error {
+		return uc.dietRepo.RestoreDiet(ctx, diet.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := uc.dietRepo.GetDietByID(ctx, diet.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if restored == nil {
+		return nil, ErrDietNotFound
+	}
+
+	if err := uc.nutrition.annotate(ctx, restored); err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ReviewMealLogUseCase lets the nutritionist who created the diet review a
+// meal log, optionally leaving a note for the patient
+type ReviewMealLogUseCase interface {
+	Execute(ctx context.Context, userID, dietID, logID, note string) (*entity.MealLog, error)
+}
+
+type reviewMealLogUseCase struct {
+	dietRepo    DietRepository
+	mealLogRepo MealLogRepository
+}
+
+// NewReviewMealLog creates a new instance of ReviewMealLogUseCase
+func NewReviewMealLog(dietRepo DietRepository, mealLogRepo MealLogRepository) ReviewMealLogUseCase {
+	return &reviewMealLogUseCase{
+		dietRepo:    dietRepo,
+		mealLogRepo: mealLogRepo,
+	}
+}
+
+func (uc *reviewMealLogUseCase) Execute(ctx context.Context, userID, dietID, logID, note string) (*entity.MealLog, error) {
+	diet, err := uc.dietRepo.GetDietByID(ctx, dietID)
+	if err != nil {
+		return nil, err
+	}
+	if diet == nil || diet.IsDeleted() {
+		return nil, ErrDietNotFound
+	}
+	if diet.CreatedBy != userID {
+		return nil, ErrUnauthorized
+	}
+
+	log, err := findDietMealLog(ctx, uc.mealLogRepo, diet.ID, logID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := uc.mealLogRepo.UpdateReview(ctx, log.ID, userID, note, now); err != nil {
+		return nil, err
+	}
+
+	log.ReviewedBy = userID
+	log.ReviewedAt = &now
+	log.ReviewNote = note
+	return log, nil
+}
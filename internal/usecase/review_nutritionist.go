@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/constants"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// ReviewNutritionistUseCase approves or rejects a nutritionist account request
+type ReviewNutritionistUseCase interface {
+	Execute(ctx context.Context, input *entity.NutritionistReviewInput) error
+}
+
+type reviewNutritionistUseCase struct {
+	userRepo UserRepository
+}
+
+// NewReviewNutritionist creates a new instance of ReviewNutritionistUseCase
+func NewReviewNutritionist(userRepo UserRepository) ReviewNutritionistUseCase {
+	return &reviewNutritionistUseCase{userRepo: userRepo}
+}
+
+// Execute promotes the user to NUTRITIONIST on approval. Rejecting an
+// approved nutritionist demotes them back to DEFAULT.
+func (uc *reviewNutritionistUseCase) Execute(ctx context.Context, input *entity.NutritionistReviewInput) error {
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if user.NutritionistStatus == "" {
+		return ErrNoNutritionistRequest
+	}
+
+	status := entity.NutritionistRejected
+	userType := constants.TokenTypeDefault
+	if input.Approve {
+		status = entity.NutritionistApproved
+		userType = constants.TokenTypeNutritionist
+	}
+
+	// Do not demote admins or custom roles when rejecting
+	if !input.Approve && user.Type != constants.TokenTypeNutritionist {
+		userType = user.Type
+	}
+
+	return uc.userRepo.UpdateNutritionistReview(ctx, input.UserID, status, userType, input.ReviewerID, input.Note, time.Now())
+}
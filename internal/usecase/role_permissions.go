@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/victorgiudicissi/your-diet/internal/constants"
+)
+
+// resolvePermissions returns the permissions of the user's role, falling back
+// to the built-in defaults when the role was never stored.
+func resolvePermissions(ctx context.Context, roleRepo RoleRepository, userType string) ([]string, error) {
+	role, err := roleRepo.FindByName(ctx, userType)
+	if err != nil {
+		return nil, err
+	}
+
+	if role == nil {
+		return constants.GetPermissionsByUserType(userType), nil
+	}
+
+	return role.Permissions, nil
+}
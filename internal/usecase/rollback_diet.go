@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// RollbackDietUseCase restores the content of an old revision. The rollback is
+// itself recorded as a new revision, so the history is never rewritten.
+type RollbackDietUseCase interface {
+	Execute(ctx context.Context, userID, dietID string, number int, expectedVersion *int64) (*entity.Diet, error)
+}
+
+type rollbackDietUseCase struct {
+	dietRepo     DietRepository
+	linkRepo     PatientLinkRepository
+	revisionRepo DietRevisionRepository
+	nutrition    nutritionCalculator
+}
+
+// NewRollbackDiet cria uma nova instância de RollbackDietUseCase
+func NewRollbackDiet(dietRepo DietRepository, linkRepo PatientLinkRepository, revisionRepo DietRevisionRepository, foodRepo FoodRepository, userRepo UserRepository) RollbackDietUseCase {
+	return &rollbackDietUseCase{
+		dietRepo:     dietRepo,
+		linkRepo:     linkRepo,
+		revisionRepo: revisionRepo,
+		nutrition:    nutritionCalculator{foodRepo: foodRepo, userRepo: userRepo},
+	}
+}
+
+func (uc *rollbackDietUseCase) Execute(ctx context.Context, userID, dietID string, number int, expectedVersion *int64) (*entity.Diet, error) {
+	diet, err := uc.dietRepo.GetDietByID(ctx, dietID)
+	if err != nil {
+		return nil, err
+	}
+
+	if diet == nil || diet.IsDeleted() {
+		return nil, ErrDietNotFound
+	}
+
+	if diet.CreatedBy != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if diet.Status == string(entity.DietArchived) {
+		return nil, ErrDietArchived
+	}
+
+	if expectedVersion != nil && *expectedVersion != diet.Version {
+		return nil, ErrPreconditionFailed
+	}
+
+	if err := requireActivePatient(ctx, uc.linkRepo, diet.CreatedBy, diet.UserEmail); err != nil {
+		return nil, err
+	}
+
+	if err := ensureBaselineRevision(ctx, uc.revisionRepo, diet); err != nil {
+		return nil, err
+	}
+
+	revision, err := findRevision(ctx, uc.revisionRepo, diet, number)
+	if err != nil {
+		return nil, err
+	}
+
+	diet.ApplySnapshot(revision.Snapshot)
+	diet.UpdatedAt = time.Now()
+	refreshDietLifecycle(diet, diet.UpdatedAt)
+
+	err = writeInFreeCalendar(ctx, uc.dietRepo, diet, func() error {
+		return uc.dietRepo.UpdateDiet(ctx, diet, diet.Version)
+	})
+	if err != nil {
+		return nil, versionError(err, expectedVersion)
+	}
+
+	if _, err := recordRevision(ctx, uc.revisionRepo, diet, entity.RevisionRollback, userID, &revision.Number); err != nil {
+		return nil, err
+	}
+
+	if err := uc.nutrition.annotate(ctx, diet); err != nil {
+		return nil, err
+	}
+
+	return diet, nil
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/utils"
+)
+
+const (
+	defaultFoodSearchLimit = 20
+	maxFoodSearchLimit     = 100
+)
+
+// SearchFoodsUseCase searches the food catalog by name, ignoring case and accents
+type SearchFoodsUseCase interface {
+	Execute(ctx context.Context, query, source string, limit int) ([]*entity.Food, error)
+}
+
+type searchFoodsUseCase struct {
+	foodRepo FoodRepository
+}
+
+// NewSearchFoods creates a new instance of SearchFoodsUseCase
+func NewSearchFoods(foodRepo FoodRepository) SearchFoodsUseCase {
+	return &searchFoodsUseCase{
+		foodRepo: foodRepo,
+	}
+}
+
+func (uc *searchFoodsUseCase) Execute(ctx context.Context, query, source string, limit int) ([]*entity.Food, error) {
+	normalized := utils.NormalizeText(query)
+	if len(normalized) < 2 {
+		return nil, fmt.Errorf("%w: q must have at least 2 characters", ErrInvalidListParams)
+	}
+
+	switch {
+	case limit == 0:
+		limit = defaultFoodSearchLimit
+	case limit < 0 || limit > maxFoodSearchLimit:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidListParams, maxFoodSearchLimit)
+	}
+
+	filter := &FoodFilter{
+		Terms: strings.Fields(normalized),
+		Limit: int64(limit),
+	}
+
+	if source != "" {
+		foodSource := entity.FoodSource(strings.ToUpper(source))
+		if foodSource != entity.FoodSourceTACO && foodSource != entity.FoodSourceUSDA {
+			return nil, fmt.Errorf("%w: source must be TACO or USDA", ErrInvalidListParams)
+		}
+		filter.Source = &foodSource
+	}
+
+	return uc.foodRepo.Search(ctx, filter)
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/constants"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// SyncDefaultRolesUseCase seeds the built-in roles on startup
+type SyncDefaultRolesUseCase interface {
+	Execute(ctx context.Context) error
+}
+
+type syncDefaultRolesUseCase struct {
+	roleRepo RoleRepository
+}
+
+// NewSyncDefaultRoles creates a new instance of SyncDefaultRolesUseCase
+func NewSyncDefaultRoles(roleRepo RoleRepository) SyncDefaultRolesUseCase {
+	return &syncDefaultRolesUseCase{roleRepo: roleRepo}
+}
+
+// Execute creates missing built-in roles and grants built-in permissions that
+// were introduced since the role was last seeded. Permissions removed by an
+// admin are never granted again.
+func (uc *syncDefaultRolesUseCase) Execute(ctx context.Context) error {
+	for _, userType := range constants.UserTypes {
+		defaults := constants.GetPermissionsByUserType(userType)
+
+		role, err := uc.roleRepo.FindByName(ctx, userType)
+		if err != nil {
+			return err
+		}
+
+		if role == nil {
+			err := uc.roleRepo.Create(ctx, &entity.Role{
+				Name:              userType,
+				Description:       constants.GetRoleDescription(userType),
+				Permissions:       defaults,
+				SeededPermissions: defaults,
+				UpdatedAt:         time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if missing := difference(defaults, role.SeededPermissions); len(missing) > 0 {
+			if err := uc.roleRepo.AddSeededPermissions(ctx, userType, missing); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// difference returns the items of a that are not in b
+func difference(a, b []string) []string {
+	seen := make(map[string]bool, len(b))
+	for _, item := range b {
+		seen[item] = true
+	}
+
+	var result []string
+	for _, item := range a {
+		if !seen[item] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
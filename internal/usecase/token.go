@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+const (
+	// AccessTokenDuration is how long a signed access token is accepted
+	AccessTokenDuration = 15 * time.Minute
+	// RefreshTokenDuration is how long a session can be refreshed without a new login
+	RefreshTokenDuration = 30 * 24 * time.Hour
+
+	refreshTokenBytes = 32
+
+	revokeReasonLogout = "logout"
+	revokeReasonReuse  = "refresh_token_reuse"
+)
+
+// newOpaqueToken returns a random URL-safe token together with the hash that
+// must be persisted instead of the token itself.
+func newOpaqueToken() (string, string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashOpaqueToken(token), nil
+}
+
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenIssuer signs access tokens carrying the permissions of the user's role
+type tokenIssuer struct {
+	signer   TokenSigner
+	roleRepo RoleRepository
+}
+
+// signAccessToken mints a short-lived access token bound to the given session.
+func (t *tokenIssuer) signAccessToken(ctx context.Context, user *entity.User, sessionID string, now time.Time) (string, time.Time, error) {
+	permissions, err := resolvePermissions(ctx, t.roleRepo, user.Type)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expirationTime := now.Add(AccessTokenDuration)
+	claims := &Claims{
+		UserID:      user.ID.Hex(),
+		SessionID:   sessionID,
+		Permissions: permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Subject:   user.ID.Hex(),
+			ID:        uuid.NewString(),
+		},
+	}
+
+	tokenString, err := t.signer.Sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expirationTime, nil
+}
+
+// startSession opens a new session (token family) for the user and returns the
+// first access/refresh token pair.
+func (t *tokenIssuer) startSession(ctx context.Context, sessionRepo SessionRepository, user *entity.User, userAgent, ipAddress string) (*entity.LoginUseCaseOutput, error) {
+	now := time.Now()
+
+	refreshToken, refreshHash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &entity.Session{
+		ID:               uuid.NewString(),
+		UserID:           user.ID.Hex(),
+		RefreshTokenHash: refreshHash,
+		UsedTokenHashes:  []string{},
+		UserAgent:        userAgent,
+		IPAddress:        ipAddress,
+		CreatedAt:        now,
+		RefreshedAt:      now,
+		ExpiresAt:        now.Add(RefreshTokenDuration),
+	}
+
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	accessToken, expiresAt, err := t.signAccessToken(ctx, user, session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.LoginUseCaseOutput{
+		Token:            accessToken,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+	}, nil
+}
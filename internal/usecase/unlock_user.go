@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+)
+
+// UnlockUserUseCase lets an administrator lift a login lockout
+type UnlockUserUseCase interface {
+	Execute(ctx context.Context, userID string) error
+}
+
+type unlockUserUseCase struct {
+	userRepo UserRepository
+}
+
+// NewUnlockUser creates a new instance of UnlockUserUseCase
+func NewUnlockUser(userRepo UserRepository) UnlockUserUseCase {
+	return &unlockUserUseCase{userRepo: userRepo}
+}
+
+func (uc *unlockUserUseCase) Execute(ctx context.Context, userID string) error {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	return uc.userRepo.ResetFailedLogins(ctx, userID)
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// UpdateAnthropometricsUseCase lets a nutritionist inform the anthropometrics
+// of a linked patient
+type UpdateAnthropometricsUseCase interface {
+	Execute(ctx context.Context, nutritionistID, linkID string, anthropometrics *entity.Anthropometrics) (*entity.EnergyAssessment, error)
+}
+
+type updateAnthropometricsUseCase struct {
+	linkRepo PatientLinkRepository
+	userRepo UserRepository
+}
+
+// NewUpdateAnthropometrics creates a new instance of UpdateAnthropometricsUseCase
+func NewUpdateAnthropometrics(linkRepo PatientLinkRepository, userRepo UserRepository) UpdateAnthropometricsUseCase {
+	return &updateAnthropometricsUseCase{
+		linkRepo: linkRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (uc *updateAnthropometricsUseCase) Execute(ctx context.Context, nutritionistID, linkID string, anthropometrics *entity.Anthropometrics) (*entity.EnergyAssessment, error) {
+	patient, err := linkedPatient(ctx, uc.linkRepo, uc.userRepo, nutritionistID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if anthropometrics.Equation == "" {
+		anthropometrics.Equation = entity.EquationMifflinStJeor
+	}
+	if err := validateAnthropometrics(anthropometrics); err != nil {
+		return nil, err
+	}
+
+	anthropometrics.UpdatedBy = nutritionistID
+	anthropometrics.UpdatedAt = time.Now()
+
+	if err := uc.userRepo.UpdateAnthropometrics(ctx, patient.ID.Hex(), anthropometrics); err != nil {
+		return nil, err
+	}
+
+	patient.Anthropometrics = anthropometrics
+	return assessEnergy(patient, "")
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
 	"github.com/victorgiudicissi/your-diet/internal/entity"
 )
 
@@ -21,55 +22,104 @@ type UpdateDietUseCaseInput struct {
 
 // UpdateDietUseCase define a interface para o caso de uso de atualização de dieta
 type UpdateDietUseCase interface {
-	Execute(ctx context.Context, dietID string, newDiet *entity.Diet) (*entity.Diet, error)
+	// expectedVersion vem do If-Match; quando nil a versão lida é usada
+	Execute(ctx context.Context, dietID string, newDiet *entity.Diet, expectedVersion *int64) (*entity.Diet, error)
 }
 
 type updateDietUseCase struct {
-	dietRepo DietRepository
+	dietRepo     DietRepository
+	linkRepo     PatientLinkRepository
+	revisionRepo DietRevisionRepository
+	foodRepo     FoodRepository
+	userRepo     UserRepository
 }
 
 // NewUpdateDiet cria uma nova instância de UpdateDietUseCase
-func NewUpdateDiet(dietRepo DietRepository) UpdateDietUseCase {
+func NewUpdateDiet(dietRepo DietRepository, linkRepo PatientLinkRepository, revisionRepo DietRevisionRepository, foodRepo FoodRepository, userRepo UserRepository) UpdateDietUseCase {
 	return &updateDietUseCase{
-		dietRepo: dietRepo,
+		dietRepo:     dietRepo,
+		linkRepo:     linkRepo,
+		revisionRepo: revisionRepo,
+		foodRepo:     foodRepo,
+		userRepo:     userRepo,
 	}
 }
 
-func (uc *updateDietUseCase) Execute(ctx context.Context, dietID string, newDiet *entity.Diet) (*entity.Diet, error) {
+func (uc *updateDietUseCase) Execute(ctx context.Context, dietID string, newDiet *entity.Diet, expectedVersion *int64) (*entity.Diet, error) {
 	diet, err := uc.dietRepo.GetDietByID(ctx, dietID)
 	if err != nil {
 		return nil, err
 	}
 
+	if diet == nil || diet.IsDeleted() {
+		return nil, ErrDietNotFound
+	}
+
 	if newDiet.CreatedBy != diet.CreatedBy {
-		return nil, errors.New("usuário não autorizado a atualizar esta dieta")
+		return nil, ErrUnauthorized
+	}
+
+	if diet.Status == string(entity.DietArchived) {
+		return nil, ErrDietArchived
 	}
 
-	if newDiet.DietName != "" && newDiet.DietName != diet.DietName {
-		diet.DietName = newDiet.DietName
+	if expectedVersion != nil && *expectedVersion != diet.Version {
+		return nil, ErrPreconditionFailed
 	}
 
-	if newDiet.DurationInDays != 0 && newDiet.DurationInDays != diet.DurationInDays {
-		diet.DurationInDays = newDiet.DurationInDays
+	// O nutricionista só pode alterar dietas de pacientes com vínculo ativo
+	if err := requireActivePatient(ctx, uc.linkRepo, diet.CreatedBy, diet.UserEmail); err != nil {
+		return nil, err
 	}
 
-	if newDiet.Status != "" && newDiet.Status != diet.Status {
-		diet.Status = newDiet.Status
+	// Dietas anteriores ao histórico ganham uma revisão com o estado atual
+	if err := ensureBaselineRevision(ctx, uc.revisionRepo, diet); err != nil {
+		return nil, err
 	}
 
-	if len(newDiet.Meals) > 0 {
-		diet.Meals = newDiet.Meals
+	if err := validateFoodReferences(ctx, uc.foodRepo, dayplan.AllMeals(newDiet)); err != nil {
+		return nil, err
 	}
 
-	if newDiet.Observations != "" && newDiet.Observations != diet.Observations {
-		diet.Observations = newDiet.Observations
+	// O PUT substitui o conteúdo da dieta por inteiro; o paciente e o status
+	// não mudam, e sem data de início a atual é mantida
+	diet.DietName = newDiet.DietName
+	diet.DurationInDays = newDiet.DurationInDays
+	if newDiet.StartsAt != nil {
+		diet.StartsAt = newDiet.StartsAt
 	}
+	diet.Meals = newDiet.Meals
+	diet.Days = newDiet.Days
+	diet.DayRule = newDiet.DayRule
+	diet.Texture = newDiet.Texture
+	diet.Observations = newDiet.Observations
 
 	diet.UpdatedAt = time.Now()
+	refreshDietLifecycle(diet, diet.UpdatedAt)
+
+	err = writeInFreeCalendar(ctx, uc.dietRepo, diet, func() error {
+		return uc.dietRepo.UpdateDiet(ctx, diet, diet.Version)
+	})
+	if err != nil {
+		return nil, versionError(err, expectedVersion)
+	}
+
+	if _, err := recordRevision(ctx, uc.revisionRepo, diet, entity.RevisionUpdated, newDiet.CreatedBy, nil); err != nil {
+		return nil, err
+	}
 
-	if err := uc.dietRepo.UpdateDiet(ctx, diet); err != nil {
+	if err := (nutritionCalculator{foodRepo: uc.foodRepo, userRepo: uc.userRepo}).annotate(ctx, diet); err != nil {
 		return nil, err
 	}
 
 	return diet, nil
 }
+
+// versionError reports a lost race as a failed precondition when the client
+// sent If-Match, and as a conflict otherwise.
+func versionError(err error, expectedVersion *int64) error {
+	if errors.Is(err, ErrVersionConflict) && expectedVersion != nil {
+		return ErrPreconditionFailed
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/dayplan"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// UpdateDietTemplateUseCase replaces the plan of a template. Diets already
+// created from the template do not change.
+type UpdateDietTemplateUseCase interface {
+	Execute(ctx context.Context, userID, templateID string, template *entity.DietTemplate) (*entity.DietTemplate, error)
+}
+
+type updateDietTemplateUseCase struct {
+	templateRepo DietTemplateRepository
+	foodRepo     FoodRepository
+	nutrition    nutritionCalculator
+}
+
+// NewUpdateDietTemplate creates a new instance of UpdateDietTemplateUseCase
+func NewUpdateDietTemplate(templateRepo DietTemplateRepository, foodRepo FoodRepository) UpdateDietTemplateUseCase {
+	return &updateDietTemplateUseCase{
+		templateRepo: templateRepo,
+		foodRepo:     foodRepo,
+		nutrition:    nutritionCalculator{foodRepo: foodRepo},
+	}
+}
+
+func (uc *updateDietTemplateUseCase) Execute(ctx context.Context, userID, templateID string, template *entity.DietTemplate) (*entity.DietTemplate, error) {
+	existing, err := findOwnedTemplate(ctx, uc.templateRepo, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateFoodReferences(ctx, uc.foodRepo, dayplan.AllMeals(templateDiet(template))); err != nil {
+		return nil, err
+	}
+
+	template.ID = existing.ID
+	template.OwnerID = existing.OwnerID
+	template.CreatedAt = existing.CreatedAt
+	template.UpdatedAt = time.Now()
+
+	if err := uc.templateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	if err := annotateTemplates(ctx, uc.nutrition, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// UpdateExportTemplateUseCase replaces the export template of the nutritionist
+type UpdateExportTemplateUseCase interface {
+	Execute(ctx context.Context, userID string, template *entity.ExportTemplate) (*entity.ExportTemplate, error)
+}
+
+type updateExportTemplateUseCase struct {
+	exportTemplateRepo ExportTemplateRepository
+}
+
+// NewUpdateExportTemplate creates a new instance of UpdateExportTemplateUseCase
+func NewUpdateExportTemplate(exportTemplateRepo ExportTemplateRepository) UpdateExportTemplateUseCase {
+	return &updateExportTemplateUseCase{
+		exportTemplateRepo: exportTemplateRepo,
+	}
+}
+
+func (uc *updateExportTemplateUseCase) Execute(ctx context.Context, userID string, template *entity.ExportTemplate) (*entity.ExportTemplate, error) {
+	if template.AccentColor == "" {
+		template.AccentColor = defaultAccentColor
+	}
+	if template.HeaderLines == nil {
+		template.HeaderLines = []string{}
+	}
+
+	if err := validateExportTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if len(template.Logo) > 0 {
+		logo, err := shrinkLogo(template.Logo)
+		if err != nil {
+			return nil, err
+		}
+		template.Logo = logo
+	}
+
+	template.OwnerID = userID
+	template.UpdatedAt = time.Now()
+
+	if err := uc.exportTemplateRepo.Save(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
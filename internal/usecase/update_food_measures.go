@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+	"github.com/victorgiudicissi/your-diet/internal/units"
+)
+
+// UpdateFoodMeasuresUseCase replaces the density and the gram weights per
+// measure of a food of the catalog
+type UpdateFoodMeasuresUseCase interface {
+	Execute(ctx context.Context, id string, densityGPerMl float64, gramWeights map[string]float64) (*entity.Food, error)
+}
+
+type updateFoodMeasuresUseCase struct {
+	foodRepo FoodRepository
+}
+
+// NewUpdateFoodMeasures creates a new instance of UpdateFoodMeasuresUseCase
+func NewUpdateFoodMeasures(foodRepo FoodRepository) UpdateFoodMeasuresUseCase {
+	return &updateFoodMeasuresUseCase{
+		foodRepo: foodRepo,
+	}
+}
+
+func (uc *updateFoodMeasuresUseCase) Execute(ctx context.Context, id string, densityGPerMl float64, gramWeights map[string]float64) (*entity.Food, error) {
+	if densityGPerMl < 0 {
+		return nil, fmt.Errorf("%w: density_g_per_ml não pode ser negativa", ErrInvalidFoodMeasures)
+	}
+
+	// Normaliza as medidas para o código canônico ("Colheres de Sopa" -> "colher de sopa")
+	canonical := map[string]float64{}
+	for name, grams := range gramWeights {
+		unit, err := units.Parse(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unidade desconhecida %q", ErrInvalidFoodMeasures, name)
+		}
+		if grams <= 0 {
+			return nil, fmt.Errorf("%w: o peso de %q deve ser maior que zero", ErrInvalidFoodMeasures, name)
+		}
+		if unit.Kind == units.Mass {
+			return nil, fmt.Errorf("%w: %q já é uma unidade de massa", ErrInvalidFoodMeasures, name)
+		}
+		canonical[unit.Code] = grams
+	}
+
+	if err := uc.foodRepo.UpdateMeasures(ctx, id, densityGPerMl, canonical); err != nil {
+		return nil, err
+	}
+
+	food, err := uc.foodRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if food == nil {
+		return nil, ErrFoodNotFound
+	}
+
+	return food, nil
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// UpdateMeasurementUseCase replaces the values of a measurement. Only the
+// author of the measurement can change it.
+type UpdateMeasurementUseCase interface {
+	Execute(ctx context.Context, userID, linkID, measurementID string, measurement *entity.Measurement) (*entity.Measurement, error)
+}
+
+type updateMeasurementUseCase struct {
+	measurementRepo MeasurementRepository
+	linkRepo        PatientLinkRepository
+	userRepo        UserRepository
+}
+
+// NewUpdateMeasurement creates a new instance of UpdateMeasurementUseCase
+func NewUpdateMeasurement(measurementRepo MeasurementRepository, linkRepo PatientLinkRepository, userRepo UserRepository) UpdateMeasurementUseCase {
+	return &updateMeasurementUseCase{
+		measurementRepo: measurementRepo,
+		linkRepo:        linkRepo,
+		userRepo:        userRepo,
+	}
+}
+
+func (uc *updateMeasurementUseCase) Execute(ctx context.Context, userID, linkID, measurementID string, measurement *entity.Measurement) (*entity.Measurement, error) {
+	patient, source, err := measurementPatient(ctx, uc.linkRepo, uc.userRepo, userID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := findPatientMeasurement(ctx, uc.measurementRepo, patient.ID.Hex(), measurementID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.CreatedBy != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if err := validateMeasurement(measurement, source); err != nil {
+		return nil, err
+	}
+
+	measurement.ID = existing.ID
+	measurement.PatientID = existing.PatientID
+	measurement.Source = existing.Source
+	measurement.CreatedBy = existing.CreatedBy
+	measurement.CreatedAt = existing.CreatedAt
+	measurement.UpdatedAt = time.Now()
+	if measurement.MeasuredAt.IsZero() {
+		measurement.MeasuredAt = existing.MeasuredAt
+	}
+
+	if err := uc.measurementRepo.Update(ctx, measurement); err != nil {
+		return nil, err
+	}
+
+	deriveMeasurementMetrics(measurement, anthropometricHeight(patient))
+	return measurement, nil
+}
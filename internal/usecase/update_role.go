@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/constants"
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// UpdateRoleUseCase creates or edits a role and its permissions
+type UpdateRoleUseCase interface {
+	Execute(ctx context.Context, role *entity.Role) (*entity.Role, error)
+}
+
+type updateRoleUseCase struct {
+	roleRepo RoleRepository
+}
+
+// NewUpdateRole creates a new instance of UpdateRoleUseCase
+func NewUpdateRole(roleRepo RoleRepository) UpdateRoleUseCase {
+	return &updateRoleUseCase{roleRepo: roleRepo}
+}
+
+// Execute replaces the role permissions. Users pick up the change the next
+// time their access token is issued (login or refresh).
+func (uc *updateRoleUseCase) Execute(ctx context.Context, role *entity.Role) (*entity.Role, error) {
+	permissions := make([]string, 0, len(role.Permissions))
+	seen := map[string]bool{}
+	for _, permission := range role.Permissions {
+		if !constants.IsKnownPermission(permission) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPermission, permission)
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			permissions = append(permissions, permission)
+		}
+	}
+
+	role.Permissions = permissions
+	role.UpdatedAt = time.Now()
+
+	if err := uc.roleRepo.Upsert(ctx, role); err != nil {
+		return nil, err
+	}
+
+	return uc.roleRepo.FindByName(ctx, role.Name)
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+// ValidateSessionUseCase checks that the session bound to an access token is still active
+type ValidateSessionUseCase interface {
+	Execute(ctx context.Context, sessionID string) error
+}
+
+type validateSessionUseCase struct {
+	sessionRepo SessionRepository
+}
+
+// NewValidateSession creates a new instance of ValidateSessionUseCase
+func NewValidateSession(sessionRepo SessionRepository) ValidateSessionUseCase {
+	return &validateSessionUseCase{sessionRepo: sessionRepo}
+}
+
+func (uc *validateSessionUseCase) Execute(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return ErrSessionRevoked
+	}
+
+	session, err := uc.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session == nil || !session.IsActive(time.Now()) {
+		return ErrSessionRevoked
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorgiudicissi/your-diet/internal/entity"
+)
+
+// VerifyEmailUseCase confirms ownership of an email address
+type VerifyEmailUseCase interface {
+	Execute(ctx context.Context, token string) error
+}
+
+type verifyEmailUseCase struct {
+	userRepo  UserRepository
+	tokenRepo UserTokenRepository
+}
+
+// NewVerifyEmail creates a new instance of VerifyEmailUseCase
+func NewVerifyEmail(userRepo UserRepository, tokenRepo UserTokenRepository) VerifyEmailUseCase {
+	return &verifyEmailUseCase{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+	}
+}
+
+func (uc *verifyEmailUseCase) Execute(ctx context.Context, token string) error {
+	now := time.Now()
+
+	userToken, err := uc.tokenRepo.Consume(ctx, hashOpaqueToken(token), entity.PurposeEmailVerification, now)
+	if err != nil {
+		return err
+	}
+
+	if userToken == nil {
+		return ErrInvalidUserToken
+	}
+
+	return uc.userRepo.MarkEmailVerified(ctx, userToken.UserID, now)
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -10,6 +12,42 @@ type EnvConfig struct {
 	MongoURL string
 	DBName   string
 	Port     string
+
+	// JWTAlgorithm is the algorithm of the active signing key (HS256, RS256, EdDSA...)
+	JWTAlgorithm string
+	// JWTKeyID is the kid stamped on tokens signed with the active key
+	JWTKeyID string
+	// JWTSecret or JWTSecretFile hold the shared secret for HMAC algorithms
+	JWTSecret     string
+	JWTSecretFile string
+	// JWTPrivateKeyFile is the PEM encoded private key for asymmetric algorithms
+	JWTPrivateKeyFile string
+	// JWTVerificationKeys lists extra "kid:ALG:path" keys still accepted during rotation
+	JWTVerificationKeys string
+
+	// SMTPHost enables the SMTP mailer; when empty emails are only logged
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string
+	// AppBaseURL is the frontend URL used to build links sent by email
+	AppBaseURL string
+	// APIBaseURL is the public URL of this API, used to build links served by
+	// the API itself such as the calendar feed
+	APIBaseURL string
+	// RequireEmailVerification blocks login until the user verifies their email
+	RequireEmailVerification bool
+
+	// TrustedProxies are the proxies whose X-Forwarded-For header is used to find the client IP
+	TrustedProxies []string
+	// AuthRateLimitPerMinute and AuthRateLimitBurst throttle the public auth endpoints per client IP
+	AuthRateLimitPerMinute int
+	AuthRateLimitBurst     int
+
+	// DietLifecycleIntervalMinutes is how often scheduled diets are started and
+	// finished diets are expired
+	DietLifecycleIntervalMinutes int
 }
 
 func LoadEnvConfig() *EnvConfig {
@@ -38,9 +76,63 @@ func LoadEnvConfig() *EnvConfig {
 		panic("PORT is not set")
 	}
 
+	jwtAlgorithm := getEnvOrDefault("JWT_ALGORITHM", "HS256")
+	jwtSecret := os.Getenv("JWT_SECRET")
+	jwtSecretFile := os.Getenv("JWT_SECRET_FILE")
+	jwtPrivateKeyFile := os.Getenv("JWT_PRIVATE_KEY_FILE")
+
+	if strings.HasPrefix(jwtAlgorithm, "HS") {
+		if jwtSecret == "" && jwtSecretFile == "" {
+			panic("JWT_SECRET or JWT_SECRET_FILE is not set")
+		}
+	} else if jwtPrivateKeyFile == "" {
+		panic("JWT_PRIVATE_KEY_FILE is not set")
+	}
+
 	return &EnvConfig{
-		MongoURL: mongoURL,
-		DBName:   dbName,
-		Port:     port,
+		MongoURL:            mongoURL,
+		DBName:              dbName,
+		Port:                port,
+		JWTAlgorithm:        jwtAlgorithm,
+		JWTKeyID:            getEnvOrDefault("JWT_KEY_ID", "default"),
+		JWTSecret:           jwtSecret,
+		JWTSecretFile:       jwtSecretFile,
+		JWTPrivateKeyFile:   jwtPrivateKeyFile,
+		JWTVerificationKeys: os.Getenv("JWT_VERIFICATION_KEYS"),
+
+		SMTPHost:                 os.Getenv("SMTP_HOST"),
+		SMTPPort:                 getEnvOrDefault("SMTP_PORT", "587"),
+		SMTPUsername:             os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:             os.Getenv("SMTP_PASSWORD"),
+		MailFrom:                 getEnvOrDefault("MAIL_FROM", "Your Diet <no-reply@your-diet.app>"),
+		AppBaseURL:               getEnvOrDefault("APP_BASE_URL", "http://localhost:5173"),
+		APIBaseURL:               getEnvOrDefault("API_BASE_URL", "http://localhost:"+port),
+		RequireEmailVerification: os.Getenv("REQUIRE_EMAIL_VERIFICATION") == "true",
+
+		TrustedProxies:         strings.Split(getEnvOrDefault("TRUSTED_PROXIES", "127.0.0.1"), ","),
+		AuthRateLimitPerMinute: getEnvIntOrDefault("AUTH_RATE_LIMIT_PER_MINUTE", 10),
+		AuthRateLimitBurst:     getEnvIntOrDefault("AUTH_RATE_LIMIT_BURST", 5),
+
+		DietLifecycleIntervalMinutes: getEnvIntOrDefault("DIET_LIFECYCLE_INTERVAL_MINUTES", 15),
+	}
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		panic(key + " must be a positive integer")
 	}
+	return parsed
 }